@@ -4,7 +4,6 @@ go 1.21
 
 require (
 	github.com/TheJumpCloud/jcapi-go v3.0.0+incompatible
-	github.com/go-resty/resty/v2 v2.7.0
 	github.com/hashicorp/terraform v0.12.26
 	github.com/hashicorp/terraform-plugin-docs v0.14.1
 	github.com/hashicorp/terraform-plugin-sdk v1.13.1
@@ -1,26 +1,221 @@
 package jumpcloud
 
-import jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+)
 
 const (
 	headerAccept = "application/json"
+
+	// defaultAPIURL is the console of JumpCloud's production environment
+	defaultAPIURL = "https://console.jumpcloud.com"
+
+	// defaultHTTPTimeout bounds a single request, so a stalled connection
+	// doesn't hang the provider until Terraform gives up
+	defaultHTTPTimeout = 2 * time.Minute
 )
 
+// HTTPClient sends the requests of the provider that don't go through the
+// JumpCloud SDK. *http.Client implements it; tests and embedders can set
+// Config.HTTPClient to stub responses or route requests differently.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// newHTTPClient returns the client used unless Config.HTTPClient is set.
+// Requests go through proxyURL if set, otherwise through the proxy of the
+// environment variables like with http.DefaultClient. caCertPEM is
+// trusted in addition to the system's CAs, insecure skips verifying the
+// certificate of the API altogether.
+func newHTTPClient(insecure bool, proxyURL, caCertPEM string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if insecure || caCertPEM != "" {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecure}
+		if caCertPEM != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+				return nil, errors.New("ca_cert_pem contains no valid PEM encoded certificate")
+			}
+			transport.TLSClientConfig.RootCAs = pool
+		}
+	}
+
+	return &http.Client{Transport: transport, Timeout: defaultHTTPTimeout}, nil
+}
+
+// validateCACertPEM checks at plan time that ca_cert_pem holds at least
+// one certificate, empty means none is configured
+func validateCACertPEM(v interface{}, k string) (ws []string, es []error) {
+	if v.(string) != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(v.(string))) {
+		es = append(es, fmt.Errorf("%s contains no valid PEM encoded certificate", k))
+	}
+	return
+}
+
+// ProviderVersion is the version of the provider, set by main from the
+// version the release was built with
+var ProviderVersion = "dev"
+
+// userAgent is the User-Agent header sent with every request, the provider
+// and its version followed by the user_agent_suffix, if any
+func userAgent(suffix string) string {
+	ua := "terraform-provider-jumpcloud/" + ProviderVersion
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
 // Config holds the JC configuration
 type Config struct {
 	APIKey string // User specific auth token
 	OrgID  string // Organization ID
+	APIURL string // Console URL the API is served from, e.g. https://console.jumpcloud.com
+
+	UserAgentSuffix string // Appended to the provider's User-Agent header
+
+	Insecure  bool   // Skip verifying the TLS certificate of the API
+	ProxyURL  string // Proxy requests are sent through, the environment's if empty
+	CACertPEM string // Additionally trusted CA certificates
+
+	// StopContext is done once Terraform asks the provider to stop, e.g.
+	// when an apply is interrupted
+	StopContext context.Context
+
+	// HTTPClient sends the raw API requests, a client built from the TLS
+	// and proxy options if nil. If it is an *http.Client the SDK clients
+	// use it too.
+	HTTPClient HTTPClient
+
+	MemberNotFoundBehavior   string // What to do with member emails that don't exist
+	MaxMemberRemovalPerApply int    // Members that may be removed from a group at once, 0 is unlimited
+	UseBulkOperations        bool   // Whether group members are managed through the bulk endpoint
+	MaxRetries               int    // Retries of requests that were rate limited
+	RetryBaseDelayMS         int    // First delay between retries, doubled for every further one
+	MemberConcurrency        int    // Group members managed in parallel without the bulk endpoint
+	CacheUserLookups         bool   // Whether user emails and IDs are resolved once per run
+	EmailLookupBatchSize     int    // User emails or IDs resolved per request
+}
+
+// ProviderSettings holds the provider options that have no place in the
+// jcapiv2.Configuration of the SDK
+type ProviderSettings struct {
+	MemberNotFoundBehavior   string
+	MaxMemberRemovalPerApply int
+	UseBulkOperations        bool
+	MaxRetries               int
+	RetryBaseDelay           time.Duration
+	MemberConcurrency        int
+	EmailLookupBatchSize     int
+
+	userCache   *userCache      // nil if disabled
+	stopContext context.Context // nil outside of the provider
+	httpClient  HTTPClient
+}
+
+var defaultProviderSettings = ProviderSettings{
+	MemberNotFoundBehavior: "error",
+	UseBulkOperations:      true,
+	MaxRetries:             5,
+	RetryBaseDelay:         time.Second,
+	MemberConcurrency:      10,
+	EmailLookupBatchSize:   50,
+	httpClient:             &http.Client{Timeout: defaultHTTPTimeout},
 }
 
-// Client instantiates a jcapiv2.Configuration struct that is passed
-// to every Resource operation
+// Meta is passed to every Resource operation. It embeds the configuration
+// of the SDK clients, along with the options of the provider.
+type Meta struct {
+	*jcapiv2.Configuration
+	Settings ProviderSettings
+
+	// bulkUnsupported is set once the API turned out not to offer the bulk
+	// membership endpoint, so it is only probed once
+	bulkUnsupported atomic.Bool
+}
+
+// newMeta returns the meta of config with the default settings
+func newMeta(config *jcapiv2.Configuration) *Meta {
+	return &Meta{Configuration: config, Settings: defaultProviderSettings}
+}
+
+// requestContext returns the context the API calls made with config run
+// in. It is done once Terraform stops the provider, so interrupted applies
+// don't hang on requests or waits between them.
+func requestContext(config *Meta) context.Context {
+	if ctx := config.Settings.stopContext; ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// Client instantiates the Meta that is passed to every Resource operation
 func (c *Config) Client() (interface{}, error) {
 	config := jcapiv2.NewConfiguration()
+	if c.APIURL != "" {
+		config.BasePath = strings.TrimSuffix(c.APIURL, "/") + "/api/v2"
+	}
 	config.AddDefaultHeader("x-api-key", c.APIKey)
+	config.UserAgent = userAgent(c.UserAgentSuffix)
 
 	if c.OrgID != "" {
 		config.AddDefaultHeader("x-org-id", c.OrgID)
 	}
+
+	meta := newMeta(config)
+	settings := &meta.Settings
+	if c.MemberNotFoundBehavior != "" {
+		settings.MemberNotFoundBehavior = c.MemberNotFoundBehavior
+	}
+	settings.MaxMemberRemovalPerApply = c.MaxMemberRemovalPerApply
+	settings.UseBulkOperations = c.UseBulkOperations
+	settings.MaxRetries = c.MaxRetries
+	settings.RetryBaseDelay = time.Duration(c.RetryBaseDelayMS) * time.Millisecond
+	if c.MemberConcurrency > 0 {
+		settings.MemberConcurrency = c.MemberConcurrency
+	}
+	if c.EmailLookupBatchSize > 0 {
+		settings.EmailLookupBatchSize = c.EmailLookupBatchSize
+	}
+	if c.CacheUserLookups {
+		settings.userCache = newUserCache()
+	}
+	settings.stopContext = c.StopContext
+	settings.httpClient = c.HTTPClient
+	if settings.httpClient == nil {
+		client, err := newHTTPClient(c.Insecure, c.ProxyURL, c.CACertPEM)
+		if err != nil {
+			return nil, err
+		}
+		settings.httpClient = client
+	}
+	if client, ok := settings.httpClient.(*http.Client); ok {
+		config.HTTPClient = client
+	}
+
 	// Instantiate the API client
-	return config, nil
+	return meta, nil
 }
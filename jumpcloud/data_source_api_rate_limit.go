@@ -0,0 +1,106 @@
+package jumpcloud
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudAPIRateLimit() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to check the JumpCloud API rate limit status of the configured API key.",
+		Read:        dataSourceJumpCloudAPIRateLimitRead,
+		Schema: map[string]*schema.Schema{
+			"requests_remaining": {
+				Description: "The number of requests left in the current window.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"requests_limit": {
+				Description: "The number of requests allowed per window.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"reset_at": {
+				Description: "The Unix timestamp at which the current window resets.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"window_seconds": {
+				Description: "The length of the rate limit window in seconds, 0 if not reported by the API.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type rateLimitStatus struct {
+	Remaining int
+	Limit     int
+	ResetAt   int64
+	Window    int
+}
+
+// parseRateLimitHeaders reads the X-RateLimit-* headers of a JumpCloud API
+// response. The reset header is either a Unix timestamp or, for small
+// values, the number of seconds until the window resets.
+func parseRateLimitHeaders(h http.Header, now time.Time) rateLimitStatus {
+	status := rateLimitStatus{}
+	status.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	status.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	status.Window, _ = strconv.Atoi(h.Get("X-RateLimit-Window"))
+
+	reset, _ := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if reset > 0 && reset < now.Unix()/2 {
+		reset += now.Unix()
+	}
+	status.ResetAt = reset
+	return status
+}
+
+func dataSourceJumpCloudAPIRateLimitRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	// any cheap request returns the rate limit headers
+	req, err := http.NewRequestWithContext(requestContext(config), http.MethodGet, config.BasePath+"/usergroups?limit=1", nil)
+	if err != nil {
+		return err
+	}
+
+	addDefaultHeaders(req, config)
+	req.Header.Add("Accept", "application/json")
+
+	res, err := config.Settings.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// a rate limited request still reports the limit, any other failure,
+	// e.g. a bad API key, doesn't
+	if res.StatusCode >= http.StatusMultipleChoices && res.StatusCode != http.StatusTooManyRequests {
+		body, _ := io.ReadAll(res.Body)
+		return newAPIError(res, body)
+	}
+
+	status := parseRateLimitHeaders(res.Header, time.Now())
+
+	d.SetId("rate_limit")
+	if err := d.Set("requests_remaining", status.Remaining); err != nil {
+		return err
+	}
+	if err := d.Set("requests_limit", status.Limit); err != nil {
+		return err
+	}
+	if err := d.Set("reset_at", int(status.ResetAt)); err != nil {
+		return err
+	}
+	if err := d.Set("window_seconds", status.Window); err != nil {
+		return err
+	}
+	return nil
+}
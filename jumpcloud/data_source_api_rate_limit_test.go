@@ -0,0 +1,76 @@
+package jumpcloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	cases := []struct {
+		Headers map[string]string
+		Status  rateLimitStatus
+	}{
+		{map[string]string{}, rateLimitStatus{}},
+		{
+			map[string]string{
+				"X-RateLimit-Limit":     "500",
+				"X-RateLimit-Remaining": "120",
+				"X-RateLimit-Reset":     "30",
+				"X-RateLimit-Window":    "60",
+			},
+			rateLimitStatus{Remaining: 120, Limit: 500, ResetAt: 1700000030, Window: 60},
+		},
+		{
+			map[string]string{
+				"X-RateLimit-Limit":     "500",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     "1700000045",
+			},
+			rateLimitStatus{Remaining: 0, Limit: 500, ResetAt: 1700000045},
+		},
+	}
+
+	for _, c := range cases {
+		h := http.Header{}
+		for k, v := range c.Headers {
+			h.Set(k, v)
+		}
+		assert.Equal(t, c.Status, parseRateLimitHeaders(h, now))
+	}
+}
+
+func TestDataSourceAPIRateLimitRead(t *testing.T) {
+	status := http.StatusOK
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("X-RateLimit-Limit", "100")
+		rw.Header().Set("X-RateLimit-Remaining", "0")
+		rw.WriteHeader(status)
+		if status == http.StatusUnauthorized {
+			rw.Write([]byte(`{"message": "Unauthorized"}`))
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := dataSourceJumpCloudAPIRateLimit()
+
+	// rate limited, which still reports the limit
+	status = http.StatusTooManyRequests
+	d := r.TestResourceData()
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, 100, d.Get("requests_limit"))
+	assert.Equal(t, 0, d.Get("requests_remaining"))
+
+	status = http.StatusUnauthorized
+	d = r.TestResourceData()
+	assert.EqualError(t, r.Read(d, config), "GET /usergroups: 401 Unauthorized: Unauthorized")
+	assert.Empty(t, d.Id())
+}
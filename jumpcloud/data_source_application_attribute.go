@@ -0,0 +1,94 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudApplicationAttribute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to read a single configuration attribute of a JumpCloud application.",
+		Read:        dataSourceJumpCloudApplicationAttributeRead,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the application.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"attribute_name": {
+				Description: "The dot separated path of the attribute in the application, e.g. `config.acsUrl.value` or `sso.idpCertExpirationAt`. List elements are addressed by index.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"value": {
+				Description: "The value of the attribute. Values that aren't strings are JSON encoded.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// lookupAttribute walks a decoded JSON object along a dot separated path
+func lookupAttribute(object interface{}, path string) (string, bool) {
+	current := object
+	for _, key := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[key]
+			if !ok {
+				return "", false
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", false
+			}
+			current = v[i]
+		default:
+			return "", false
+		}
+	}
+
+	if s, ok := current.(string); ok {
+		return s, true
+	}
+	b, err := json.Marshal(current)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+func dataSourceJumpCloudApplicationAttributeRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	applicationID := d.Get("application_id").(string)
+	name := d.Get("attribute_name").(string)
+
+	// the raw application is used, as the attributes depend on its type
+	var application map[string]interface{}
+	ok, err := jumpCloudV1Request(config, http.MethodGet, "/applications/"+applicationID, nil, &application)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("application %s not found", applicationID)
+	}
+
+	value, ok := lookupAttribute(application, name)
+	if !ok {
+		return fmt.Errorf("application %s has no attribute %s", applicationID, name)
+	}
+
+	d.SetId(applicationID + "/" + name)
+	if err := d.Set("value", value); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceJumpCloudApplicationAttribute(t *testing.T) {
+	applicationID := os.Getenv("JUMPCLOUD_SAML_APPLICATION_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SAML_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "jumpcloud_application_attribute" "test" {
+  application_id = "%s"
+  attribute_name = "config.acsUrl.value"
+}`, applicationID),
+				Check: resource.TestCheckResourceAttrSet("data.jumpcloud_application_attribute.test", "value"),
+			},
+		},
+	})
+}
+
+func TestLookupAttribute(t *testing.T) {
+	var application map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"name": "saml",
+		"beta": false,
+		"config": {
+			"acsUrl": {"value": "https://sp.example.com/acs"},
+			"constantAttributes": {"value": [{"name": "role", "value": "admin"}]}
+		}
+	}`), &application)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		Path  string
+		Value string
+		OK    bool
+	}{
+		{"name", "saml", true},
+		{"beta", "false", true},
+		{"config.acsUrl.value", "https://sp.example.com/acs", true},
+		{"config.constantAttributes.value.0.value", "admin", true},
+		{"config.acsUrl", `{"value":"https://sp.example.com/acs"}`, true},
+		{"config.constantAttributes.value.1", "", false},
+		{"config.missing", "", false},
+		{"name.value", "", false},
+	}
+
+	for _, c := range cases {
+		value, ok := lookupAttribute(application, c.Path)
+		assert.Equal(t, c.OK, ok, c.Path)
+		assert.Equal(t, c.Value, value, c.Path)
+	}
+}
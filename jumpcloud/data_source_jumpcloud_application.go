@@ -1,12 +1,10 @@
 package jumpcloud
 
 import (
-	"context"
 	"fmt"
 	"log"
 
 	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
-	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -32,7 +30,8 @@ func dataSourceJumpCloudApplication() *schema.Resource {
 
 func dataSourceJumpCloudApplicationRead(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[DEBUG] Starting dataSourceJumpCloudApplicationRead")
-	configv1 := convertV2toV1Config(m.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 	applicationName, nameExists := d.GetOk("name")
 	displayLabel, displayLabelExists := d.GetOk("display_label")
@@ -41,7 +40,7 @@ func dataSourceJumpCloudApplicationRead(d *schema.ResourceData, m interface{}) e
 		return fmt.Errorf("either name or display_label must be provided")
 	}
 
-	applicationsResponse, _, err := client.ApplicationsApi.ApplicationsList(context.Background(), "_id, displayName, displayLabel", "", nil)
+	applicationsResponse, _, err := client.ApplicationsApi.ApplicationsList(ctx, "_id, displayName, displayLabel", "", nil)
 
 	if err != nil {
 		return err
@@ -0,0 +1,142 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"strings"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudLdapServer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to look up the LDAP server of the organization, e.g. to associate " +
+			"user groups with it or to configure LDAP clients.",
+		Read: dataSourceJumpCloudLdapServerRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the LDAP server. Only needed if the organization has more than one.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"organization": {
+				Description: "The ID of the organization the LDAP server belongs to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"base_dn": {
+				Description: "The base DN of the users of the LDAP server, e.g. `ou=Users,o=<organization>,dc=jumpcloud,dc=com`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"user_lockout_action": {
+				Description: "What happens to locked out users in LDAP, `disable` or `remove`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"user_password_expiration_action": {
+				Description: "What happens to users with an expired password in LDAP, `disable` or `remove`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ldapBaseDN is the base DN of the users in the LDAP directory of the
+// organization, which is the same for all of its LDAP servers
+func ldapBaseDN(organizationID string) string {
+	return "ou=Users,o=" + organizationID + ",dc=jumpcloud,dc=com"
+}
+
+// getOrganizationID returns the organization the provider manages, which
+// is set for MSP admins and otherwise the only one the API key has access to
+func getOrganizationID(config *Meta) (string, error) {
+	ctx := requestContext(config)
+	if orgID := config.DefaultHeader["x-org-id"]; orgID != "" {
+		return orgID, nil
+	}
+
+	client := jcapiv1.NewAPIClient(convertV2toV1Config(config))
+	orgs, res, err := client.OrganizationsApi.OrganizationList(ctx, "", "", map[string]interface{}{
+		"fields": "_id displayName",
+		"limit":  int32(2),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error listing organizations: %w", apiError(res, err))
+	}
+	if len(orgs.Results) != 1 {
+		return "", fmt.Errorf("expected the API key to have access to one organization, found %d, "+
+			"set org_id in the provider configuration", len(orgs.Results))
+	}
+	return orgs.Results[0].Id, nil
+}
+
+// singleLdapServer returns the LDAP server named name, or the only one if
+// name is empty
+func singleLdapServer(servers []jcapiv2.LdapServerOutput, name string) (*jcapiv2.LdapServerOutput, error) {
+	var found []jcapiv2.LdapServerOutput
+	for _, server := range servers {
+		if name == "" || server.Name == name {
+			found = append(found, server)
+		}
+	}
+
+	switch {
+	case len(found) == 1:
+		return &found[0], nil
+	case name != "":
+		return nil, fmt.Errorf("expected one LDAP server named %s, found %d", name, len(found))
+	case len(found) == 0:
+		return nil, fmt.Errorf("no LDAP server found")
+	default:
+		names := make([]string, len(found))
+		for i, server := range found {
+			names[i] = server.Name
+		}
+		return nil, fmt.Errorf("more than one LDAP server found, set name to one of: %s", strings.Join(names, ", "))
+	}
+}
+
+func dataSourceJumpCloudLdapServerRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	servers, res, err := client.LDAPServersApi.LdapserversList(ctx, "", "", map[string]interface{}{
+		"limit": int32(pageSize),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing LDAP servers: %w", apiError(res, err))
+	}
+
+	server, err := singleLdapServer(servers, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	orgID, err := getOrganizationID(config)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(server.Id)
+	if err := d.Set("name", server.Name); err != nil {
+		return err
+	}
+	if err := d.Set("organization", orgID); err != nil {
+		return err
+	}
+	if err := d.Set("base_dn", ldapBaseDN(orgID)); err != nil {
+		return err
+	}
+	if err := d.Set("user_lockout_action", server.UserLockoutAction); err != nil {
+		return err
+	}
+	if err := d.Set("user_password_expiration_action", server.UserPasswordExpirationAction); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceLdapServer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "jumpcloud_ldap_server" "ldap" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.jumpcloud_ldap_server.ldap", "id"),
+					resource.TestCheckResourceAttrSet("data.jumpcloud_ldap_server.ldap", "base_dn"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceLdapServerRead(t *testing.T) {
+	servers := []jcapiv2.LdapServerOutput{
+		{Id: "ldap1", Name: "JumpCloud LDAP", UserLockoutAction: "disable", UserPasswordExpirationAction: "remove"},
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/ldapservers":
+			assert.NoError(t, json.NewEncoder(rw).Encode(servers))
+		case "/organizations":
+			assert.NoError(t, json.NewEncoder(rw).Encode(jcapiv1.Organizationslist{
+				Results:    []jcapiv1.OrganizationslistResults{{Id: "org1"}},
+				TotalCount: 1,
+			}))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/v2"
+	r := dataSourceJumpCloudLdapServer()
+
+	// the only server is found without a name
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "ldap1", d.Id())
+	assert.Equal(t, "JumpCloud LDAP", d.Get("name"))
+	assert.Equal(t, "org1", d.Get("organization"))
+	assert.Equal(t, "ou=Users,o=org1,dc=jumpcloud,dc=com", d.Get("base_dn"))
+	assert.Equal(t, "disable", d.Get("user_lockout_action"))
+	assert.Equal(t, "remove", d.Get("user_password_expiration_action"))
+
+	// MSP admins manage the organization they configured
+	config.AddDefaultHeader("x-org-id", "org2")
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "ou=Users,o=org2,dc=jumpcloud,dc=com", d.Get("base_dn"))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "other"})
+	assert.EqualError(t, r.Read(d, config), "expected one LDAP server named other, found 0")
+
+	// several servers need a name
+	servers = append(servers, jcapiv2.LdapServerOutput{Id: "ldap2", Name: "other"})
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.EqualError(t, r.Read(d, config), "more than one LDAP server found, set name to one of: JumpCloud LDAP, other")
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "other"})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "ldap2", d.Id())
+}
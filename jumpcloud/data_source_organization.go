@@ -0,0 +1,95 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudOrganization() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the JumpCloud organization the provider manages, i.e. the one " +
+			"of `org_id` or the only one the API key has access to.",
+		Read: dataSourceJumpCloudOrganizationRead,
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description: "The name of the organization.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"settings": {
+				Description: "A summary of the settings of the organization.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"contact_name": {
+							Description: "The name of the organization's contact.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"contact_email": {
+							Description: "The e-mail address of the organization's contact.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"password_min_length": {
+							Description: "The minimum length of user passwords, 0 if not enforced.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"password_expires_days": {
+							Description: "The number of days after which user passwords expire, 0 if they don't.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenOrganizationSettings(settings OrganizationSettings) []interface{} {
+	policy := settings.PasswordPolicy
+	minLength := 0
+	if policy.EnableMinLength {
+		minLength = policy.MinLength
+	}
+	expiresDays := 0
+	if policy.EnablePasswordExpirationInDays {
+		expiresDays = policy.PasswordExpirationInDays
+	}
+	return []interface{}{map[string]interface{}{
+		"contact_name":          settings.ContactName,
+		"contact_email":         settings.ContactEmail,
+		"password_min_length":   minLength,
+		"password_expires_days": expiresDays,
+	}}
+}
+
+func dataSourceJumpCloudOrganizationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	orgID, err := getOrganizationID(config)
+	if err != nil {
+		return err
+	}
+
+	// the v2 API has no endpoint for the organization itself
+	var org Organization
+	ok, err := jumpCloudV1Request(config, http.MethodGet, "/organizations/"+orgID, nil, &org)
+	if err != nil {
+		return fmt.Errorf("error reading organization %s: %w", orgID, err)
+	}
+	if !ok {
+		return fmt.Errorf("organization %s not found, check org_id in the provider configuration", orgID)
+	}
+
+	d.SetId(orgID)
+	if err := d.Set("display_name", org.DisplayName); err != nil {
+		return err
+	}
+	return d.Set("settings", flattenOrganizationSettings(org.Settings))
+}
@@ -0,0 +1,84 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceOrganization(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "jumpcloud_organization" "current" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.jumpcloud_organization.current", "id"),
+					resource.TestCheckResourceAttrSet("data.jumpcloud_organization.current", "display_name"),
+					resource.TestCheckResourceAttr("data.jumpcloud_organization.current", "settings.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceOrganizationRead(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/organizations":
+			assert.NoError(t, json.NewEncoder(rw).Encode(jcapiv1.Organizationslist{
+				Results:    []jcapiv1.OrganizationslistResults{{Id: "org1"}},
+				TotalCount: 1,
+			}))
+		case "/organizations/org1":
+			rw.Write([]byte(`{
+				"_id": "org1",
+				"displayName": "Acme",
+				"settings": {
+					"contactName": "Jane Doe",
+					"contactEmail": "it@acme.org",
+					"passwordPolicy": {
+						"enableMinLength": true,
+						"minLength": 12,
+						"enablePasswordExpirationInDays": false,
+						"passwordExpirationInDays": 90
+					}
+				}
+			}`))
+		case "/organizations/org2":
+			rw.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/v2"
+	r := dataSourceJumpCloudOrganization()
+
+	// the only organization of the API key
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "org1", d.Id())
+	assert.Equal(t, "Acme", d.Get("display_name"))
+	assert.Equal(t, "Jane Doe", d.Get("settings.0.contact_name"))
+	assert.Equal(t, "it@acme.org", d.Get("settings.0.contact_email"))
+	assert.Equal(t, 12, d.Get("settings.0.password_min_length"))
+	assert.Equal(t, 0, d.Get("settings.0.password_expires_days"))
+
+	// MSP admins get the organization they configured
+	config.AddDefaultHeader("x-org-id", "org2")
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.EqualError(t, r.Read(d, config),
+		"organization org2 not found, check org_id in the provider configuration")
+}
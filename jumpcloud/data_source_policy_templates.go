@@ -0,0 +1,179 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudPolicyTemplates() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to look up the policy templates a `jumpcloud_policy` can be based on.",
+		Read:        dataSourceJumpCloudPolicyTemplatesRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The unique name of a template, e.g. `lock_screen_darwin`. If set, only this " +
+					"template is returned, along with its config fields.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"templates": {
+				Description: "The policy templates.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the template, the `template_id` of a policy.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The unique name of the template.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"display_name": {
+							Description: "The name of the template shown in the console.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the template.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"os_meta_family": {
+							Description: "The OS family the template applies to, e.g. `darwin`, `linux` or `windows`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"config_fields": {
+							Description: "The config fields a policy sets in its `values`. Only returned if `name` is set.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "The name of the field, the key in `values`.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"label": {
+										Description: "The label of the field shown in the console.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"display_type": {
+										Description: "How the field is shown, e.g. `checkbox` for boolean and `number` for numeric fields.",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"required": {
+										Description: "Whether the field has to be set.",
+										Type:        schema.TypeBool,
+										Computed:    true,
+									},
+									"read_only": {
+										Description: "Whether the field can't be set.",
+										Type:        schema.TypeBool,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getPolicyTemplates lists the policy templates, only those with the name
+// if it isn't empty
+func getPolicyTemplates(config *Meta, name string) ([]jcapiv2.PolicyTemplate, error) {
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	templates := []jcapiv2.PolicyTemplate{}
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		optionals := map[string]interface{}{
+			"limit": int32(pageSize),
+			"skip":  skip,
+		}
+		if name != "" {
+			optionals["filter"] = []string{"name:eq:" + name}
+		}
+
+		page, res, err := client.PolicytemplatesApi.PolicytemplatesList(ctx, "", "", optionals)
+		for _, template := range page {
+			// the filter may not match exactly
+			if name == "" || template.Name == name {
+				templates = append(templates, template)
+			}
+		}
+		return res, len(page), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing policy templates: %s", err)
+	}
+	return templates, nil
+}
+
+func flattenPolicyTemplateConfigFields(fields []jcapiv2.PolicyTemplateConfigField) []interface{} {
+	out := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, map[string]interface{}{
+			"name":         field.Name,
+			"label":        field.Label,
+			"display_type": field.DisplayType,
+			"required":     field.Required,
+			"read_only":    field.ReadOnly,
+		})
+	}
+	return out
+}
+
+func dataSourceJumpCloudPolicyTemplatesRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	name := d.Get("name").(string)
+
+	templates, err := getPolicyTemplates(config, name)
+	if err != nil {
+		return err
+	}
+	if name != "" && len(templates) != 1 {
+		return fmt.Errorf("expected one policy template named %s, found %d", name, len(templates))
+	}
+
+	out := make([]interface{}, 0, len(templates))
+	for _, template := range templates {
+		fields := []interface{}{}
+		// every template's fields take a request of their own
+		if name != "" {
+			configFields, err := getPolicyTemplateFields(config, template.Id)
+			if err != nil {
+				return err
+			}
+			fields = flattenPolicyTemplateConfigFields(configFields)
+		}
+
+		out = append(out, map[string]interface{}{
+			"id":             template.Id,
+			"name":           template.Name,
+			"display_name":   template.DisplayName,
+			"description":    template.Description,
+			"os_meta_family": template.OsMetaFamily,
+			"config_fields":  fields,
+		})
+	}
+
+	if name != "" {
+		d.SetId(templates[0].Id)
+	} else {
+		d.SetId("policy_templates")
+	}
+	return d.Set("templates", out)
+}
@@ -0,0 +1,72 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourcePolicyTemplates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "jumpcloud_policy_templates" "all" {}`,
+				Check:  resource.TestCheckResourceAttrSet("data.jumpcloud_policy_templates.all", "templates.0.id"),
+			},
+		},
+	})
+}
+
+func TestDataSourcePolicyTemplatesRead(t *testing.T) {
+	var filters []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/policytemplates":
+			filters = append(filters, r.URL.Query().Get("filter"))
+			assert.NoError(t, json.NewEncoder(rw).Encode([]jcapiv2.PolicyTemplate{
+				{Id: "t1", Name: "lock_screen_darwin", DisplayName: "Lock Screen", OsMetaFamily: "darwin"},
+				{Id: "t2", Name: "lock_screen_darwin_v2", OsMetaFamily: "darwin"},
+			}))
+		case "/policytemplates/t1":
+			assert.NoError(t, json.NewEncoder(rw).Encode(jcapiv2.PolicyTemplateWithDetails{
+				Id:           "t1",
+				ConfigFields: []jcapiv2.PolicyTemplateConfigField{{Name: "timeout", DisplayType: "number", Required: true}},
+			}))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := dataSourceJumpCloudPolicyTemplates()
+
+	// all templates, without their config fields
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, 2, d.Get("templates.#"))
+	assert.Equal(t, 0, d.Get("templates.0.config_fields.#"))
+
+	// a single template with its config fields
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "lock_screen_darwin"})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "t1", d.Id())
+	assert.Equal(t, 1, d.Get("templates.#"))
+	assert.Equal(t, "Lock Screen", d.Get("templates.0.display_name"))
+	assert.Equal(t, "timeout", d.Get("templates.0.config_fields.0.name"))
+	assert.Equal(t, true, d.Get("templates.0.config_fields.0.required"))
+	assert.Equal(t, []string{"", "name:eq:lock_screen_darwin"}, filters)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "unknown"})
+	assert.EqualError(t, r.Read(d, config), fmt.Sprintf("expected one policy template named %s, found 0", "unknown"))
+}
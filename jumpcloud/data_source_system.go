@@ -0,0 +1,161 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// systemLookupFields maps the arguments a system can be looked up by to
+// the fields of the v1 Systems API
+var systemLookupFields = map[string]string{
+	"hostname":      "hostname",
+	"display_name":  "displayName",
+	"serial_number": "serialNumber",
+}
+
+func dataSourceJumpCloudSystem() *schema.Resource {
+	lookup := []string{"hostname", "display_name", "serial_number"}
+
+	return &schema.Resource{
+		Description: "Use this data source to look up a system by its hostname, display name or serial number.",
+		Read:        dataSourceJumpCloudSystemRead,
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Description:  "The hostname of the system.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: lookup,
+			},
+			"display_name": {
+				Description: "The name of the system shown in the console.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"serial_number": {
+				Description: "The serial number of the system.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"os": {
+				Description: "The operating system of the system, e.g. `Mac OS X` or `Windows`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"os_version": {
+				Description: "The version of the operating system.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"agent_version": {
+				Description: "The version of the JumpCloud agent installed on the system.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"active": {
+				Description: "Whether the agent is currently connected to JumpCloud.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"last_contact": {
+				Description: "The last time the agent contacted JumpCloud, as an RFC 3339 timestamp.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// findSystems lists up to limit systems whose field equals value. The SDK
+// can't be used as its model lacks the serial number.
+func findSystems(config *Meta, field, value string, limit int) ([]System, error) {
+	query := url.Values{}
+	query.Set("filter", field+":$eq:"+value)
+	query.Set("limit", fmt.Sprint(limit))
+
+	var systems SystemsList
+	if _, err := jumpCloudV1Request(config, http.MethodGet, "/systems?"+query.Encode(), nil, &systems); err != nil {
+		return nil, fmt.Errorf("error looking up system with %s %s: %w", field, value, err)
+	}
+	return systems.Results, nil
+}
+
+// formatLastContact normalizes the last contact of a system to UTC
+func formatLastContact(lastContact string) string {
+	if t, err := time.Parse(time.RFC3339, lastContact); err == nil {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return lastContact
+}
+
+// singleSystem returns the only system of systems, which were found by
+// field = value, or an error if there are none or several
+func singleSystem(systems []System, field, value string) (*System, error) {
+	switch len(systems) {
+	case 0:
+		return nil, fmt.Errorf("no system found with %s %s", field, value)
+	case 1:
+		return &systems[0], nil
+	default:
+		ids := make([]string, len(systems))
+		for i, system := range systems {
+			ids[i] = system.Id
+		}
+		return nil, fmt.Errorf("more than one system found with %s %s: %s", field, value, strings.Join(ids, ", "))
+	}
+}
+
+func dataSourceJumpCloudSystemRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var field, value string
+	for k := range systemLookupFields {
+		if v := d.Get(k).(string); v != "" {
+			field, value = k, v
+		}
+	}
+
+	// one more than needed to tell ambiguous matches apart
+	systems, err := findSystems(config, systemLookupFields[field], value, 2)
+	if err != nil {
+		return err
+	}
+	system, err := singleSystem(systems, field, value)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(system.Id)
+	if err := d.Set("hostname", system.Hostname); err != nil {
+		return err
+	}
+	if err := d.Set("display_name", system.DisplayName); err != nil {
+		return err
+	}
+	if err := d.Set("serial_number", system.SerialNumber); err != nil {
+		return err
+	}
+	if err := d.Set("os", system.Os); err != nil {
+		return err
+	}
+	if err := d.Set("os_version", system.Version); err != nil {
+		return err
+	}
+	if err := d.Set("agent_version", system.AgentVersion); err != nil {
+		return err
+	}
+	if err := d.Set("active", system.Active); err != nil {
+		return err
+	}
+	if err := d.Set("last_contact", formatLastContact(system.LastContact)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceJumpCloudSystemAgentHealth() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to check the connectivity of the JumpCloud agent of a system.",
+		Read:        dataSourceJumpCloudSystemAgentHealthRead,
+		Schema: map[string]*schema.Schema{
+			"system_id": {
+				Description: "The ID of the system.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"connection_history_limit": {
+				Description:  "The number of most recent connections to return in `connection_history`. Defaults to `10`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"latest_agent_version": {
+				Description: "The agent version to compare against. Defaults to the most recent agent version reported by the systems of the organization.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"agent_version": {
+				Description: "The version of the JumpCloud agent installed on the system.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"last_contact": {
+				Description: "The last time the agent contacted JumpCloud, as an RFC 3339 timestamp.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"connection_history": {
+				Description: "The most recent connections of the agent, newest first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connected": {
+				Description: "Whether the agent is currently connected to JumpCloud.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"agent_version_up_to_date": {
+				Description: "Whether `agent_version` is at least `latest_agent_version`.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceJumpCloudSystemAgentHealthRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv1.NewAPIClient(convertV2toV1Config(config))
+
+	id := d.Get("system_id").(string)
+	system, res, err := client.SystemsApi.SystemsGet(ctx, id, "", headerAccept, nil)
+	if err != nil {
+		return fmt.Errorf("error reading system %s: %w", id, apiError(res, err))
+	}
+
+	latest := d.Get("latest_agent_version").(string)
+	if latest == "" {
+		latest, err = latestAgentVersion(ctx, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(system.Id)
+	if err := d.Set("agent_version", system.AgentVersion); err != nil {
+		return err
+	}
+	if err := d.Set("last_contact", formatLastContact(system.LastContact)); err != nil {
+		return err
+	}
+	if err := d.Set("connection_history",
+		flattenConnectionHistory(system.ConnectionHistory, d.Get("connection_history_limit").(int))); err != nil {
+		return err
+	}
+	if err := d.Set("connected", system.Active); err != nil {
+		return err
+	}
+	if err := d.Set("latest_agent_version", latest); err != nil {
+		return err
+	}
+	if err := d.Set("agent_version_up_to_date", compareAgentVersions(system.AgentVersion, latest) >= 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// latestAgentVersion returns the most recent agent version reported by
+// any system of the organization
+func latestAgentVersion(ctx context.Context, config *Meta) (string, error) {
+	client := jcapiv1.NewAPIClient(convertV2toV1Config(config))
+
+	latest := ""
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		optionals := map[string]interface{}{
+			"fields": "agentVersion",
+			"limit":  int32(pageSize),
+			"skip":   skip,
+		}
+		var systems jcapiv1.Systemslist
+		var err error
+		systems, res, err = client.SystemsApi.SystemsList(ctx, "", headerAccept, optionals)
+		for _, system := range systems.Results {
+			if compareAgentVersions(system.AgentVersion, latest) > 0 {
+				latest = system.AgentVersion
+			}
+		}
+		return res, len(systems.Results), err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error listing systems: %w", apiError(res, err))
+	}
+	return latest, nil
+}
+
+// compareAgentVersions compares two dotted agent versions numerically,
+// returning -1, 0 or 1. Non-numeric components compare as 0.
+func compareAgentVersions(a, b string) int {
+	split := func(v string) []string {
+		return strings.FieldsFunc(v, func(r rune) bool { return r == '.' || r == '-' })
+	}
+	as, bs := split(a), split(b)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// flattenConnectionHistory returns the last limit entries of the agent's
+// connection history, newest first. Entries that aren't plain strings are
+// kept as JSON.
+func flattenConnectionHistory(history []interface{}, limit int) []string {
+	out := make([]string, 0, limit)
+	for i := len(history) - 1; i >= 0 && len(out) < limit; i-- {
+		if s, ok := history[i].(string); ok {
+			out = append(out, s)
+			continue
+		}
+		b, err := json.Marshal(history[i])
+		if err != nil {
+			continue
+		}
+		out = append(out, string(b))
+	}
+	return out
+}
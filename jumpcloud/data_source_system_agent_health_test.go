@@ -0,0 +1,108 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceSystemAgentHealth(t *testing.T) {
+	systemID := os.Getenv("JUMPCLOUD_SYSTEM_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if systemID == "" {
+				t.Skip("JUMPCLOUD_SYSTEM_ID must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSystemAgentHealth(systemID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.jumpcloud_system_agent_health.test", "id", systemID),
+					resource.TestCheckResourceAttrSet("data.jumpcloud_system_agent_health.test", "agent_version"),
+					resource.TestCheckResourceAttrSet("data.jumpcloud_system_agent_health.test", "latest_agent_version"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSystemAgentHealth(systemID string) string {
+	return fmt.Sprintf(`
+		data "jumpcloud_system_agent_health" "test" {
+			system_id = "%s"
+		}`, systemID,
+	)
+}
+
+func TestCompareAgentVersions(t *testing.T) {
+	cases := []struct {
+		A, B   string
+		Result int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.10.0", "1.9.9", 1},
+		{"1.2", "1.2.1", -1},
+		{"1.2.3-4", "1.2.3-3", 1},
+		{"", "0.0.1", -1},
+		{"1.0.0", "", 1},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.Result, compareAgentVersions(c.A, c.B), "%s vs %s", c.A, c.B)
+	}
+}
+
+func TestLatestAgentVersion(t *testing.T) {
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/systems", r.URL.Path)
+		requests++
+		if requests == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		// a full page and a partial one with the latest version
+		systems := jcapiv1.Systemslist{Results: make([]jcapiv1.System, pageSize)}
+		for i := range systems.Results {
+			systems.Results[i].AgentVersion = "1.9.0"
+		}
+		if r.URL.Query().Get("skip") == "100" {
+			systems.Results = []jcapiv1.System{{AgentVersion: "1.10.2"}}
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(systems))
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{MaxRetries: 2, RetryBaseDelayMS: 1}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL + "/api/v2"
+
+	latest, err := latestAgentVersion(context.TODO(), config.(*Meta))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.10.2", latest)
+	assert.Equal(t, 3, requests)
+}
+
+func TestFlattenConnectionHistory(t *testing.T) {
+	history := []interface{}{
+		"2023-01-01T00:00:00Z",
+		map[string]interface{}{"ip": "10.0.0.1"},
+		"2023-01-03T00:00:00Z",
+	}
+
+	assert.Equal(t, []string{"2023-01-03T00:00:00Z", `{"ip":"10.0.0.1"}`},
+		flattenConnectionHistory(history, 2))
+	assert.Equal(t, []string{}, flattenConnectionHistory(nil, 5))
+}
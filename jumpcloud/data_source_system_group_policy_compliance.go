@@ -0,0 +1,161 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudSystemGroupPolicyCompliance() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to check whether the systems of a JumpCloud system group comply with the policies bound to the group.",
+		Read:        dataSourceJumpCloudSystemGroupPolicyComplianceRead,
+		Schema: map[string]*schema.Schema{
+			"system_group_id": {
+				Description: "The ID of the system group.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"overall_compliant": {
+				Description: "Whether every policy was applied successfully to every system of the group.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"compliant_count": {
+				Description: "The number of system and policy pairs the policy was applied successfully to.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"non_compliant_count": {
+				Description: "The number of system and policy pairs the policy failed, is pending or has no result for.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"non_compliant_details": {
+				Description: "The system and policy pairs that aren't compliant.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"system_id": {
+							Description: "The ID of the system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"policy_id": {
+							Description: "The ID of the policy.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "The state of the latest policy result, `no_result` if the policy wasn't applied yet.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getPolicyStates returns the state of the latest result of a policy per system
+func getPolicyStates(ctx context.Context, config *Meta, policyID string) (map[string]string, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	states := map[string]string{}
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		var results []jcapiv2.PolicyResult
+		var err error
+		results, res, err = client.PoliciesApi.PolicystatusesList(ctx, policyID, "", headerAccept,
+			map[string]interface{}{
+				"limit": int32(pageSize),
+				"skip":  skip,
+			})
+		for _, result := range results {
+			states[result.SystemID] = result.State
+		}
+		return res, len(results), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing results of policy %s: %w", policyID, apiError(res, err))
+	}
+	return states, nil
+}
+
+// evaluatePolicyCompliance checks every system and policy pair against
+// states, which holds the result states per policy and system
+func evaluatePolicyCompliance(systemIDs, policyIDs []string, states map[string]map[string]string) (int, []map[string]interface{}) {
+	compliant := 0
+	details := []map[string]interface{}{}
+	for _, policyID := range policyIDs {
+		for _, systemID := range systemIDs {
+			state, ok := states[policyID][systemID]
+			if !ok {
+				state = "no_result"
+			}
+			if state == "success" {
+				compliant++
+				continue
+			}
+			details = append(details, map[string]interface{}{
+				"system_id": systemID,
+				"policy_id": policyID,
+				"state":     state,
+			})
+		}
+	}
+	return compliant, details
+}
+
+func dataSourceJumpCloudSystemGroupPolicyComplianceRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	groupID := d.Get("system_group_id").(string)
+
+	systemIDs, err := getSystemGroupMemberIDs(ctx, config, groupID)
+	if err != nil {
+		return err
+	}
+
+	policyIDs, err := graphTraverse(ctx, config, func(optionals map[string]interface{}) ([]jcapiv2.GraphObjectWithPaths, *http.Response, error) {
+		policies, res, err := client.SystemGroupAssociationsApi.GraphSystemGroupTraversePolicy(
+			ctx, groupID, "", headerAccept, optionals)
+		if err != nil {
+			return nil, res, fmt.Errorf("error listing policies of system group %s: %w", groupID, apiError(res, err))
+		}
+		return policies, res, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	states := map[string]map[string]string{}
+	for _, policyID := range policyIDs {
+		if states[policyID], err = getPolicyStates(ctx, config, policyID); err != nil {
+			return err
+		}
+	}
+
+	compliant, details := evaluatePolicyCompliance(systemIDs, policyIDs, states)
+
+	d.SetId(groupID)
+	if err := d.Set("overall_compliant", len(details) == 0); err != nil {
+		return err
+	}
+	if err := d.Set("compliant_count", compliant); err != nil {
+		return err
+	}
+	if err := d.Set("non_compliant_count", len(details)); err != nil {
+		return err
+	}
+	if err := d.Set("non_compliant_details", details); err != nil {
+		return err
+	}
+	return nil
+}
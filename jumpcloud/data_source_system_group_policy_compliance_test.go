@@ -0,0 +1,53 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceJumpCloudSystemGroupPolicyCompliance(t *testing.T) {
+	groupID := os.Getenv("JUMPCLOUD_SYSTEM_GROUP_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if groupID == "" {
+				t.Skip("JUMPCLOUD_SYSTEM_GROUP_ID must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "jumpcloud_system_group_policy_compliance" "test" {
+  system_group_id = "%s"
+}`, groupID),
+				Check: resource.TestCheckResourceAttrSet("data.jumpcloud_system_group_policy_compliance.test", "overall_compliant"),
+			},
+		},
+	})
+}
+
+func TestEvaluatePolicyCompliance(t *testing.T) {
+	states := map[string]map[string]string{
+		"p1": {"s1": "success", "s2": "failed"},
+		"p2": {"s1": "success", "s2": "success"},
+		"p3": {"s1": "pending"},
+	}
+
+	compliant, details := evaluatePolicyCompliance([]string{"s1", "s2"}, []string{"p1", "p2", "p3"}, states)
+	assert.Equal(t, 3, compliant)
+	assert.Equal(t, []map[string]interface{}{
+		{"system_id": "s2", "policy_id": "p1", "state": "failed"},
+		{"system_id": "s1", "policy_id": "p3", "state": "pending"},
+		{"system_id": "s2", "policy_id": "p3", "state": "no_result"},
+	}, details)
+
+	compliant, details = evaluatePolicyCompliance([]string{"s1"}, []string{}, states)
+	assert.Equal(t, 0, compliant)
+	assert.Empty(t, details)
+}
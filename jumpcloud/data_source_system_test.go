@@ -0,0 +1,95 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceSystem(t *testing.T) {
+	hostname := os.Getenv("JUMPCLOUD_SYSTEM_HOSTNAME")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if hostname == "" {
+				t.Skip("JUMPCLOUD_SYSTEM_HOSTNAME must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "jumpcloud_system" "test" {
+						hostname = "%s"
+					}`, hostname),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.jumpcloud_system.test", "id"),
+					resource.TestCheckResourceAttrSet("data.jumpcloud_system.test", "os"),
+					resource.TestCheckResourceAttrSet("data.jumpcloud_system.test", "agent_version"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSystemRead(t *testing.T) {
+	systems := map[string][]System{
+		"hostname:$eq:build-01": {{
+			System: jcapiv1.System{
+				Id:           "s1",
+				Hostname:     "build-01",
+				DisplayName:  "Build 01",
+				Os:           "Ubuntu",
+				Version:      "22.04",
+				AgentVersion: "1.150.0",
+				Active:       true,
+				LastContact:  "2024-03-01T12:00:00.000+01:00",
+			},
+			SerialNumber: "C02XYZ",
+		}},
+		"serialNumber:$eq:C02XYZ": {{System: jcapiv1.System{Id: "s1"}, SerialNumber: "C02XYZ"}},
+		"displayName:$eq:Laptop":  {{System: jcapiv1.System{Id: "s2"}}, {System: jcapiv1.System{Id: "s3"}}},
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/systems", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		results := systems[r.URL.Query().Get("filter")]
+		assert.NoError(t, json.NewEncoder(rw).Encode(SystemsList{Results: results, TotalCount: len(results)}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/v2"
+	r := dataSourceJumpCloudSystem()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"hostname": "build-01"})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "s1", d.Id())
+	assert.Equal(t, "Build 01", d.Get("display_name"))
+	assert.Equal(t, "C02XYZ", d.Get("serial_number"))
+	assert.Equal(t, "Ubuntu", d.Get("os"))
+	assert.Equal(t, "22.04", d.Get("os_version"))
+	assert.Equal(t, "1.150.0", d.Get("agent_version"))
+	assert.Equal(t, true, d.Get("active"))
+	assert.Equal(t, "2024-03-01T11:00:00Z", d.Get("last_contact"))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"serial_number": "C02XYZ"})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "s1", d.Id())
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"display_name": "Laptop"})
+	assert.EqualError(t, r.Read(d, config), "more than one system found with display_name Laptop: s2, s3")
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"hostname": "unknown"})
+	assert.EqualError(t, r.Read(d, config), "no system found with hostname unknown")
+}
@@ -0,0 +1,172 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudSystems() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the systems matching all of the given filters, e.g. to " +
+			"bind all macOS systems to a policy. Without filters, all systems of the organization are returned.",
+		Read: dataSourceJumpCloudSystemsRead,
+		Schema: map[string]*schema.Schema{
+			"os": {
+				Description: "Only return systems with this operating system, e.g. `Mac OS X`, `Windows` or `Ubuntu`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tag": {
+				Description: "Only return systems with this tag.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"active": {
+				Description: "Only return systems whose agent is currently connected (`true`) or disconnected (`false`).",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"ids": {
+				Description: "The IDs of the systems.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"systems": {
+				Description: "The systems.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"hostname": {
+							Description: "The hostname of the system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"display_name": {
+							Description: "The name of the system shown in the console.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"serial_number": {
+							Description: "The serial number of the system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"os": {
+							Description: "The operating system of the system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"os_version": {
+							Description: "The version of the operating system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"agent_version": {
+							Description: "The version of the JumpCloud agent installed on the system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"active": {
+							Description: "Whether the agent is currently connected to JumpCloud.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"last_contact": {
+							Description: "The last time the agent contacted JumpCloud, as an RFC 3339 timestamp.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// systemsFilters translates the filters of the data source into the
+// field:$eq:value filters of the v1 API, which are all applied
+func systemsFilters(d *schema.ResourceData) []string {
+	filters := []string{}
+	if os := d.Get("os").(string); os != "" {
+		filters = append(filters, "os:$eq:"+os)
+	}
+	if tag := d.Get("tag").(string); tag != "" {
+		filters = append(filters, "tags:$eq:"+tag)
+	}
+	// false is a filter of its own, so unset has to be told apart from it
+	if active, ok := d.GetOkExists("active"); ok {
+		filters = append(filters, "active:$eq:"+strconv.FormatBool(active.(bool)))
+	}
+	return filters
+}
+
+// listSystems lists the systems matching all filters
+func listSystems(config *Meta, filters []string) ([]System, error) {
+	ctx := requestContext(config)
+	systems := []System{}
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		query := url.Values{}
+		query.Set("limit", fmt.Sprint(pageSize))
+		query.Set("skip", fmt.Sprint(skip))
+		query.Set("sort", "_id")
+		for _, filter := range filters {
+			query.Add("filter", filter)
+		}
+
+		var page SystemsList
+		res, _, err := jumpCloudPageRequest(ctx, config, v1BasePath(config)+"/systems?"+query.Encode(), &page)
+		systems = append(systems, page.Results...)
+		return res, len(page.Results), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing systems: %w", err)
+	}
+	return systems, nil
+}
+
+func flattenSystem(system System) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            system.Id,
+		"hostname":      system.Hostname,
+		"display_name":  system.DisplayName,
+		"serial_number": system.SerialNumber,
+		"os":            system.Os,
+		"os_version":    system.Version,
+		"agent_version": system.AgentVersion,
+		"active":        system.Active,
+		"last_contact":  formatLastContact(system.LastContact),
+	}
+}
+
+func dataSourceJumpCloudSystemsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	systems, err := listSystems(config, systemsFilters(d))
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(systems))
+	out := make([]interface{}, 0, len(systems))
+	for _, system := range systems {
+		ids = append(ids, system.Id)
+		out = append(out, flattenSystem(system))
+	}
+
+	d.SetId("systems")
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+	return d.Set("systems", out)
+}
@@ -0,0 +1,103 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceSystems(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "jumpcloud_systems" "all" {}`,
+				Check:  resource.TestCheckResourceAttrSet("data.jumpcloud_systems.all", "ids.#"),
+			},
+		},
+	})
+}
+
+func TestDataSourceSystemsRead(t *testing.T) {
+	// a full and a partial page
+	all := []System{}
+	for i := 0; i < pageSize+20; i++ {
+		all = append(all, System{System: jcapiv1.System{Id: fmt.Sprintf("s%03d", i), Os: "Mac OS X", Active: true}})
+	}
+
+	var filters [][]string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/systems", r.URL.Path)
+		filters = append(filters, r.URL.Query()["filter"])
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := skip + limit
+		if end > len(all) {
+			end = len(all)
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(SystemsList{Results: all[skip:end], TotalCount: len(all)}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/v2"
+	r := dataSourceJumpCloudSystems()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"os":     "Mac OS X",
+		"tag":    "build",
+		"active": true,
+	})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, pageSize+20, d.Get("ids.#"))
+	assert.Equal(t, "s000", d.Get("ids.0"))
+	assert.Equal(t, "s119", d.Get("systems.119.id"))
+	assert.Equal(t, "Mac OS X", d.Get("systems.0.os"))
+	assert.Equal(t, true, d.Get("systems.0.active"))
+	expected := []string{"os:$eq:Mac OS X", "tags:$eq:build", "active:$eq:true"}
+	assert.Equal(t, [][]string{expected, expected}, filters)
+
+	// false is a filter, unset isn't
+	filters = nil
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"active": false})
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, []string{"active:$eq:false"}, filters[0])
+
+	filters = nil
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, filters[0])
+}
+
+func TestListSystemsRateLimited(t *testing.T) {
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(SystemsList{Results: []System{{System: jcapiv1.System{Id: "s"}}}}))
+	}))
+	defer testServer.Close()
+
+	// the rate limited page is retried, which takes its response
+	config, err := (&Config{MaxRetries: 2, RetryBaseDelayMS: 1}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL + "/v2"
+
+	systems, err := listSystems(config.(*Meta), nil)
+	assert.NoError(t, err)
+	assert.Len(t, systems, 1)
+	assert.Equal(t, 2, requests)
+}
@@ -3,11 +3,10 @@ package jumpcloud
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"strings"
 
 	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
-	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	// "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,20 +16,37 @@ func dataSourceJumpCloudUser() *schema.Resource {
 		Read: dataSourceJumpCloudUserRead,
 		Schema: map[string]*schema.Schema{
 			"email": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"email", "username"},
 			},
 			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"firstname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"lastname": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"suspended": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"mfa_configured": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func getUserDetails(client *jcapiv1.APIClient, email string) (*jcapiv1.Systemuserreturn, error) {
-	ctx := context.TODO()
-
+func getUserDetails(ctx context.Context, client *jcapiv1.APIClient, email string) (*jcapiv1.Systemuserreturn, error) {
 	contentType := "application/json"
 	accept := "application/json"
 
@@ -60,22 +76,65 @@ func getUserDetails(client *jcapiv1.APIClient, email string) (*jcapiv1.Systemuse
 	return &user, nil
 }
 
+// singleUser returns the only user of users, which were found by
+// field = value, or an error if there are none or several
+func singleUser(users []jcapiv1.Systemuserreturn, field, value string) (*jcapiv1.Systemuserreturn, error) {
+	switch len(users) {
+	case 0:
+		return nil, fmt.Errorf("no user found with %s %s", field, value)
+	case 1:
+		return &users[0], nil
+	default:
+		ids := make([]string, len(users))
+		for i, user := range users {
+			ids[i] = user.Id
+		}
+		return nil, fmt.Errorf("more than one user found with %s %s: %s", field, value, strings.Join(ids, ", "))
+	}
+}
+
 func dataSourceJumpCloudUserRead(d *schema.ResourceData, m interface{}) error {
-	configv1 := convertV2toV1Config(m.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
-	userEmail := d.Get("email").(string)
 
-	// Use the getUserDetails function to query user details using the userEmail
-	user, err := getUserDetails(client, userEmail)
+	field, value := "email", d.Get("email").(string)
+	if value == "" {
+		field, value = "username", d.Get("username").(string)
+	}
 
-	// If an error occurs or no user is found, return an error
+	users, res, err := client.SystemusersApi.SystemusersList(ctx, "", "", map[string]interface{}{
+		"filter": field + ":$eq:" + value,
+		// one more than needed to tell ambiguous matches apart
+		"limit": int32(2),
+	})
 	if err != nil {
-		return errors.New("user not found")
+		return fmt.Errorf("error looking up user with %s %s: %w", field, value, apiError(res, err))
 	}
 
-	// Set the user ID in the Terraform resource data object
-	d.SetId(user.Id)
-	d.Set("id", user.Id)
+	user, err := singleUser(users.Results, field, value)
+	if err != nil {
+		return err
+	}
 
+	d.SetId(user.Id)
+	if err := d.Set("email", user.Email); err != nil {
+		return err
+	}
+	if err := d.Set("username", user.Username); err != nil {
+		return err
+	}
+	if err := d.Set("firstname", user.Firstname); err != nil {
+		return err
+	}
+	if err := d.Set("lastname", user.Lastname); err != nil {
+		return err
+	}
+	if err := d.Set("suspended", user.Suspended); err != nil {
+		return err
+	}
+	if err := d.Set("mfa_configured", user.Mfa != nil && user.Mfa.Configured); err != nil {
+		return err
+	}
 	return nil
 }
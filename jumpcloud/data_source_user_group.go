@@ -1,10 +1,10 @@
 package jumpcloud
 
 import (
-	"context"
 	"fmt"
+	"log"
+	"strings"
 
-	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -12,14 +12,39 @@ func dataSourceJumpCloudUserGroup() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceJumpCloudUserGroupRead,
 		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "group_name"},
+				Description:  "The name of the group.",
+			},
 			"group_name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Deprecated:  "use name instead",
+				Description: "The name of the group.",
 			},
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"attributes": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"member_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 			"members": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -33,42 +58,58 @@ func dataSourceJumpCloudUserGroup() *schema.Resource {
 }
 
 func dataSourceJumpCloudUserGroupRead(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
-
-	groupName := d.Get("group_name").(string)
-
-	filter := fmt.Sprintf(`{"name":"%s"}`, groupName)
+	config := m.(*Meta)
+	ctx := requestContext(config)
 
-	limit := int32(0) // No limit specified to retrieve all matching groups
+	groupName := d.Get("name").(string)
+	if groupName == "" {
+		groupName = d.Get("group_name").(string)
+	}
 
-	groups, _, err := client.UserGroupsApi.GroupsUserList(context.Background(), "application/json", "application/json", map[string]interface{}{
-		"filter": filter,
-		"limit":  limit,
-		"sort":   []string{},
-	})
+	id, err := userGroupIDByName(config, groupName)
 	if err != nil {
 		return err
 	}
 
-	for _, group := range groups {
-		if group.Name == groupName {
-			d.SetId(group.Id)
+	group, ok, err := userGroupReadHelper(ctx, config, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("user group %s (%s) was deleted while being read", groupName, id)
+	}
 
-			memberIDs, err := getUserGroupMemberIDs(client, d.Id())
-			if err != nil {
-				return err
-			}
-			memberEmails, err := userIDsToEmails(config, memberIDs)
-			if err != nil {
-				return err
-			}
-			if err := d.Set("members", memberEmails); err != nil {
-				return err
-			}
-			return nil
-		}
+	d.SetId(group.ID)
+	if err := d.Set("name", group.Name); err != nil {
+		return err
+	}
+	if err := d.Set("group_name", group.Name); err != nil {
+		return err
+	}
+	if err := d.Set("description", group.Description); err != nil {
+		return err
+	}
+	if err := d.Set("attributes", flattenAttributes(&group.Attributes.UserGroupAttributes)); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("No user group found with name: %s", groupName)
+	memberIDs, err := getUserGroupMemberIDs(ctx, config, d.Id())
+	if err != nil {
+		return err
+	}
+	memberEmails, unresolved, err := userIDsToEmails(ctx, config, memberIDs)
+	if err != nil {
+		return err
+	}
+	if len(unresolved) > 0 {
+		log.Printf("[WARN] members %s of user group %s are no JumpCloud users anymore",
+			strings.Join(unresolved, ", "), groupName)
+	}
+	if err := d.Set("member_count", len(memberIDs)); err != nil {
+		return err
+	}
+	if err := d.Set("members", memberEmails); err != nil {
+		return err
+	}
+	return nil
 }
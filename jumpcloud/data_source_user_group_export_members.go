@@ -0,0 +1,62 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudUserGroupExportMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to export the members of a JumpCloud user group for use in other workspaces.",
+		Read:        dataSourceJumpCloudUserGroupExportMembersRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the user group.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"member_emails_json": {
+				Description: "The sorted member emails as a JSON array, suitable for outputs read through `terraform_remote_state`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceJumpCloudUserGroupExportMembersRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	groupID := d.Get("group_id").(string)
+
+	memberIDs, err := getUserGroupMemberIDs(ctx, config, groupID)
+	if err != nil {
+		return err
+	}
+	memberEmails, unresolved, err := userIDsToEmails(ctx, config, memberIDs)
+	if err != nil {
+		return err
+	}
+	if len(unresolved) > 0 {
+		log.Printf("[WARN] members %s of user group %s are no JumpCloud users anymore and not exported",
+			strings.Join(unresolved, ", "), groupID)
+	}
+
+	// keep the output stable so consumers don't see spurious changes
+	sort.Strings(memberEmails)
+	exported, err := json.Marshal(memberEmails)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(groupID)
+	if err := d.Set("member_emails_json", string(exported)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceJumpCloudUserGroupExportMembers_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceJumpCloudUserGroupExportMembersConfig(rName),
+				Check: resource.TestCheckResourceAttr("data.jumpcloud_user_group_export_members.test",
+					"member_emails_json", fmt.Sprintf(`["%[1]s1@testorg.com","%[1]s2@testorg.com"]`, rName)),
+			},
+		},
+	})
+}
+
+func testAccDataSourceJumpCloudUserGroupExportMembersConfig(name string) string {
+	return fmt.Sprintf(`
+resource "jumpcloud_user" "test_user1" {
+  username = "%[1]s1"
+  email = "%[1]s1@testorg.com"
+}
+resource "jumpcloud_user" "test_user2" {
+  username = "%[1]s2"
+  email = "%[1]s2@testorg.com"
+}
+
+resource "jumpcloud_user_group" "test_group" {
+  name = "%[1]s"
+  members = [
+    jumpcloud_user.test_user2.email,
+    jumpcloud_user.test_user1.email,
+  ]
+}
+
+data "jumpcloud_user_group_export_members" "test" {
+  group_id = jumpcloud_user_group.test_group.id
+}`, name)
+}
@@ -0,0 +1,97 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceJumpCloudUserGroupInactiveMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to find members of a JumpCloud user group without recent activity.",
+		Read:        dataSourceJumpCloudUserGroupInactiveMembersRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the user group.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"inactive_days": {
+				Description:  "The number of days without activity after which a member is considered inactive.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"activated_only": {
+				Description: "Only consider members that have activated their account.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"inactive_members": {
+				Description: "The emails of the inactive members.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceJumpCloudUserGroupInactiveMembersRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	groupID := d.Get("group_id").(string)
+	activatedOnly := d.Get("activated_only").(bool)
+	cutoff := time.Now().AddDate(0, 0, -d.Get("inactive_days").(int))
+
+	memberIDs, err := getUserGroupMemberIDs(ctx, config, groupID)
+	if err != nil {
+		return err
+	}
+
+	inactive := []string{}
+	for _, memberID := range memberIDs {
+		// the activity timestamps are not part of jcapiv1.Systemuserreturn,
+		// so the user is read through the HTTP API directly
+		var user SystemUserActivity
+		ok, err := jumpCloudV1Request(config, http.MethodGet, "/systemusers/"+memberID, nil, &user)
+		if err != nil {
+			return fmt.Errorf("error reading user %s of group %s: %s", memberID, groupID, err)
+		}
+		if !ok {
+			log.Printf("[WARN] user %s of group %s not found, skipping", memberID, groupID)
+			continue
+		}
+
+		if activatedOnly && !user.Activated {
+			continue
+		}
+
+		lastActivity := user.LastStateChangeDate
+		if lastActivity == "" {
+			lastActivity = user.Created
+		}
+		lastActivityAt, err := time.Parse(time.RFC3339, lastActivity)
+		if err != nil {
+			return fmt.Errorf("error parsing activity timestamp %q of user %s: %s", lastActivity, memberID, err)
+		}
+
+		if lastActivityAt.Before(cutoff) {
+			inactive = append(inactive, user.Email)
+		}
+	}
+
+	d.SetId(groupID)
+	if err := d.Set("inactive_members", inactive); err != nil {
+		return err
+	}
+	return nil
+}
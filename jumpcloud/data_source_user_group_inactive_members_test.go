@@ -0,0 +1,49 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceJumpCloudUserGroupInactiveMembers_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				// freshly created users are never inactive
+				Config: testAccDataSourceJumpCloudUserGroupInactiveMembersConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.jumpcloud_user_group_inactive_members.test", "id"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user_group_inactive_members.test", "inactive_members.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceJumpCloudUserGroupInactiveMembersConfig(name string) string {
+	return fmt.Sprintf(`
+resource "jumpcloud_user" "test_user" {
+  username = "%[1]s"
+  email = "%[1]s@testorg.com"
+  firstname = "Firstname"
+  lastname = "Lastname"
+}
+
+resource "jumpcloud_user_group" "test_group" {
+  name = "%[1]s"
+  members = [jumpcloud_user.test_user.email]
+}
+
+data "jumpcloud_user_group_inactive_members" "test" {
+  group_id      = jumpcloud_user_group.test_group.id
+  inactive_days = 30
+}`, name)
+}
@@ -0,0 +1,172 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+const directoryInsightsPageSize = 1000
+
+func dataSourceJumpCloudUserGroupMembershipLog() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to audit when users were added to or removed from a JumpCloud user group, based on Directory Insights.",
+		Read:        dataSourceJumpCloudUserGroupMembershipLogRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the user group.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"start_time": {
+				Description:  "The RFC 3339 timestamp to start the log at. Defaults to 90 days ago, the retention of Directory Insights.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"end_time": {
+				Description:  "The RFC 3339 timestamp to end the log at. Defaults to now.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"events": {
+				Description: "The membership changes of the group, oldest first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {
+							Description: "The RFC 3339 timestamp of the change.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"user_email": {
+							Description: "The email of the user that was added or removed.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"action": {
+							Description: "Either `add` or `remove`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"performed_by_email": {
+							Description: "The email of the administrator that made the change.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"source": {
+							Description: "Where the change was made, one of `console`, `api` or `terraform`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// directoryInsightsURL derives the Directory Insights events endpoint from
+// config.BasePath, Directory Insights is served by the API host
+func directoryInsightsURL(config *Meta) string {
+	base := strings.TrimSuffix(config.BasePath, "/api/v2")
+	base = strings.Replace(base, "://console.", "://api.", 1)
+	return base + "/insights/directory/v1/events"
+}
+
+// getGroupMembershipEvents pages through the Directory Insights events
+// matching query, following the X-Search_After header. Rate limited
+// requests are retried like those of the pager.
+func getGroupMembershipEvents(config *Meta, query DirectoryInsightsQuery) ([]GroupMembershipEvent, error) {
+	var events []GroupMembershipEvent
+	query.Limit = directoryInsightsPageSize
+
+	ctx := requestContext(config)
+	for {
+		var page []GroupMembershipEvent
+		var res *http.Response
+		err := withRateLimitRetry(ctx, config, func() (*http.Response, error) {
+			var err error
+			page = nil
+			res, _, err = doJumpCloudRequest(ctx, config, http.MethodPost, directoryInsightsURL(config), query, &page)
+			return res, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error searching Directory Insights events: %w", err)
+		}
+		events = append(events, page...)
+
+		searchAfter := res.Header.Get("X-Search_After")
+		if len(page) < directoryInsightsPageSize || searchAfter == "" {
+			break
+		}
+		if err := json.Unmarshal([]byte(searchAfter), &query.SearchAfter); err != nil {
+			return nil, fmt.Errorf("error decoding X-Search_After header %s: %s", searchAfter, err)
+		}
+	}
+	return events, nil
+}
+
+// membershipEventSource tells changes made by Terraform, through the
+// API and in the console apart
+func membershipEventSource(event GroupMembershipEvent) string {
+	if strings.Contains(strings.ToLower(event.UserAgent.Name), "terraform") {
+		return "terraform"
+	}
+	if event.AuthMethod == "api_key" {
+		return "api"
+	}
+	return "console"
+}
+
+func dataSourceJumpCloudUserGroupMembershipLogRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	groupID := d.Get("group_id").(string)
+
+	startTime := d.Get("start_time").(string)
+	if startTime == "" {
+		startTime = time.Now().AddDate(0, 0, -90).UTC().Format(time.RFC3339)
+	}
+
+	query := DirectoryInsightsQuery{
+		Service:   []string{"directory"},
+		StartTime: startTime,
+		EndTime:   d.Get("end_time").(string),
+		Sort:      "ASC",
+		SearchTerm: map[string]interface{}{
+			"and": []map[string]interface{}{
+				{"event_type": "group_management"},
+				{"resource.id": groupID},
+			},
+		},
+	}
+
+	events, err := getGroupMembershipEvents(config, query)
+	if err != nil {
+		return err
+	}
+
+	flattened := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		flattened = append(flattened, map[string]interface{}{
+			"timestamp":          event.Timestamp,
+			"user_email":         event.Target.Email,
+			"action":             event.Operation,
+			"performed_by_email": event.InitiatedBy.Email,
+			"source":             membershipEventSource(event),
+		})
+	}
+
+	d.SetId(groupID)
+	if err := d.Set("events", flattened); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceJumpCloudUserGroupMembershipLog(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "jumpcloud_user_group" "test_group" {
+  name = "%s"
+}
+
+data "jumpcloud_user_group_membership_log" "test_log" {
+  group_id = jumpcloud_user_group.test_group.id
+}`, rName),
+				Check: resource.TestCheckResourceAttrSet("data.jumpcloud_user_group_membership_log.test_log", "events.#"),
+			},
+		},
+	})
+}
+
+func TestGetGroupMembershipEvents(t *testing.T) {
+	var queries []DirectoryInsightsQuery
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/insights/directory/v1/events", r.URL.Path)
+
+		var query DirectoryInsightsQuery
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		queries = append(queries, query)
+
+		// two pages, a full one and a partial one
+		count := directoryInsightsPageSize
+		if query.SearchAfter != nil {
+			count = 2
+		}
+
+		events := make([]string, count)
+		for i := range events {
+			events[i] = fmt.Sprintf(`{"timestamp":"2021-06-01T12:00:00Z","operation":"add","target":{"email":"user%d@example.com"}}`, i)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("X-Search_After", `[1622548800000,"abc"]`)
+		rw.Write([]byte("[" + strings.Join(events, ",") + "]"))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+
+	events, err := getGroupMembershipEvents(config, DirectoryInsightsQuery{
+		Service:   []string{"directory"},
+		StartTime: "2021-06-01T00:00:00Z",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, events, directoryInsightsPageSize+2)
+	assert.Equal(t, "user1@example.com", events[directoryInsightsPageSize+1].Target.Email)
+
+	assert.Len(t, queries, 2)
+	assert.Nil(t, queries[0].SearchAfter)
+	assert.Equal(t, []interface{}{float64(1622548800000), "abc"}, queries[1].SearchAfter)
+}
+
+func TestGetGroupMembershipEventsErrors(t *testing.T) {
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.WriteHeader(http.StatusForbidden)
+		rw.Write([]byte(`{"message": "Directory Insights is not enabled"}`))
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{MaxRetries: 2, RetryBaseDelayMS: 1}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL + "/api/v2"
+
+	// the rate limited request is retried, the error response is parsed
+	_, err = getGroupMembershipEvents(config.(*Meta), DirectoryInsightsQuery{Service: []string{"directory"}})
+	assert.EqualError(t, err, "error searching Directory Insights events: "+
+		"POST /insights/directory/v1/events: 403 Forbidden: Directory Insights is not enabled")
+	assert.Equal(t, 2, requests)
+}
+
+func TestMembershipEventSource(t *testing.T) {
+	var event GroupMembershipEvent
+	assert.Equal(t, "console", membershipEventSource(event))
+
+	event.AuthMethod = "api_key"
+	assert.Equal(t, "api", membershipEventSource(event))
+
+	event.UserAgent.Name = "Terraform/0.14.7"
+	assert.Equal(t, "terraform", membershipEventSource(event))
+}
+
+func TestDirectoryInsightsURL(t *testing.T) {
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = "https://console.jumpcloud.com/api/v2"
+	assert.Equal(t, "https://api.jumpcloud.com/insights/directory/v1/events", directoryInsightsURL(config))
+}
@@ -2,10 +2,14 @@ package jumpcloud
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccDataSourceJumpCloudUserGroup_basic(t *testing.T) {
@@ -21,6 +25,8 @@ func TestAccDataSourceJumpCloudUserGroup_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.jumpcloud_user_group.test_group", "id"),
 					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "group_name", rName),
+					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "name", rName),
+					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "member_count", "2"),
 					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "members.#", "2"),
 					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "members.0", fmt.Sprintf("%s1@testorg.com", rName)),
 					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "members.1", fmt.Sprintf("%s3@testorg.com", rName)),
@@ -30,6 +36,67 @@ func TestAccDataSourceJumpCloudUserGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceJumpCloudUserGroup_name(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "jumpcloud_user_group" "test_group" {
+  name        = "%[1]s"
+  description = "Looked up by name"
+  posix_gid   = 4242
+  posix_name  = "%[1]s"
+}
+
+data "jumpcloud_user_group" "test_group" {
+  name = jumpcloud_user_group.test_group.name
+}`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.jumpcloud_user_group.test_group", "id",
+						"jumpcloud_user_group.test_group", "id"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "description", "Looked up by name"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group",
+						"attributes.posix_groups", fmt.Sprintf("4242:%s", rName)),
+					resource.TestCheckResourceAttr("data.jumpcloud_user_group.test_group", "member_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUserGroupIDByName(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/usergroups", r.URL.Path)
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("filter") {
+		case "name:eq:admins":
+			rw.Write([]byte(`[{"id":"1","name":"admins"},{"id":"2","name":"Admins"}]`))
+		case "name:eq:developers":
+			rw.Write([]byte(`[{"id":"3","name":"developers"},{"id":"4","name":"developers"}]`))
+		default:
+			rw.Write([]byte(`[]`))
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	id, err := userGroupIDByName(config, "admins")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", id)
+
+	_, err = userGroupIDByName(config, "developers")
+	assert.EqualError(t, err, "2 user groups are named developers (3, 4), refer to the group by ID instead")
+
+	_, err = userGroupIDByName(config, "nobody")
+	assert.EqualError(t, err, "no user group found with name: nobody")
+}
+
 func testAccDataSourceJumpCloudUserGroupConfig(groupName string) string {
 	return fmt.Sprintf(`
 resource "jumpcloud_user" "test_user1" {
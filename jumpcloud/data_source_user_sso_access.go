@@ -0,0 +1,150 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceJumpCloudUserSSOAccess() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the SSO applications a JumpCloud user can access through their group memberships.",
+		Read:        dataSourceJumpCloudUserSSOAccessRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Description: "The email of the user.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"applications": {
+				Description: "The applications the user can access, one entry per granting group.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"application_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"granted_via_group_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"granted_via_group_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// graphTraverse collects the IDs of the objects returned by a paginated
+// graph traversal
+func graphTraverse(ctx context.Context, config *Meta,
+	list func(optionals map[string]interface{}) ([]jcapiv2.GraphObjectWithPaths, *http.Response, error)) ([]string, error) {
+
+	var ids []string
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		objects, res, err := list(map[string]interface{}{
+			"limit": int32(pageSize),
+			"skip":  skip,
+		})
+		for _, object := range objects {
+			ids = append(ids, object.Id)
+		}
+		return res, len(objects), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func dataSourceJumpCloudUserSSOAccessRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	clientv1 := jcapiv1.NewAPIClient(convertV2toV1Config(config))
+
+	email := d.Get("email").(string)
+	user, err := getUserDetails(ctx, clientv1, email)
+	if err != nil {
+		return err
+	}
+
+	groupIDs, err := graphTraverse(ctx, config, func(optionals map[string]interface{}) ([]jcapiv2.GraphObjectWithPaths, *http.Response, error) {
+		optionals["filter"] = []string{"type:eq:user_group"}
+		groups, res, err := client.UsersApi.GraphUserMemberOf(ctx, user.Id, "", headerAccept, optionals)
+		if err != nil {
+			return nil, res, fmt.Errorf("error listing groups of user %s: %w", email, apiError(res, err))
+		}
+		return groups, res, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	applicationNames := map[string]string{}
+	applications := []map[string]interface{}{}
+	for _, groupID := range groupIDs {
+		group, res, err := client.UserGroupsApi.GroupsUserGet(ctx, groupID, "", headerAccept, nil)
+		if err != nil {
+			return fmt.Errorf("error reading user group %s: %w", groupID, apiError(res, err))
+		}
+
+		applicationIDs, err := graphTraverse(ctx, config, func(optionals map[string]interface{}) ([]jcapiv2.GraphObjectWithPaths, *http.Response, error) {
+			apps, res, err := client.UserGroupsApi.GraphUserGroupTraverseApplication(ctx, groupID, "", headerAccept, optionals)
+			if err != nil {
+				return nil, res, fmt.Errorf("error listing applications of user group %s: %w", groupID, apiError(res, err))
+			}
+			return apps, res, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, applicationID := range applicationIDs {
+			name, ok := applicationNames[applicationID]
+			if !ok {
+				application, res, err := clientv1.ApplicationsApi.ApplicationsGet(ctx, applicationID, nil)
+				if err != nil {
+					return fmt.Errorf("error reading application %s: %w", applicationID, apiError(res, err))
+				}
+				name = application.DisplayLabel
+				applicationNames[applicationID] = name
+			}
+
+			applications = append(applications, map[string]interface{}{
+				"application_id":         applicationID,
+				"application_name":       name,
+				"granted_via_group_id":   groupID,
+				"granted_via_group_name": group.Name,
+			})
+		}
+	}
+
+	sort.SliceStable(applications, func(i, j int) bool {
+		if applications[i]["application_name"] != applications[j]["application_name"] {
+			return applications[i]["application_name"].(string) < applications[j]["application_name"].(string)
+		}
+		return applications[i]["granted_via_group_name"].(string) < applications[j]["granted_via_group_name"].(string)
+	})
+
+	d.SetId(user.Id)
+	if err := d.Set("applications", applications); err != nil {
+		return err
+	}
+	return nil
+}
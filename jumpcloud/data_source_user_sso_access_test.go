@@ -0,0 +1,51 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceJumpCloudUserSSOAccess_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				// the group isn't bound to any application
+				Config: testAccDataSourceJumpCloudUserSSOAccessConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.jumpcloud_user_sso_access.test", "id",
+						"jumpcloud_user.test_user", "id"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user_sso_access.test", "applications.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceJumpCloudUserSSOAccessConfig(name string) string {
+	return fmt.Sprintf(`
+resource "jumpcloud_user" "test_user" {
+  username = "%[1]s"
+  email = "%[1]s@testorg.com"
+  firstname = "Firstname"
+  lastname = "Lastname"
+}
+
+resource "jumpcloud_user_group" "test_group" {
+  name = "%[1]s"
+  members = [jumpcloud_user.test_user.email]
+}
+
+data "jumpcloud_user_sso_access" "test" {
+  email = jumpcloud_user.test_user.email
+  depends_on = [jumpcloud_user_group.test_group]
+}
+`, name)
+}
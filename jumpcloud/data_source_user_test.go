@@ -0,0 +1,68 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceJumpCloudUser_lookup(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceJumpCloudUserLookupConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.jumpcloud_user.by_email", "id",
+						"jumpcloud_user.test_user", "id"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user.by_email", "username", rName),
+					resource.TestCheckResourceAttr("data.jumpcloud_user.by_email", "firstname", "Firstname"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user.by_email", "lastname", "Lastname"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user.by_email", "suspended", "true"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user.by_email", "mfa_configured", "false"),
+					resource.TestCheckResourceAttrPair("data.jumpcloud_user.by_username", "id",
+						"jumpcloud_user.test_user", "id"),
+					resource.TestCheckResourceAttr("data.jumpcloud_user.by_username", "email", rName+"@testorg.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceJumpCloudUserLookupConfig(name string) string {
+	return fmt.Sprintf(`
+resource "jumpcloud_user" "test_user" {
+  username  = "%[1]s"
+  email     = "%[1]s@testorg.com"
+  firstname = "Firstname"
+  lastname  = "Lastname"
+  suspended = true
+}
+
+data "jumpcloud_user" "by_email" {
+  email = jumpcloud_user.test_user.email
+}
+
+data "jumpcloud_user" "by_username" {
+  username = jumpcloud_user.test_user.username
+}`, name)
+}
+
+func TestSingleUser(t *testing.T) {
+	user, err := singleUser([]jcapiv1.Systemuserreturn{{Id: "1"}}, "email", "jane.doe@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", user.Id)
+
+	_, err = singleUser(nil, "username", "jane.doe")
+	assert.EqualError(t, err, "no user found with username jane.doe")
+
+	_, err = singleUser([]jcapiv1.Systemuserreturn{{Id: "1"}, {Id: "2"}}, "username", "jane.doe")
+	assert.EqualError(t, err, "more than one user found with username jane.doe: 1, 2")
+}
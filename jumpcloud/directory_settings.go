@@ -0,0 +1,148 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// directorySettings implements the resources managing the settings of a
+// directory integration, e.g. Google Workspace. Connecting the directory
+// requires the consent of the other side, so it is connected in the console
+// and adopted by its name, and destroying the resource only forgets it.
+type directorySettings struct {
+	// path of the endpoint of the directories of the type, e.g. /gsuites
+	path string
+	// directoryType is the type of the directory in the directories API
+	directoryType string
+	// kind names the directory in errors, e.g. "Google Workspace"
+	kind string
+	// service names where users and groups are provisioned, e.g. "Google"
+	service string
+	// tenant names what the domain belongs to, e.g. "Office 365 tenant"
+	tenant string
+}
+
+func (s *directorySettings) schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description:  "The name of the directory in JumpCloud, used to find it when the resource is created.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+		"domain": {
+			Description: fmt.Sprintf("The default domain of the %s.", s.tenant),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		// there is no user_sync: the API can't turn provisioning users
+		// off, the users of the user groups bound to the directory are
+		// provisioned
+		"group_sync": {
+			Description: fmt.Sprintf("Whether the user groups bound to the directory are synced to %s as groups.",
+				s.service),
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"user_lockout_action": {
+			Description:  fmt.Sprintf("What happens to locked out users in %s. Possible values: `suspend`, `maintain`.", s.service),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"suspend", "maintain"}, false),
+		},
+		"user_password_expiration_action": {
+			Description: fmt.Sprintf("What happens to users with an expired password in %s. "+
+				"Possible values: `suspend`, `maintain`.", s.service),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"suspend", "maintain"}, false),
+		},
+	}
+}
+
+// expandDirectorySettings builds the changeable settings of the directory
+// from d, leaving out the lockout actions JumpCloud decides on unless
+// configured
+func expandDirectorySettings(d *schema.ResourceData) DirectorySettings {
+	return DirectorySettings{
+		Name:                         d.Get("name").(string),
+		GroupsEnabled:                d.Get("group_sync").(bool),
+		UserLockoutAction:            d.Get("user_lockout_action").(string),
+		UserPasswordExpirationAction: d.Get("user_password_expiration_action").(string),
+	}
+}
+
+func (s *directorySettings) create(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	id, err := directoryIDByName(config, s.directoryType, s.kind, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return s.update(d, m)
+}
+
+func (s *directorySettings) read(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var directory DirectorySettings
+	ok, err := jumpCloudRequest(config, http.MethodGet, s.path+"/"+d.Id(), nil, &directory)
+	if err != nil {
+		return fmt.Errorf("error reading %s directory %s: %w", s.kind, d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	domain := ""
+	if directory.DefaultDomain != nil {
+		domain = directory.DefaultDomain.Domain
+	}
+
+	if err := d.Set("name", directory.Name); err != nil {
+		return err
+	}
+	if err := d.Set("domain", domain); err != nil {
+		return err
+	}
+	if err := d.Set("group_sync", directory.GroupsEnabled); err != nil {
+		return err
+	}
+	if err := d.Set("user_lockout_action", directory.UserLockoutAction); err != nil {
+		return err
+	}
+	if err := d.Set("user_password_expiration_action", directory.UserPasswordExpirationAction); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *directorySettings) update(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandDirectorySettings(d)
+	if _, err := jumpCloudRequest(config, http.MethodPatch, s.path+"/"+d.Id(), body, nil); err != nil {
+		return fmt.Errorf("error updating %s directory %s: %w", s.kind, d.Id(), err)
+	}
+	return s.read(d, m)
+}
+
+// delete only forgets the directory, disconnecting it is left to the
+// console like connecting it
+func (s *directorySettings) delete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[WARN] %s directory %s is still connected, disconnect it in the JumpCloud console",
+		s.kind, d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,222 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// graphAssociation implements the resources binding objects to a source
+// object through the associations endpoint of the source in the graph API,
+// e.g. user groups to a RADIUS server. The resources are additive: only
+// the configured objects are bound, and unbound once they are no longer
+// configured or the resource is destroyed. Objects bound outside of
+// Terraform are left alone.
+type graphAssociation struct {
+	// path of the associations endpoint, %s is the ID of the source
+	path string
+	// sourceAttribute holds the ID of the source, which is the ID of the
+	// resource as well
+	sourceAttribute string
+	// sourceName names the source in errors, e.g. "RADIUS server"
+	sourceName string
+	// targets maps the set attributes holding the IDs of the bound objects
+	// to their graph type
+	targets map[string]string
+}
+
+// targetName names the graph type in errors, e.g. "user group"
+func targetName(targetType string) string {
+	return strings.ReplaceAll(targetType, "_", " ")
+}
+
+// attributes returns the target attributes in a predictable order
+func (a *graphAssociation) attributes() []string {
+	attributes := []string{}
+	for attribute := range a.targets {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+// list returns the IDs of the objects of targetType bound to the source.
+// ok is false if the source doesn't exist.
+func (a *graphAssociation) list(ctx context.Context, config *Meta, sourceID,
+	targetType string) (ids []string, ok bool, err error) {
+
+	ids = []string{}
+	ok = true
+	err = newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		var graphconnect []jcapiv2.GraphConnection
+		res, found, err := jumpCloudPageRequest(ctx, config, config.BasePath+fmt.Sprintf(a.path, sourceID)+
+			fmt.Sprintf("?targets=%s&limit=%d&skip=%d", targetType, pageSize, skip), &graphconnect)
+		ok = ok && found
+		for _, v := range graphconnect {
+			ids = append(ids, v.To.Id)
+		}
+		return res, len(graphconnect), err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting the %ss of %s %s: %w",
+			targetName(targetType), a.sourceName, sourceID, err)
+	}
+	return ids, ok, nil
+}
+
+// manage binds (action add) or unbinds (action remove) the object
+func (a *graphAssociation) manage(ctx context.Context, config *Meta, sourceID,
+	targetType, targetID, action string) error {
+
+	graphType := jcapiv2.GraphType(targetType)
+	body := jcapiv2.GraphManagementReq{
+		Op:    action,
+		Type_: &graphType,
+		Id:    targetID,
+	}
+
+	if _, err := jumpCloudRequestContext(ctx, config, http.MethodPost, fmt.Sprintf(a.path, sourceID), body, nil); err != nil {
+		return fmt.Errorf("error trying to %s %s %s on %s %s: %w",
+			action, targetName(targetType), targetID, a.sourceName, sourceID, err)
+	}
+	return nil
+}
+
+// sync binds the configured objects and unbinds the ones no longer
+// configured
+func (a *graphAssociation) sync(config *Meta, d *schema.ResourceData) error {
+	ctx := requestContext(config)
+	sourceID := d.Get(a.sourceAttribute).(string)
+
+	for _, attribute := range a.attributes() {
+		targetType := a.targets[attribute]
+		current, ok, err := a.list(ctx, config, sourceID, targetType)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%s %s not found", a.sourceName, sourceID)
+		}
+
+		old, new := d.GetChange(attribute)
+		for _, v := range new.(*schema.Set).List() {
+			if !stringInSlice(v.(string), current) {
+				if err := a.manage(ctx, config, sourceID, targetType, v.(string), "add"); err != nil {
+					return err
+				}
+			}
+		}
+		for _, v := range old.(*schema.Set).Difference(new.(*schema.Set)).List() {
+			if stringInSlice(v.(string), current) {
+				if err := a.manage(ctx, config, sourceID, targetType, v.(string), "remove"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *graphAssociation) create(d *schema.ResourceData, m interface{}) error {
+	if err := a.sync(m.(*Meta), d); err != nil {
+		return err
+	}
+	d.SetId(d.Get(a.sourceAttribute).(string))
+	return a.read(d, m)
+}
+
+func (a *graphAssociation) read(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	// objects unbound outside of Terraform show up as drift and are bound
+	// again by the next apply
+	for _, attribute := range a.attributes() {
+		current, ok, err := a.list(ctx, config, d.Id(), a.targets[attribute])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// the source was deleted
+			d.SetId("")
+			return nil
+		}
+
+		bound := []string{}
+		for _, v := range d.Get(attribute).(*schema.Set).List() {
+			if stringInSlice(v.(string), current) {
+				bound = append(bound, v.(string))
+			}
+		}
+		if err := d.Set(attribute, bound); err != nil {
+			return err
+		}
+	}
+	return d.Set(a.sourceAttribute, d.Id())
+}
+
+func (a *graphAssociation) update(d *schema.ResourceData, m interface{}) error {
+	if err := a.sync(m.(*Meta), d); err != nil {
+		return err
+	}
+	return a.read(d, m)
+}
+
+func (a *graphAssociation) delete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	for _, attribute := range a.attributes() {
+		targetType := a.targets[attribute]
+		current, _, err := a.list(ctx, config, d.Id(), targetType)
+		if err != nil {
+			return err
+		}
+		for _, v := range d.Get(attribute).(*schema.Set).List() {
+			if stringInSlice(v.(string), current) {
+				if err := a.manage(ctx, config, d.Id(), targetType, v.(string), "remove"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// importer takes source_id:target_id,... for a single target attribute.
+// The IDs of several target attributes can't be told apart, so then the
+// ID is the one of the source, and all of its current associations are
+// imported.
+func (a *graphAssociation) importer(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(a.targets) > 1 {
+		config := m.(*Meta)
+		for _, attribute := range a.attributes() {
+			ids, ok, err := a.list(requestContext(config), config, d.Id(), a.targets[attribute])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("%s %s not found", a.sourceName, d.Id())
+			}
+			_ = d.Set(attribute, ids)
+		}
+		_ = d.Set(a.sourceAttribute, d.Id())
+		return []*schema.ResourceData{d}, nil
+	}
+
+	attribute := a.attributes()[0]
+	sourceID, ids, err := parseAssociationImportID(d.Id(), a.sourceAttribute, a.targets[attribute]+"_id")
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(sourceID)
+	_ = d.Set(a.sourceAttribute, sourceID)
+	_ = d.Set(attribute, ids)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,54 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphAssociationList(t *testing.T) {
+	var skips []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/commands/gone/associations" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "/commands/cmd/associations", r.URL.Path)
+		assert.Equal(t, "system", r.URL.Query().Get("targets"))
+		skip := r.URL.Query().Get("skip")
+		skips = append(skips, skip)
+
+		// a full first page and a partial second one
+		count := pageSize
+		if skip != "0" {
+			count = 1
+		}
+		page := make([]jcapiv2.GraphConnection, count)
+		for i := range page {
+			page[i].To = &jcapiv2.GraphObject{Id: "system" + skip + "-" + strconv.Itoa(i)}
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(page))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	ids, ok, err := commandAssociation.list(context.TODO(), config, "cmd", "system")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, ids, pageSize+1)
+	assert.Equal(t, "system100-0", ids[pageSize])
+	assert.Equal(t, []string{"0", "100"}, skips)
+
+	// the command was deleted
+	_, ok, err = commandAssociation.list(context.TODO(), config, "gone", "system")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
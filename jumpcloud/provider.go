@@ -1,14 +1,17 @@
 package jumpcloud
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 // Provider instantiates a terraform provider for Jumpcloud
 // This includes all operations on all supported resources and
 // global Jumpcloud parameters
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
@@ -21,24 +24,170 @@ func Provider() *schema.Provider {
 				Required:    false,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUMPCLOUD_ORG_ID", nil),
-				Description: descriptions["org_id"],
+				// an empty org_id would silently fall back to the API key's default organization
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  descriptions["org_id"],
+			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUMPCLOUD_API_URL", defaultAPIURL),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  descriptions["api_url"],
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["user_agent_suffix"],
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["insecure"],
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  descriptions["proxy_url"],
+			},
+			"ca_cert_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCACertPEM,
+				Description:  descriptions["ca_cert_pem"],
+			},
+			"member_not_found_behavior": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "error",
+				ValidateFunc: validation.StringInSlice([]string{"error", "warn", "ignore"}, false),
+				Description:  descriptions["member_not_found_behavior"],
+			},
+			"max_member_removal_per_apply": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_member_removal_per_apply"],
+			},
+			"use_bulk_operations": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: descriptions["use_bulk_operations"],
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_retries"],
+			},
+			"retry_base_delay_ms": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["retry_base_delay_ms"],
+			},
+			"member_concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  descriptions["member_concurrency"],
+			},
+			"cache_user_lookups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: descriptions["cache_user_lookups"],
+			},
+			"email_lookup_batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  descriptions["email_lookup_batch_size"],
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"jumpcloud_application":            resourceApplication(),
-			"jumpcloud_user":                   resourceUser(),
-			"jumpcloud_user_group":             resourceUserGroup(),
-			"jumpcloud_user_group_membership":  resourceUserGroupMembership(),
-			"jumpcloud_system_group":           resourceGroupsSystem(),
-			"jumpcloud_user_group_association": resourceUserGroupAssociation(),
+			"jumpcloud_application":                           resourceApplication(),
+			"jumpcloud_user":                                  resourceUser(),
+			"jumpcloud_user_group":                            resourceUserGroup(),
+			"jumpcloud_user_group_membership":                 resourceUserGroupMembership(),
+			"jumpcloud_system_group":                          resourceSystemGroup(),
+			"jumpcloud_system_group_membership":               resourceSystemGroupMembership(),
+			"jumpcloud_user_group_association":                resourceUserGroupAssociation(),
+			"jumpcloud_google_workspace_sync_rule":            resourceGoogleWorkspaceSyncRule(),
+			"jumpcloud_group_ldap_attribute":                  resourceGroupLdapAttribute(),
+			"jumpcloud_user_ldap_attribute":                   resourceUserLdapAttribute(),
+			"jumpcloud_organization_branding":                 resourceOrganizationBranding(),
+			"jumpcloud_password_manager_settings":             resourcePasswordManagerSettings(),
+			"jumpcloud_provider_binding":                      resourceProviderBinding(),
+			"jumpcloud_user_custom_fields":                    resourceUserCustomFields(),
+			"jumpcloud_user_attribute_sync":                   resourceUserAttributeSync(),
+			"jumpcloud_user_unlock":                           resourceUserUnlock(),
+			"jumpcloud_user_activation_email_resend":          resourceUserActivationEmailResend(),
+			"jumpcloud_user_group_device_restrictions":        resourceUserGroupDeviceRestrictions(),
+			"jumpcloud_user_group_member_expiry_notification": resourceUserGroupMemberExpiryNotification(),
+			"jumpcloud_user_group_manager":                    resourceUserGroupManager(),
+			"jumpcloud_user_group_access_expiry":              resourceUserGroupAccessExpiry(),
+			"jumpcloud_user_group_tag":                        resourceUserGroupTag(),
+			"jumpcloud_user_group_permission_set":             resourceUserGroupPermissionSet(),
+			"jumpcloud_user_group_provisioning_attribute":     resourceUserGroupProvisioningAttribute(),
+			"jumpcloud_user_group_scim_attribute":             resourceUserGroupScimAttribute(),
+			"jumpcloud_application_user_group_association":    resourceApplicationUserGroupAssociation(),
+			"jumpcloud_application_group_sync":                resourceApplicationGroupSync(),
+			"jumpcloud_application_group_membership_sync":     resourceApplicationGroupMembershipSync(),
+			"jumpcloud_application_sp_certificate":            resourceApplicationSPCertificate(),
+			"jumpcloud_command":                               resourceCommand(),
+			"jumpcloud_command_association":                   resourceCommandAssociation(),
+			"jumpcloud_radius_server":                         resourceRadiusServer(),
+			"jumpcloud_radius_server_user_group_association":  resourceRadiusServerUserGroupAssociation(),
+			"jumpcloud_ldap_server_user_group_association":    resourceLdapServerUserGroupAssociation(),
+			"jumpcloud_policy":                                resourcePolicy(),
+			"jumpcloud_policy_group_association":              resourcePolicyGroupAssociation(),
+			"jumpcloud_command_result":                        resourceCommandResult(),
+			"jumpcloud_directory_sync_job":                    resourceDirectorySyncJob(),
+			"jumpcloud_system_group_tag":                      resourceSystemGroupTag(),
+			"jumpcloud_system_mdm_profile":                    resourceSystemMDMProfile(),
+			"jumpcloud_system_command_schedule":               resourceSystemCommandSchedule(),
+			"jumpcloud_active_directory":                      resourceActiveDirectory(),
+			"jumpcloud_g_suite":                               resourceGSuite(),
+			"jumpcloud_office365":                             resourceOffice365(),
+			"jumpcloud_software_app":                          resourceSoftwareApp(),
+			"jumpcloud_software_app_association":              resourceSoftwareAppAssociation(),
+			"jumpcloud_password_policy":                       resourcePasswordPolicy(),
+			"jumpcloud_scim_server":                           resourceScimServer(),
+			"jumpcloud_ip_list":                               resourceIPList(),
+			"jumpcloud_conditional_access_policy":             resourceConditionalAccessPolicy(),
+			"jumpcloud_user_system_association":               resourceUserSystemAssociation(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"jumpcloud_user":        dataSourceJumpCloudUser(),
-			"jumpcloud_user_group":  dataSourceJumpCloudUserGroup(),
-			"jumpcloud_application": dataSourceJumpCloudApplication(),
+			"jumpcloud_user":                           dataSourceJumpCloudUser(),
+			"jumpcloud_user_group":                     dataSourceJumpCloudUserGroup(),
+			"jumpcloud_application":                    dataSourceJumpCloudApplication(),
+			"jumpcloud_user_group_inactive_members":    dataSourceJumpCloudUserGroupInactiveMembers(),
+			"jumpcloud_user_group_membership_log":      dataSourceJumpCloudUserGroupMembershipLog(),
+			"jumpcloud_user_group_export_members":      dataSourceJumpCloudUserGroupExportMembers(),
+			"jumpcloud_application_attribute":          dataSourceJumpCloudApplicationAttribute(),
+			"jumpcloud_user_sso_access":                dataSourceJumpCloudUserSSOAccess(),
+			"jumpcloud_system_group_policy_compliance": dataSourceJumpCloudSystemGroupPolicyCompliance(),
+			"jumpcloud_api_rate_limit":                 dataSourceJumpCloudAPIRateLimit(),
+			"jumpcloud_policy_templates":               dataSourceJumpCloudPolicyTemplates(),
+			"jumpcloud_ldap_server":                    dataSourceJumpCloudLdapServer(),
+			"jumpcloud_system":                         dataSourceJumpCloudSystem(),
+			"jumpcloud_systems":                        dataSourceJumpCloudSystems(),
+			"jumpcloud_organization":                   dataSourceJumpCloudOrganization(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, p.StopContext())
+	}
+	return p
 }
 
 var descriptions map[string]string
@@ -46,14 +195,60 @@ var descriptions map[string]string
 func init() {
 	descriptions = map[string]string{
 		"api_key": "The x-api-key header used to connect to JumpCloud.",
-		"org_id":  "The x-org-id header used to connect to JumpCloud.",
+		"org_id": "The x-org-id header used to connect to JumpCloud, sent with every request. Required by " +
+			"multi-tenant (MSP) admins, omitting it uses the default organization of the API key.",
+		"api_url": "The URL of the JumpCloud console the API is served from, " +
+			"e.g. for regional or staging environments. Defaults to https://console.jumpcloud.com.",
+		"user_agent_suffix": "Appended to the `terraform-provider-jumpcloud/<version>` User-Agent header of every " +
+			"request, e.g. to attribute API traffic to a team or pipeline in JumpCloud's logs.",
+		"insecure": "Skip verifying the TLS certificate of the API. Only meant for testing against staging " +
+			"environments or debugging proxies, never for production.",
+		"proxy_url": "The URL of the proxy requests are sent through, e.g. `http://proxy.example.com:3128`. " +
+			"Defaults to the proxy of the `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+		"ca_cert_pem": "PEM encoded CA certificates trusted in addition to the system's, e.g. the one of a " +
+			"TLS inspecting corporate proxy.",
+		"member_not_found_behavior": "What to do when a group member email doesn't match a JumpCloud user: " +
+			"`error` fails the apply, `warn` logs a warning and skips the member, `ignore` skips it silently.",
+		"max_member_removal_per_apply": "The maximum number of members that may be removed from a single user group " +
+			"in one apply, guarding against accidentally emptied member lists. 0 means unlimited.",
+		"use_bulk_operations": "Manage user group members through JumpCloud's bulk endpoint when it's available. " +
+			"Disable to send one request per member, e.g. for debugging.",
+		"max_retries": "How often a request that was rate limited by JumpCloud (HTTP 429) is retried. " +
+			"0 disables retrying.",
+		"retry_base_delay_ms": "The delay in milliseconds before the first retry of a rate limited request, " +
+			"doubled for every further retry. A `Retry-After` header sent by JumpCloud takes precedence.",
+		"member_concurrency": "The number of user group members added or removed in parallel when the bulk endpoint " +
+			"isn't used.",
+		"cache_user_lookups": "Resolve every user email and ID only once per run, even if it is a member of several groups. " +
+			"Disable to always look users up, e.g. for debugging.",
+		"email_lookup_batch_size": "The number of user emails resolved to IDs, or IDs to emails, per request. " +
+			"Larger batches need fewer requests for large groups but longer URLs, which may exceed the limits of " +
+			"proxies in between.",
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, stopContext context.Context) (interface{}, error) {
 	config := Config{
 		APIKey: d.Get("api_key").(string),
 		OrgID:  d.Get("org_id").(string),
+		APIURL: d.Get("api_url").(string),
+
+		UserAgentSuffix: d.Get("user_agent_suffix").(string),
+
+		Insecure:  d.Get("insecure").(bool),
+		ProxyURL:  d.Get("proxy_url").(string),
+		CACertPEM: d.Get("ca_cert_pem").(string),
+
+		MemberNotFoundBehavior:   d.Get("member_not_found_behavior").(string),
+		MaxMemberRemovalPerApply: d.Get("max_member_removal_per_apply").(int),
+		UseBulkOperations:        d.Get("use_bulk_operations").(bool),
+		MaxRetries:               d.Get("max_retries").(int),
+		RetryBaseDelayMS:         d.Get("retry_base_delay_ms").(int),
+		MemberConcurrency:        d.Get("member_concurrency").(int),
+		CacheUserLookups:         d.Get("cache_user_lookups").(bool),
+		EmailLookupBatchSize:     d.Get("email_lookup_batch_size").(int),
+
+		StopContext: stopContext,
 	}
 
 	return config.Client()
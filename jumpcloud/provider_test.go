@@ -3,8 +3,17 @@ package jumpcloud
 // see https://www.terraform.io/docs/plugins/provider.html#provider
 
 import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
@@ -26,3 +35,271 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProviderSettings(t *testing.T) {
+	c := Config{APIKey: "key", MemberNotFoundBehavior: "warn"}
+	config, err := c.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if got := config.(*Meta).Settings.MemberNotFoundBehavior; got != "warn" {
+		t.Fatalf("expected warn, got %s", got)
+	}
+	if got := newMeta(jcapiv2.NewConfiguration()).Settings.MemberNotFoundBehavior; got != "error" {
+		t.Fatalf("expected error, got %s", got)
+	}
+}
+
+func TestRequestContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config, err := (&Config{APIKey: "key", StopContext: ctx}).Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if got := requestContext(config.(*Meta)); got != ctx {
+		t.Fatalf("expected the stop context, got %v", got)
+	}
+	// configurations not made by the provider are never stopped
+	if got := requestContext(newMeta(jcapiv2.NewConfiguration())); got.Done() != nil {
+		t.Fatalf("expected a background context, got %v", got)
+	}
+}
+
+func TestConfigAPIURL(t *testing.T) {
+	c := Config{APIKey: "key", APIURL: "https://console.eu.jumpcloud.com/"}
+	config, err := c.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	configv2 := config.(*Meta)
+	if configv2.BasePath != "https://console.eu.jumpcloud.com/api/v2" {
+		t.Fatalf("unexpected v2 base path %s", configv2.BasePath)
+	}
+	if got := convertV2toV1Config(configv2).BasePath; got != "https://console.eu.jumpcloud.com/api" {
+		t.Fatalf("unexpected v1 base path %s", got)
+	}
+
+	// the SDK's default is the production console
+	config, err = (&Config{APIKey: "key"}).Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if got := config.(*Meta).BasePath; got != defaultAPIURL+"/api/v2" {
+		t.Fatalf("unexpected default base path %s", got)
+	}
+}
+
+func TestProviderOrgIDValidation(t *testing.T) {
+	for orgID, valid := range map[string]bool{
+		"5f1b1bb2c1d5f40001b2a3c4": true,
+		"":                         false,
+		"  ":                       false,
+	} {
+		raw := map[string]interface{}{"api_key": "key", "org_id": orgID}
+		_, errs := Provider().Validate(terraform.NewResourceConfigRaw(raw))
+		if valid != (len(errs) == 0) {
+			t.Errorf("org_id %q: expected valid = %t, got errors %v", orgID, valid, errs)
+		}
+	}
+}
+
+func TestAddDefaultHeaders(t *testing.T) {
+	config, err := (&Config{APIKey: "key", OrgID: "org"}).Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/usergroups", nil)
+	addDefaultHeaders(req, config.(*Meta))
+	if req.Header.Get("x-api-key") != "key" || req.Header.Get("x-org-id") != "org" {
+		t.Fatalf("unexpected headers %v", req.Header)
+	}
+	// the same organization is used by the v1 API
+	if got := convertV2toV1Config(config.(*Meta)).DefaultHeader["x-org-id"]; got != "org" {
+		t.Fatalf("unexpected v1 x-org-id %s", got)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var userAgents []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		rw.Write([]byte("{}"))
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{APIKey: "key", APIURL: testServer.URL, UserAgentSuffix: "team-infra"}).Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	configv2 := config.(*Meta)
+	configv1 := convertV2toV1Config(configv2)
+
+	// both SDK clients, raw requests and the metadata download
+	_, _, _ = jcapiv2.NewAPIClient(configv2.Configuration).UserGroupsApi.GroupsUserGet(context.TODO(), "id", "", "", nil)
+	_, _, _ = jcapiv1.NewAPIClient(configv1).SystemsApi.SystemsGet(context.TODO(), "id", "", "", nil)
+	_, _ = jumpCloudRequest(configv2, http.MethodGet, "/usergroups/id", nil, nil)
+	_, _ = GetApplicationMetadataXml(configv2, "id")
+
+	expected := "terraform-provider-jumpcloud/" + ProviderVersion + " team-infra"
+	if len(userAgents) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(userAgents))
+	}
+	for i, ua := range userAgents {
+		if ua != expected {
+			t.Errorf("request %d: expected User-Agent %q, got %q", i, expected, ua)
+		}
+	}
+
+	// without a suffix only the provider and its version are sent
+	if got := userAgent(""); got != "terraform-provider-jumpcloud/"+ProviderVersion {
+		t.Fatalf("unexpected default User-Agent %s", got)
+	}
+}
+
+// stubHTTPClient answers every request with body instead of sending it
+type stubHTTPClient struct {
+	requests []*http.Request
+	body     string
+}
+
+func (c *stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestHTTPClient(t *testing.T) {
+	stub := &stubHTTPClient{body: `{"id": "group", "name": "engineering"}`}
+	config, err := (&Config{APIKey: "key", OrgID: "org", HTTPClient: stub}).Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	configv2 := config.(*Meta)
+
+	group, ok, err := userGroupReadHelper(context.TODO(), configv2, "group")
+	if err != nil || !ok || group.Name != "engineering" {
+		t.Fatalf("unexpected user group %+v, found %t: %v", group, ok, err)
+	}
+	metadata, err := GetApplicationMetadataXml(configv2, "app")
+	if err != nil || metadata != stub.body {
+		t.Fatalf("unexpected metadata %q: %v", metadata, err)
+	}
+
+	if len(stub.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(stub.requests))
+	}
+	if got := stub.requests[0].URL.String(); got != "https://console.jumpcloud.com/api/v2/usergroups/group" {
+		t.Errorf("unexpected user group URL %s", got)
+	}
+	if got := stub.requests[1].URL.String(); got != "https://console.jumpcloud.com/api/organizations/org/applications/app/metadata.xml" {
+		t.Errorf("unexpected metadata URL %s", got)
+	}
+	for _, req := range stub.requests {
+		if req.Header.Get("x-api-key") != "key" || req.Header.Get("x-org-id") != "org" {
+			t.Errorf("request to %s isn't authenticated", req.URL)
+		}
+	}
+
+	// the default client bounds requests and is shared with the SDK clients
+	config, err = (&Config{APIKey: "key"}).Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	configv2 = config.(*Meta)
+	client, ok := configv2.Settings.httpClient.(*http.Client)
+	if !ok || client.Timeout != defaultHTTPTimeout {
+		t.Fatalf("unexpected default HTTP client %+v", configv2.Settings.httpClient)
+	}
+	if configv2.HTTPClient != client || convertV2toV1Config(configv2).HTTPClient != client {
+		t.Fatal("expected the SDK clients to use the HTTP client of the provider")
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("{}"))
+	}))
+	defer tlsServer.Close()
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw}))
+
+	// the test server's certificate is only trusted when configured
+	for _, c := range []struct {
+		Insecure  bool
+		CACertPEM string
+		Trusted   bool
+	}{
+		{false, "", false},
+		{false, caCertPEM, true},
+		{true, "", true},
+	} {
+		client, err := newHTTPClient(c.Insecure, "", c.CACertPEM)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		res, err := client.Get(tlsServer.URL)
+		if err == nil {
+			res.Body.Close()
+		}
+		if c.Trusted != (err == nil) {
+			t.Errorf("insecure %t, CA configured %t: expected trusted = %t, got %v",
+				c.Insecure, c.CACertPEM != "", c.Trusted, err)
+		}
+	}
+
+	if _, err := newHTTPClient(false, "", "not a certificate"); err == nil {
+		t.Fatal("expected an error for an invalid CA certificate")
+	}
+
+	// requests go through the proxy, including the ones of the SDK clients
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		rw.Write([]byte("{}"))
+	}))
+	defer proxy.Close()
+
+	config, err := (&Config{APIKey: "key", APIURL: "http://jumpcloud.invalid", ProxyURL: proxy.URL}).Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	configv2 := config.(*Meta)
+	_, _ = jumpCloudRequest(configv2, http.MethodGet, "/usergroups/id", nil, nil)
+	_, _, _ = jcapiv2.NewAPIClient(configv2.Configuration).UserGroupsApi.GroupsUserGet(context.TODO(), "id", "", "", nil)
+	expected := []string{
+		"http://jumpcloud.invalid/api/v2/usergroups/id",
+		"http://jumpcloud.invalid/api/v2/usergroups/id",
+	}
+	if !reflect.DeepEqual(proxied, expected) {
+		t.Fatalf("expected proxied requests %v, got %v", expected, proxied)
+	}
+}
+
+func TestProviderTLSProxyValidation(t *testing.T) {
+	for _, c := range []struct {
+		Key   string
+		Value string
+		Valid bool
+	}{
+		{"proxy_url", "http://proxy.example.com:3128", true},
+		{"proxy_url", "socks5://127.0.0.1:1080", true},
+		{"proxy_url", "proxy.example.com:3128", false},
+		{"ca_cert_pem", "-----BEGIN CERTIFICATE-----\nnope\n-----END CERTIFICATE-----\n", false},
+		{"ca_cert_pem", "", true},
+	} {
+		raw := map[string]interface{}{"api_key": "key", c.Key: c.Value}
+		_, errs := Provider().Validate(terraform.NewResourceConfigRaw(raw))
+		if c.Valid != (len(errs) == 0) {
+			t.Errorf("%s %q: expected valid = %t, got errors %v", c.Key, c.Value, c.Valid, errs)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceActiveDirectory() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing the integration of an Active Directory domain with JumpCloud. " +
+			"The domain is synced by the AD agents installed on its domain controllers.",
+		Create: resourceActiveDirectoryCreate,
+		Read:   resourceActiveDirectoryRead,
+		Delete: resourceActiveDirectoryDelete,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description:  "The domain to integrate, e.g. `DC=corp,DC=example,DC=com`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"sync_status": {
+				Description: "The sync status of the domain: `connected` if one of its AD agents is connected, " +
+					"otherwise the state of its first agent, or `no_agent` while no agent is installed.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// activeDirectorySyncStatus sums up the states of the AD agents of a
+// domain, any connected agent keeps the domain in sync
+func activeDirectorySyncStatus(agents []jcapiv2.ActiveDirectoryAgentListOutput) string {
+	if len(agents) == 0 {
+		return "no_agent"
+	}
+	for _, agent := range agents {
+		if agent.State == "connected" {
+			return agent.State
+		}
+	}
+	return agents[0].State
+}
+
+func resourceActiveDirectoryCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := jcapiv2.ActiveDirectoryInput{Domain: d.Get("domain").(string)}
+	var directory jcapiv2.ActiveDirectoryOutput
+	if _, err := jumpCloudRequest(config, http.MethodPost, "/activedirectories", body, &directory); err != nil {
+		return fmt.Errorf("error creating Active Directory %s: %w", body.Domain, err)
+	}
+
+	d.SetId(directory.Id)
+	return resourceActiveDirectoryRead(d, m)
+}
+
+func resourceActiveDirectoryRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var directory jcapiv2.ActiveDirectoryOutput
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/activedirectories/"+d.Id(), nil, &directory)
+	if err != nil {
+		return fmt.Errorf("error reading Active Directory %s: %w", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	var agents []jcapiv2.ActiveDirectoryAgentListOutput
+	if _, err := jumpCloudRequest(config, http.MethodGet,
+		fmt.Sprintf("/activedirectories/%s/agents?limit=%d", d.Id(), pageSize), nil, &agents); err != nil {
+		return fmt.Errorf("error listing the agents of Active Directory %s: %w", d.Id(), err)
+	}
+
+	if err := d.Set("domain", directory.Domain); err != nil {
+		return err
+	}
+	if err := d.Set("sync_status", activeDirectorySyncStatus(agents)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceActiveDirectoryDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudRequest(config, http.MethodDelete, "/activedirectories/"+d.Id(), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting Active Directory %s: %w", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
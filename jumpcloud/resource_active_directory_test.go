@@ -0,0 +1,102 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccActiveDirectory(t *testing.T) {
+	domain := fmt.Sprintf("DC=%s,DC=example,DC=com", acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	fullResourceName := "jumpcloud_active_directory.test_directory"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "jumpcloud_active_directory" "test_directory" {
+						domain = "%s"
+					}`, domain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "domain", domain),
+					// no agent is installed for the test domain
+					resource.TestCheckResourceAttr(fullResourceName, "sync_status", "no_agent"),
+				),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestActiveDirectoryCreateRead(t *testing.T) {
+	var directory *jcapiv2.ActiveDirectoryOutput
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/activedirectories":
+			var input jcapiv2.ActiveDirectoryInput
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+			directory = &jcapiv2.ActiveDirectoryOutput{Id: "ad", Domain: input.Domain}
+			assert.NoError(t, json.NewEncoder(rw).Encode(directory))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/activedirectories/ad":
+			if directory == nil {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			assert.NoError(t, json.NewEncoder(rw).Encode(directory))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/activedirectories/ad/agents":
+			rw.Write([]byte(`[{"id":"agent1","state":"disconnected"},{"id":"agent2","state":"connected"}]`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/activedirectories/ad":
+			directory = nil
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceActiveDirectory()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"domain": "DC=corp,DC=example,DC=com",
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "ad", d.Id())
+	assert.Equal(t, "DC=corp,DC=example,DC=com", d.Get("domain"))
+	assert.Equal(t, "connected", d.Get("sync_status"))
+
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, "", d.Id())
+
+	// a directory deleted outside of Terraform is removed from state
+	d.SetId("ad")
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "", d.Id())
+}
+
+func TestActiveDirectorySyncStatus(t *testing.T) {
+	assert.Equal(t, "no_agent", activeDirectorySyncStatus(nil))
+	assert.Equal(t, "disconnected", activeDirectorySyncStatus([]jcapiv2.ActiveDirectoryAgentListOutput{
+		{Id: "agent1", State: "disconnected"},
+		{Id: "agent2", State: "unsealed"},
+	}))
+	assert.Equal(t, "connected", activeDirectorySyncStatus([]jcapiv2.ActiveDirectoryAgentListOutput{
+		{Id: "agent1", State: "disconnected"},
+		{Id: "agent2", State: "connected"},
+	}))
+}
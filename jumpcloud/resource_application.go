@@ -4,24 +4,13 @@ import (
 	"log"
 
 	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
-	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-
 	// "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"golang.org/x/net/context"
 )
 
-type Constant struct {
-	name string
-	value string
-	read_only bool
-	required bool
-	visible bool
-}
-
 func resourceApplication() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Provides a resource for adding an Amazon Web Services (AWS) account application. **Note:** This resource is due to change in future versions to be more generic and allow for adding various applications supported by JumpCloud.",
+		Description: "Provides a resource for managing a SAML application. The metadata XML JumpCloud generates for it is exposed as `metadata_xml`.",
 		Create:      resourceApplicationCreate,
 		Read:        resourceApplicationRead,
 		Update:      resourceApplicationUpdate,
@@ -38,8 +27,8 @@ func resourceApplication() *schema.Resource {
 			"beta": {
 				Description: "",
 				Type:        schema.TypeBool,
-				Default:	 false,
-				Optional:      true,
+				Default:     false,
+				Optional:    true,
 			},
 			"display_label": {
 				Description: "Name of the application to display",
@@ -54,63 +43,63 @@ func resourceApplication() *schema.Resource {
 			"learn_more": {
 				Description: "",
 				Type:        schema.TypeString,
-				Optional:	 true,
+				Optional:    true,
 			},
-			"constant_attributes":{
-				Description:	"",
-				Type:			schema.TypeList,
-				Optional:	    true,
-				Elem:			&schema.Resource{
+			"constant_attributes": {
+				Description: "",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"name":{
-							Type: schema.TypeString,
+						"name": {
+							Type:     schema.TypeString,
 							Required: true,
 						},
-						"value":{
-							Type: schema.TypeString,
+						"value": {
+							Type:     schema.TypeString,
 							Required: true,
 						},
-						"read_only":{
-							Type: schema.TypeBool,
+						"read_only": {
+							Type:     schema.TypeBool,
 							Optional: true,
-							Default: false,
+							Default:  false,
 						},
-						"required":{
-							Type: schema.TypeBool,
+						"required": {
+							Type:     schema.TypeBool,
 							Optional: true,
-							Default: false,
+							Default:  false,
 						},
-						"visible":{
-							Type: schema.TypeBool,
+						"visible": {
+							Type:     schema.TypeBool,
 							Optional: true,
-							Default: true,
+							Default:  true,
 						},
 					},
 				},
 			},
-			"idp_certificate":{
+			"idp_certificate": {
 				Description: "",
 				Type:        schema.TypeString,
 				Required:    true,
 				Sensitive:   true,
 			},
-			"idp_entity_id":{
+			"idp_entity_id": {
 				Description: "",
 				Type:        schema.TypeString,
 				Required:    true,
 			},
-			"idp_private_key":{
+			"idp_private_key": {
 				Description: "",
 				Type:        schema.TypeString,
 				Required:    true,
-				Sensitive:	 true,
+				Sensitive:   true,
 			},
-			"sp_entity_id":{
+			"sp_entity_id": {
 				Description: "",
 				Type:        schema.TypeString,
 				Required:    true,
 			},
-			"acs_url":{
+			"acs_url": {
 				Description: "",
 				Type:        schema.TypeString,
 				Required:    true,
@@ -125,7 +114,8 @@ func resourceApplication() *schema.Resource {
 }
 
 func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
-	configv1 := convertV2toV1Config(meta.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(meta.(*Meta))
+	ctx := requestContext(meta.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 
 	payload := generateApplicationPayload(d)
@@ -134,7 +124,7 @@ func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Println("[INFO] body=", request["body"])
-	returnStruct, _, err := client.ApplicationsApi.ApplicationsPost(context.TODO(), request)
+	returnStruct, _, err := client.ApplicationsApi.ApplicationsPost(ctx, request)
 	if err != nil {
 		return err
 	}
@@ -144,10 +134,11 @@ func resourceApplicationCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceApplicationRead(d *schema.ResourceData, meta interface{}) error {
-	configv1 := convertV2toV1Config(meta.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(meta.(*Meta))
+	ctx := requestContext(meta.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 
-	res, _, err := client.ApplicationsApi.ApplicationsGet(context.TODO(), d.Id(), nil)
+	res, _, err := client.ApplicationsApi.ApplicationsGet(ctx, d.Id(), nil)
 
 	// If the object does not exist, unset the ID
 	if err != nil {
@@ -166,13 +157,34 @@ func resourceApplicationRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("sso_url", res.SsoUrl); err != nil {
 		return err
 	}
-	
+	if err := d.Set("name", res.Name); err != nil {
+		return err
+	}
+	if err := d.Set("beta", res.Beta); err != nil {
+		return err
+	}
+	if err := d.Set("learn_more", res.LearnMore); err != nil {
+		return err
+	}
+	if err := d.Set("constant_attributes", flattenConstantAttributes(res.Config)); err != nil {
+		return err
+	}
+	// the certificate and the private key are kept as configured
+	if res.Config != nil {
+		if err := d.Set("acs_url", applicationConfigValue(res.Config.AcsUrl)); err != nil {
+			return err
+		}
+		if err := d.Set("idp_entity_id", applicationConfigValue(res.Config.IdpEntityId)); err != nil {
+			return err
+		}
+		if err := d.Set("sp_entity_id", applicationConfigValue(res.Config.SpEntityId)); err != nil {
+			return err
+		}
+	}
+
 	if res.Id != "" {
 		log.Println("[INFO] response ID is ", res.Id)
-		orgId := configv1.DefaultHeader["x-org-id"]
-		apiKey := configv1.DefaultHeader["x-api-key"]
-
-		metadataXml, err := GetApplicationMetadataXml(orgId, res.Id, apiKey)
+		metadataXml, err := GetApplicationMetadataXml(meta.(*Meta), res.Id)
 		if err != nil {
 			return err
 		}
@@ -188,7 +200,8 @@ func resourceApplicationRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
-	configv1 := convertV2toV1Config(meta.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(meta.(*Meta))
+	ctx := requestContext(meta.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 
 	payload := generateApplicationPayload(d)
@@ -196,7 +209,7 @@ func resourceApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
 		"body": payload,
 	}
 
-	_, _, err := client.ApplicationsApi.ApplicationsPut(context.TODO(), d.Id(), request)
+	_, _, err := client.ApplicationsApi.ApplicationsPut(ctx, d.Id(), request)
 	if err != nil {
 		return err
 	}
@@ -204,10 +217,11 @@ func resourceApplicationUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceApplicationDelete(d *schema.ResourceData, meta interface{}) error {
-	configv1 := convertV2toV1Config(meta.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(meta.(*Meta))
+	ctx := requestContext(meta.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 
-	_, _, err := client.ApplicationsApi.ApplicationsDelete(context.TODO(), d.Id(), nil)
+	_, _, err := client.ApplicationsApi.ApplicationsDelete(ctx, d.Id(), nil)
 	if err != nil {
 		return err
 	}
@@ -216,19 +230,48 @@ func resourceApplicationDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func generateApplicationPayload(d *schema.ResourceData) jcapiv1.Application {
+func expandConstantAttributes(input []interface{}) []jcapiv1.ApplicationConfigConstantAttributesValue {
 	constants := []jcapiv1.ApplicationConfigConstantAttributesValue{}
-	for _, data_raw := range d.Get("constant_attributes").([]interface{}) {
-		data := data_raw.(map[string]interface{})
-		constant := jcapiv1.ApplicationConfigConstantAttributesValue{}
+	for _, v := range input {
+		data := v.(map[string]interface{})
+		constants = append(constants, jcapiv1.ApplicationConfigConstantAttributesValue{
+			Name:     data["name"].(string),
+			Value:    data["value"].(string),
+			ReadOnly: data["read_only"].(bool),
+			Required: data["required"].(bool),
+			Visible:  data["visible"].(bool),
+		})
+	}
+	return constants
+}
 
-		constant.Name = data["name"].([]interface{})[0].(string)
-		constant.Value = data["value"].([]interface{})[0].(string)
-		constant.ReadOnly= data["read_only"].([]interface{})[0].(bool)
-		constant.Required = data["required"].([]interface{})[0].(bool)
-		constant.Visible = data["visible"].([]interface{})[0].(bool)
-		constants = append(constants, constant)
+func flattenConstantAttributes(config *jcapiv1.ApplicationConfig) []interface{} {
+	constants := []interface{}{}
+	if config == nil || config.ConstantAttributes == nil {
+		return constants
 	}
+	for _, v := range config.ConstantAttributes.Value {
+		constants = append(constants, map[string]interface{}{
+			"name":      v.Name,
+			"value":     v.Value,
+			"read_only": v.ReadOnly,
+			"required":  v.Required,
+			"visible":   v.Visible,
+		})
+	}
+	return constants
+}
+
+// applicationConfigValue returns the value of a field of an application's
+// config, which may be missing from the API's response
+func applicationConfigValue(field *jcapiv1.ApplicationConfigAcsUrl) string {
+	if field == nil {
+		return ""
+	}
+	return field.Value
+}
+
+func generateApplicationPayload(d *schema.ResourceData) jcapiv1.Application {
 	return jcapiv1.Application{
 		// TODO clearify if previous Active: true is translated to Beta: false
 		// Active:		  true,
@@ -236,56 +279,57 @@ func generateApplicationPayload(d *schema.ResourceData) jcapiv1.Application {
 		Name:         d.Get("name").(string),
 		DisplayLabel: d.Get("display_label").(string),
 		SsoUrl:       d.Get("sso_url").(string),
+		LearnMore:    d.Get("learn_more").(string),
 		Config: &jcapiv1.ApplicationConfig{
 			AcsUrl: &jcapiv1.ApplicationConfigAcsUrl{
-				Type_:"text",
-				Label:"ACS Url:",
-				Value:d.Get("acs_url").(string),
-				Required:true,
-				Visible:true,
-				ReadOnly:false,
-				Position:4,
+				Type_:    "text",
+				Label:    "ACS Url:",
+				Value:    d.Get("acs_url").(string),
+				Required: true,
+				Visible:  true,
+				ReadOnly: false,
+				Position: 4,
 			},
 			ConstantAttributes: &jcapiv1.ApplicationConfigConstantAttributes{
-				Value: constants,
+				Value: expandConstantAttributes(d.Get("constant_attributes").([]interface{})),
 			},
 			DatabaseAttributes: &jcapiv1.ApplicationConfigDatabaseAttributes{},
-			IdpCertificate:&jcapiv1.ApplicationConfigAcsUrl{
-				Type_:"file",
-				Label:"IdP Certificate:",
-				Value:d.Get("idp_certificate").(string),
-				Required:true,
-				Visible:true,
-				ReadOnly:false,
-				Position:2,
+			IdpCertificate: &jcapiv1.ApplicationConfigAcsUrl{
+				Type_:    "file",
+				Label:    "IdP Certificate:",
+				Value:    d.Get("idp_certificate").(string),
+				Required: true,
+				Visible:  true,
+				ReadOnly: false,
+				Position: 2,
 			},
-			IdpEntityId:&jcapiv1.ApplicationConfigAcsUrl{
-				Type_:"text",
-				Label:"IdP Entity ID:",
-				Value:d.Get("idp_entity_id").(string),
-				Required:true,
-				Visible:true,
-				ReadOnly:false,
-				Position:0,
+			IdpEntityId: &jcapiv1.ApplicationConfigAcsUrl{
+				Type_:    "text",
+				Label:    "IdP Entity ID:",
+				Value:    d.Get("idp_entity_id").(string),
+				Required: true,
+				Visible:  true,
+				ReadOnly: false,
+				Position: 0,
 			},
-			IdpPrivateKey:&jcapiv1.ApplicationConfigAcsUrl{
-				Type_:"file",
-				Label:"IdP Private Key:",
-				Value:d.Get("idp_private_key").(string),
-				Required:true,
-				Visible:true,
-				ReadOnly:false,
-				Position:1,
+			IdpPrivateKey: &jcapiv1.ApplicationConfigAcsUrl{
+				Type_:    "file",
+				Label:    "IdP Private Key:",
+				Value:    d.Get("idp_private_key").(string),
+				Required: true,
+				Visible:  true,
+				ReadOnly: false,
+				Position: 1,
 			},
-			SpEntityId:&jcapiv1.ApplicationConfigAcsUrl{
-				Type_:"text",
-				Label:"SP Entity ID:",
-				Value:d.Get("sp_entity_id").(string),
-				Required:true,
-				Visible:true,
-				ReadOnly:false,
-				Position:4,
+			SpEntityId: &jcapiv1.ApplicationConfigAcsUrl{
+				Type_:    "text",
+				Label:    "SP Entity ID:",
+				Value:    d.Get("sp_entity_id").(string),
+				Required: true,
+				Visible:  true,
+				ReadOnly: false,
+				Position: 4,
 			},
 		},
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,195 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceApplicationGroupMembershipSync() *schema.Resource {
+	return &schema.Resource{
+		Description: "Keeps the user groups bound to a JumpCloud application in sync, re-binding groups that were removed outside of Terraform.",
+		Create:      resourceApplicationGroupMembershipSyncUpdate,
+		Read:        resourceApplicationGroupMembershipSyncRead,
+		Update:      resourceApplicationGroupMembershipSyncUpdate,
+		Delete:      resourceApplicationGroupMembershipSyncDelete,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_ids": {
+				Description: "The IDs of the user groups bound to the application.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sync_mode": {
+				Description:  "`additive` only binds the listed groups, `strict` also unbinds any other group. Defaults to `additive`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "additive",
+				ValidateFunc: validation.StringInSlice([]string{"additive", "strict"}, false),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: applicationGroupMembershipSyncImporter,
+		},
+	}
+}
+
+func applicationGroupMembershipSyncImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("application_id", d.Id())
+	_ = d.Set("sync_mode", "strict")
+	return []*schema.ResourceData{d}, nil
+}
+
+func getApplicationUserGroupIDs(ctx context.Context, config *Meta, applicationID string) ([]string, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	var groupIDs []string
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		optionals := map[string]interface{}{
+			"limit": int32(pageSize),
+			"skip":  skip,
+		}
+
+		var graphconnect []jcapiv2.GraphConnection
+		var err error
+		graphconnect, res, err = client.ApplicationsApi.GraphApplicationAssociationsList(
+			ctx, applicationID, []string{"user_group"}, "", "", optionals)
+		for _, v := range graphconnect {
+			groupIDs = append(groupIDs, v.To.Id)
+		}
+		return res, len(graphconnect), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting user groups of application %s: %w", applicationID, apiError(res, err))
+	}
+	return groupIDs, nil
+}
+
+func manageApplicationUserGroup(ctx context.Context, client *jcapiv2.APIClient, applicationID, groupID, action string) error {
+	groupType := jcapiv2.GraphType("user_group")
+	req := map[string]interface{}{
+		"body": jcapiv2.GraphManagementReq{
+			Op:    action,
+			Type_: &groupType,
+			Id:    groupID,
+		},
+	}
+
+	res, err := client.ApplicationsApi.GraphApplicationAssociationsPost(
+		ctx, applicationID, "", "", req)
+	if err != nil {
+		return fmt.Errorf("error trying to %s group %s on application %s: %w",
+			action, groupID, applicationID, apiError(res, err))
+	}
+	return nil
+}
+
+func resourceApplicationGroupMembershipSyncRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, d.Get("application_id").(string))
+	if err != nil {
+		return err
+	}
+
+	groupIDs := current
+	if d.Get("sync_mode").(string) == "additive" {
+		// groups bound outside of Terraform are none of our business
+		groupIDs = []string{}
+		desired := d.Get("group_ids").(*schema.Set)
+		for _, id := range current {
+			if desired.Contains(id) {
+				groupIDs = append(groupIDs, id)
+			}
+		}
+	}
+
+	if err := d.Set("group_ids", groupIDs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resourceApplicationGroupMembershipSyncUpdate is also used on create,
+// every apply binds the missing groups
+func resourceApplicationGroupMembershipSyncUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, applicationID)
+	if err != nil {
+		return err
+	}
+
+	desired := d.Get("group_ids").(*schema.Set)
+	for _, v := range desired.List() {
+		if !stringInSlice(v.(string), current) {
+			if err := manageApplicationUserGroup(ctx, client, applicationID, v.(string), "add"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// groups dropped from the configuration are unbound in both modes
+	if d.HasChange("group_ids") {
+		old, _ := d.GetChange("group_ids")
+		for _, v := range old.(*schema.Set).Difference(desired).List() {
+			if stringInSlice(v.(string), current) {
+				if err := manageApplicationUserGroup(ctx, client, applicationID, v.(string), "remove"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if d.Get("sync_mode").(string) == "strict" {
+		for _, id := range current {
+			if !desired.Contains(id) {
+				if err := manageApplicationUserGroup(ctx, client, applicationID, id, "remove"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	d.SetId(applicationID)
+	return resourceApplicationGroupMembershipSyncRead(d, m)
+}
+
+func resourceApplicationGroupMembershipSyncDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, applicationID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range d.Get("group_ids").(*schema.Set).List() {
+		if stringInSlice(v.(string), current) {
+			if err := manageApplicationUserGroup(ctx, client, applicationID, v.(string), "remove"); err != nil {
+				return err
+			}
+		}
+	}
+	d.SetId("")
+	return nil
+}
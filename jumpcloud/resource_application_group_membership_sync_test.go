@@ -0,0 +1,53 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccApplicationGroupMembershipSync(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	applicationID := os.Getenv("JUMPCLOUD_SAML_APPLICATION_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SAML_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationGroupMembershipSync(rName, applicationID, "jumpcloud_user_group.test_group[0].id"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_application_group_membership_sync.test_sync",
+					"group_ids.#", "1"),
+			},
+			{
+				Config: testAccApplicationGroupMembershipSync(rName, applicationID,
+					"jumpcloud_user_group.test_group[0].id, jumpcloud_user_group.test_group[1].id"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_application_group_membership_sync.test_sync",
+					"group_ids.#", "2"),
+			},
+		},
+	})
+}
+
+func testAccApplicationGroupMembershipSync(name, applicationID, groupIDs string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			count = 2
+			name  = "%s${count.index}"
+		}
+
+		resource "jumpcloud_application_group_membership_sync" "test_sync" {
+			application_id = "%s"
+			group_ids      = [%s]
+		}`, name, applicationID, groupIDs,
+	)
+}
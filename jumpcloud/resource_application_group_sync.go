@@ -0,0 +1,167 @@
+package jumpcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApplicationGroupSync() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the complete set of user groups bound to a JumpCloud application. Groups that aren't listed are unbound.",
+		Create:      resourceApplicationGroupSyncUpdate,
+		Read:        resourceApplicationGroupSyncRead,
+		Update:      resourceApplicationGroupSyncUpdate,
+		Delete:      resourceApplicationGroupSyncDelete,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_ids": {
+				Description: "The IDs of the only user groups bound to the application.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: applicationGroupSyncImporter,
+		},
+	}
+}
+
+func applicationGroupSyncImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("application_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+// diffGroupIDs returns the groups to bind and to unbind to get from
+// current to desired, sorted for a predictable order of the API calls
+func diffGroupIDs(current, desired []string) (add, remove []string) {
+	for _, id := range desired {
+		if !stringInSlice(id, current) {
+			add = append(add, id)
+		}
+	}
+	for _, id := range current {
+		if !stringInSlice(id, desired) {
+			remove = append(remove, id)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return
+}
+
+// syncApplicationGroups applies the computed changes; as the API has no
+// transactions, the changes already made are reverted if one fails. The
+// error tells whether the revert left the bound groups as they were.
+func syncApplicationGroups(ctx context.Context, client *jcapiv2.APIClient, applicationID string, add, remove []string) error {
+	type change struct{ groupID, op, undo string }
+
+	var changes []change
+	for _, id := range add {
+		changes = append(changes, change{id, "add", "remove"})
+	}
+	for _, id := range remove {
+		changes = append(changes, change{id, "remove", "add"})
+	}
+
+	for i, c := range changes {
+		err := manageApplicationUserGroup(ctx, client, applicationID, c.groupID, c.op)
+		if err == nil {
+			continue
+		}
+
+		var rollbackErrs []error
+		for j := i - 1; j >= 0; j-- {
+			if rollbackErr := manageApplicationUserGroup(ctx, client, applicationID, changes[j].groupID, changes[j].undo); rollbackErr != nil {
+				rollbackErrs = append(rollbackErrs, rollbackErr)
+			}
+		}
+		if len(rollbackErrs) > 0 {
+			return fmt.Errorf("%w; rolling back failed, the bound groups are partially changed: %w",
+				err, errors.Join(rollbackErrs...))
+		}
+		return fmt.Errorf("%w; no changes were kept", err)
+	}
+	return nil
+}
+
+func resourceApplicationGroupSyncRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, d.Get("application_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("group_ids", current); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resourceApplicationGroupSyncUpdate is also used on create, the bound
+// groups are read, diffed against group_ids and only then changed
+func resourceApplicationGroupSyncUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, applicationID)
+	if err != nil {
+		return err
+	}
+
+	var desired []string
+	for _, v := range d.Get("group_ids").(*schema.Set).List() {
+		desired = append(desired, v.(string))
+	}
+
+	add, remove := diffGroupIDs(current, desired)
+	if err := syncApplicationGroups(ctx, client, applicationID, add, remove); err != nil {
+		return fmt.Errorf("error syncing the groups of application %s: %w", applicationID, err)
+	}
+
+	d.SetId(applicationID)
+	return resourceApplicationGroupSyncRead(d, m)
+}
+
+func resourceApplicationGroupSyncDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, applicationID)
+	if err != nil {
+		return err
+	}
+
+	// only the groups in state are unbound, groups bound since the last
+	// refresh weren't bound by this resource
+	var remove []string
+	for _, v := range d.Get("group_ids").(*schema.Set).List() {
+		if stringInSlice(v.(string), current) {
+			remove = append(remove, v.(string))
+		}
+	}
+	sort.Strings(remove)
+	if err := syncApplicationGroups(ctx, client, applicationID, nil, remove); err != nil {
+		return fmt.Errorf("error unbinding the groups of application %s: %s", applicationID, err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,141 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccApplicationGroupSync(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	applicationID := os.Getenv("JUMPCLOUD_SAML_APPLICATION_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SAML_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationGroupSync(rName, applicationID,
+					"jumpcloud_user_group.test_group[0].id, jumpcloud_user_group.test_group[1].id"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_application_group_sync.test_sync",
+					"group_ids.#", "2"),
+			},
+			{
+				Config: testAccApplicationGroupSync(rName, applicationID, "jumpcloud_user_group.test_group[1].id"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_application_group_sync.test_sync",
+					"group_ids.#", "1"),
+			},
+		},
+	})
+}
+
+func testAccApplicationGroupSync(name, applicationID, groupIDs string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			count = 2
+			name  = "%s${count.index}"
+		}
+
+		resource "jumpcloud_application_group_sync" "test_sync" {
+			application_id = "%s"
+			group_ids      = [%s]
+		}`, name, applicationID, groupIDs,
+	)
+}
+
+func TestDiffGroupIDs(t *testing.T) {
+	add, remove := diffGroupIDs([]string{"c", "a", "b"}, []string{"d", "b", "e"})
+	assert.Equal(t, []string{"d", "e"}, add)
+	assert.Equal(t, []string{"a", "c"}, remove)
+
+	add, remove = diffGroupIDs([]string{"a"}, []string{"a"})
+	assert.Empty(t, add)
+	assert.Empty(t, remove)
+}
+
+func TestSyncApplicationGroupsRollback(t *testing.T) {
+	var ops []string
+	failing := ""
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var req jcapiv2.GraphManagementReq
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		ops = append(ops, req.Op+" "+req.Id)
+
+		if req.Op == "remove" && (req.Id == "old" || req.Id == failing) {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	err := syncApplicationGroups(context.TODO(), jcapiv2.NewAPIClient(config.Configuration), "app", []string{"new1", "new2"}, []string{"old"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no changes were kept")
+	}
+	assert.Equal(t, []string{"add new1", "add new2", "remove old", "remove new2", "remove new1"}, ops)
+
+	// a failed rollback leaves the groups partially changed
+	ops = nil
+	failing = "new1"
+	err = syncApplicationGroups(context.TODO(), jcapiv2.NewAPIClient(config.Configuration), "app", []string{"new1", "new2"}, []string{"old"})
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "no changes were kept")
+		assert.Contains(t, err.Error(), "rolling back failed, the bound groups are partially changed")
+		assert.Contains(t, err.Error(), "remove group new1")
+	}
+	assert.Equal(t, []string{"add new1", "add new2", "remove old", "remove new2", "remove new1"}, ops)
+}
+
+func TestApplicationGroupSyncDelete(t *testing.T) {
+	bound := map[string]bool{"a": true, "b": true, "later": true}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/applications/app/associations", r.URL.Path)
+		if r.Method == http.MethodPost {
+			var req jcapiv2.GraphManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "remove", req.Op)
+			delete(bound, req.Id)
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		connections := []jcapiv2.GraphConnection{}
+		for id := range bound {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceApplicationGroupSync()
+
+	// the group bound since the last refresh is left alone
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"application_id": "app",
+		"group_ids":      []interface{}{"a", "b"},
+	})
+	d.SetId("app")
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, map[string]bool{"later": true}, bound)
+}
@@ -0,0 +1,197 @@
+package jumpcloud
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApplicationSPCertificate() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource for managing the SP certificate of a JumpCloud SAML application.",
+		Create:        resourceApplicationSPCertificateCreate,
+		Read:          resourceApplicationSPCertificateRead,
+		Update:        resourceApplicationSPCertificateUpdate,
+		Delete:        resourceApplicationSPCertificateDelete,
+		CustomizeDiff: applicationSPCertificateCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the SAML application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"certificate_pem": {
+				Description: "The PEM encoded X.509 SP certificate.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, err := parseCertificatePEM(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%q: %s", k, err))
+					}
+					return
+				},
+			},
+			"fingerprint": {
+				Description: "The SHA-256 fingerprint of the certificate.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"expiry_date": {
+				Description: "The RFC 3339 timestamp the certificate expires at.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"subject": {
+				Description: "The distinguished name of the certificate subject.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: applicationSPCertificateImporter,
+		},
+	}
+}
+
+func applicationSPCertificateImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("application_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+type certificateInfo struct {
+	Fingerprint string
+	ExpiryDate  string
+	Subject     string
+}
+
+// parseCertificatePEM extracts the details of the first certificate
+// found in a PEM string
+func parseCertificatePEM(certificatePEM string) (*certificateInfo, error) {
+	block, _ := pem.Decode([]byte(certificatePEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %s", err)
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	hexBytes := make([]string, len(sum))
+	for i, b := range sum {
+		hexBytes[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return &certificateInfo{
+		Fingerprint: strings.Join(hexBytes, ":"),
+		ExpiryDate:  cert.NotAfter.UTC().Format(time.RFC3339),
+		Subject:     cert.Subject.String(),
+	}, nil
+}
+
+func setCertificateInfo(set func(string, interface{}) error, certificatePEM string) error {
+	info, err := parseCertificatePEM(certificatePEM)
+	if err != nil {
+		return err
+	}
+
+	if err := set("fingerprint", info.Fingerprint); err != nil {
+		return err
+	}
+	if err := set("expiry_date", info.ExpiryDate); err != nil {
+		return err
+	}
+	if err := set("subject", info.Subject); err != nil {
+		return err
+	}
+	return nil
+}
+
+// applicationSPCertificateCustomizeDiff populates the computed certificate
+// details at plan time
+func applicationSPCertificateCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("certificate_pem") {
+		return nil
+	}
+
+	certificatePEM := d.Get("certificate_pem").(string)
+	if certificatePEM == "" {
+		// not known yet
+		return nil
+	}
+	return setCertificateInfo(d.SetNew, certificatePEM)
+}
+
+func applicationSPCertificatePath(d *schema.ResourceData) string {
+	return "/applications/" + d.Get("application_id").(string) + "/spcertificate"
+}
+
+func putApplicationSPCertificate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	// the new certificate replaces the old one in place, existing
+	// sessions are not affected
+	payload := SPCertificate{Certificate: d.Get("certificate_pem").(string)}
+	_, err := jumpCloudRequest(config, http.MethodPut, applicationSPCertificatePath(d), payload, nil)
+	if err != nil {
+		return fmt.Errorf("error uploading SP certificate of application %s: %s", d.Get("application_id"), err)
+	}
+	return nil
+}
+
+func resourceApplicationSPCertificateCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putApplicationSPCertificate(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("application_id").(string))
+	return resourceApplicationSPCertificateRead(d, m)
+}
+
+func resourceApplicationSPCertificateRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var certificate SPCertificate
+	ok, err := jumpCloudRequest(config, http.MethodGet, applicationSPCertificatePath(d), nil, &certificate)
+	if err != nil {
+		return err
+	}
+
+	if !ok || certificate.Certificate == "" {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("certificate_pem", certificate.Certificate); err != nil {
+		return err
+	}
+	return setCertificateInfo(d.Set, certificate.Certificate)
+}
+
+func resourceApplicationSPCertificateUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putApplicationSPCertificate(d, m); err != nil {
+		return err
+	}
+	return resourceApplicationSPCertificateRead(d, m)
+}
+
+func resourceApplicationSPCertificateDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete, applicationSPCertificatePath(d), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error removing SP certificate of application %s: %s", d.Get("application_id"), err)
+	}
+	d.SetId("")
+	return nil
+}
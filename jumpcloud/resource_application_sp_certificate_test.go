@@ -0,0 +1,88 @@
+package jumpcloud
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccApplicationSPCertificate(t *testing.T) {
+	applicationID := os.Getenv("JUMPCLOUD_SAML_APPLICATION_ID")
+	first := testCertificatePEM(t, "first.example.com", time.Now().Add(24*time.Hour))
+	second := testCertificatePEM(t, "second.example.com", time.Now().Add(48*time.Hour))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SAML_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationSPCertificate(applicationID, first),
+				Check: resource.TestCheckResourceAttr("jumpcloud_application_sp_certificate.test_certificate",
+					"subject", "CN=first.example.com"),
+			},
+			{
+				Config: testAccApplicationSPCertificate(applicationID, second),
+				Check: resource.TestCheckResourceAttr("jumpcloud_application_sp_certificate.test_certificate",
+					"subject", "CN=second.example.com"),
+			},
+		},
+	})
+}
+
+func testAccApplicationSPCertificate(applicationID, certificatePEM string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_application_sp_certificate" "test_certificate" {
+			application_id  = "%s"
+			certificate_pem = <<EOT
+%sEOT
+		}`, applicationID, certificatePEM,
+	)
+}
+
+func testCertificatePEM(t *testing.T, commonName string, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestParseCertificatePEM(t *testing.T) {
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	info, err := parseCertificatePEM(testCertificatePEM(t, "sp.example.com", notAfter))
+	assert.NoError(t, err)
+	assert.Equal(t, "CN=sp.example.com", info.Subject)
+	assert.Equal(t, "2030-01-02T03:04:05Z", info.ExpiryDate)
+	assert.Len(t, info.Fingerprint, 32*3-1)
+
+	_, err = parseCertificatePEM("not a certificate")
+	assert.Error(t, err)
+}
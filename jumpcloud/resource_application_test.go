@@ -1,49 +1,125 @@
 package jumpcloud
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"testing"
+	"time"
 
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/stretchr/testify/assert"
 )
 
-func Test_resourceApplication(t *testing.T) {
+func TestAccApplication(t *testing.T) {
 	randSuffix := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 	fullResourceName := "jumpcloud_application.example_app"
+	certificate, privateKey := testIdPKeyPairPEM(t)
 
-	resource.ParallelTest(t, resource.TestCase{
-		PreCheck: func() {
-		},
-		ProviderFactories: testAccProviders,
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
 		Steps: []resource.TestStep{
 			// Create step
 			{
-				Config: testApplicationConfig(randSuffix, "test_aws_account"),
+				Config: testApplicationConfig(randSuffix, "https://example.com/saml/acs", certificate, privateKey),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(fullResourceName, "display_label", "test_aws_account"),
+					resource.TestCheckResourceAttr(fullResourceName, "display_label", "test_saml_"+randSuffix),
+					resource.TestCheckResourceAttr(fullResourceName, "acs_url", "https://example.com/saml/acs"),
+					resource.TestCheckResourceAttr(fullResourceName, "constant_attributes.0.name", "department"),
+					resource.TestCheckResourceAttrSet(fullResourceName, "metadata_xml"),
 				),
 			},
-			userImportStep(fullResourceName),
+			applicationImportStep(fullResourceName),
 			// Update Step
 			{
-				Config: testApplicationConfig(randSuffix, "test_aws_account_updated"),
+				Config: testApplicationConfig(randSuffix, "https://example.com/saml/acs/v2", certificate, privateKey),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(fullResourceName, "display_label", "test_aws_account_updated"),
+					resource.TestCheckResourceAttr(fullResourceName, "acs_url", "https://example.com/saml/acs/v2"),
 				),
 			},
-			userImportStep(fullResourceName),
+			applicationImportStep(fullResourceName),
 		},
 	})
 }
 
-func testApplicationConfig(randSuffix string, displayLabel string) string {
+func applicationImportStep(name string) resource.TestStep {
+	return resource.TestStep{
+		ResourceName:      name,
+		ImportState:       true,
+		ImportStateVerify: true,
+		// kept as configured
+		ImportStateVerifyIgnore: []string{"idp_certificate", "idp_private_key"},
+	}
+}
+
+func testApplicationConfig(randSuffix, acsURL, certificate, privateKey string) string {
 	return fmt.Sprintf(`
 resource "jumpcloud_application" "example_app" {
-	display_label        = "%s"
-	sso_url              = "https://sso.jumpcloud.com/saml2/example-application_%s"
-	saml_role_attribute  = "arn:aws:iam::AWS_ACCOUNT_ID:role/MY_ROLE,arn:aws:iam::AWS_ACCOUNT_ID:saml-provider/MY_SAML_PROVIDER"
-	aws_session_duration = 432000
+	name            = "saml"
+	display_label   = "test_saml_%[1]s"
+	sso_url         = "https://sso.jumpcloud.com/saml2/example-application_%[1]s"
+	idp_entity_id   = "https://sso.jumpcloud.com/saml2/example-application_%[1]s"
+	sp_entity_id    = "https://example.com/saml/%[1]s"
+	acs_url         = "%[2]s"
+	idp_certificate = <<EOT
+%[3]sEOT
+	idp_private_key = <<EOT
+%[4]sEOT
+
+	constant_attributes {
+		name  = "department"
+		value = "engineering"
+	}
+}
+`, randSuffix, acsURL, certificate, privateKey)
 }
-`, displayLabel, randSuffix)
+
+// testIdPKeyPairPEM generates a self-signed certificate and its private key
+// for the IdP of a SAML application
+func testIdPKeyPairPEM(t *testing.T) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "JumpCloud"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+func TestConstantAttributes(t *testing.T) {
+	input := []interface{}{map[string]interface{}{
+		"name":      "department",
+		"value":     "engineering",
+		"read_only": true,
+		"required":  false,
+		"visible":   true,
+	}}
+
+	constants := expandConstantAttributes(input)
+	assert.Equal(t, []jcapiv1.ApplicationConfigConstantAttributesValue{{
+		Name: "department", Value: "engineering", ReadOnly: true, Visible: true,
+	}}, constants)
+
+	config := &jcapiv1.ApplicationConfig{
+		ConstantAttributes: &jcapiv1.ApplicationConfigConstantAttributes{Value: constants},
+	}
+	assert.Equal(t, input, flattenConstantAttributes(config))
+	assert.Empty(t, flattenConstantAttributes(nil))
 }
@@ -0,0 +1,104 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"strings"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceApplicationUserGroupAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Binds a JumpCloud user group to an SSO application, so the members of the group get access to it.",
+		Create:      resourceApplicationUserGroupAssociationCreate,
+		Read:        resourceApplicationUserGroupAssociationRead,
+		Delete:      resourceApplicationUserGroupAssociationDelete,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_group_id": {
+				Description: "The ID of the user group.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: applicationUserGroupAssociationImporter,
+		},
+	}
+}
+
+// parseApplicationUserGroupID splits the applicationID:userGroupID ID of an association
+func parseApplicationUserGroupID(id string) (applicationID, groupID string, err error) {
+	s := strings.Split(id, ":")
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return "", "", fmt.Errorf("invalid ID %q, expected 'application_id:user_group_id'", id)
+	}
+	return s[0], s[1], nil
+}
+
+func applicationUserGroupAssociationImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	applicationID, groupID, err := parseApplicationUserGroupID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	_ = d.Set("application_id", applicationID)
+	_ = d.Set("user_group_id", groupID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceApplicationUserGroupAssociationCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+	groupID := d.Get("user_group_id").(string)
+
+	if err := manageApplicationUserGroup(ctx, client, applicationID, groupID, "add"); err != nil {
+		return err
+	}
+	d.SetId(applicationID + ":" + groupID)
+	return resourceApplicationUserGroupAssociationRead(d, m)
+}
+
+func resourceApplicationUserGroupAssociationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, d.Get("application_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// removed outside of Terraform, the next apply binds the group again
+	if !stringInSlice(d.Get("user_group_id").(string), current) {
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceApplicationUserGroupAssociationDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+	groupID := d.Get("user_group_id").(string)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, applicationID)
+	if err != nil {
+		return err
+	}
+	if stringInSlice(groupID, current) {
+		if err := manageApplicationUserGroup(ctx, client, applicationID, groupID, "remove"); err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
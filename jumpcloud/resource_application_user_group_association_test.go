@@ -0,0 +1,93 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccApplicationUserGroupAssociation(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	applicationID := os.Getenv("JUMPCLOUD_SAML_APPLICATION_ID")
+	fullResourceName := "jumpcloud_application_user_group_association.test_association"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SAML_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationUserGroupAssociation(rName, applicationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "application_id", applicationID),
+					resource.TestCheckResourceAttrPair(fullResourceName, "user_group_id",
+						"jumpcloud_user_group.test_group", "id"),
+				),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{ // unbind the group via the api, then check the plan binds it again
+				PreConfig:          unbindApplicationUserGroupViaAPI(t, applicationID, rName),
+				Config:             testAccApplicationUserGroupAssociation(rName, applicationID),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccApplicationUserGroupAssociation(name, applicationID string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_application_user_group_association" "test_association" {
+			application_id = "%s"
+			user_group_id  = jumpcloud_user_group.test_group.id
+		}`, name, applicationID,
+	)
+}
+
+func unbindApplicationUserGroupViaAPI(t *testing.T, applicationID, groupName string) func() {
+	return func() {
+		config := newMeta(jcapiv2.NewConfiguration())
+		config.AddDefaultHeader("x-api-key", os.Getenv("JUMPCLOUD_API_KEY"))
+		client := jcapiv2.NewAPIClient(config.Configuration)
+
+		groups, _, err := client.UserGroupsApi.GroupsUserList(context.Background(), "", "", map[string]interface{}{
+			"filter": []string{fmt.Sprintf(`name:eq:%s`, groupName)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := manageApplicationUserGroup(context.TODO(), client, applicationID, groups[0].Id, "remove"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseApplicationUserGroupID(t *testing.T) {
+	applicationID, groupID, err := parseApplicationUserGroupID("app:group")
+	assert.NoError(t, err)
+	assert.Equal(t, "app", applicationID)
+	assert.Equal(t, "group", groupID)
+
+	for _, id := range []string{"app", "app/group", "app:", ":group", "app:group:more"} {
+		_, _, err := parseApplicationUserGroupID(id)
+		assert.Error(t, err, id)
+	}
+}
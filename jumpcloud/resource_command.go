@@ -0,0 +1,187 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceCommand() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a JumpCloud command, a script run on systems.",
+		Create:      resourceCommandCreate,
+		Read:        resourceCommandRead,
+		Update:      resourceCommandUpdate,
+		Delete:      resourceCommandDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the command.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"command": {
+				Description: "The script to run.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"command_type": {
+				Description: "The OS the command runs on. Possible values: `linux`, `windows`, `mac`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"linux",
+					"windows",
+					"mac",
+				}, false),
+			},
+			"launch_type": {
+				Description: "How the command is launched. Possible values: `manual`, `trigger`, `event`, `scheduled`. " +
+					"Leave it unset if the command is scheduled by `jumpcloud_system_command_schedule`.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"manual",
+					"trigger",
+					"event",
+					"scheduled",
+				}, false),
+			},
+			"user": {
+				Description: "The ID of the system user the command runs as, `000000000000000000000000` for root. " +
+					"Required by JumpCloud for `linux` and `mac` commands.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"timeout": {
+				Description:  "The number of seconds the command may run for.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      120,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"files": {
+				Description: "The IDs of the files uploaded along with the command.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// applyCommand sets the fields of command managed by the resource d
+func applyCommand(d *schema.ResourceData) func(*jcapiv1.Command) {
+	return func(command *jcapiv1.Command) {
+		command.Name = d.Get("name").(string)
+		command.Command = d.Get("command").(string)
+		command.CommandType = d.Get("command_type").(string)
+		command.User = d.Get("user").(string)
+		command.Timeout = strconv.Itoa(d.Get("timeout").(int))
+		if launchType, ok := d.GetOk("launch_type"); ok {
+			command.LaunchType = launchType.(string)
+		}
+
+		command.Files = []string{}
+		for _, v := range d.Get("files").([]interface{}) {
+			command.Files = append(command.Files, v.(string))
+		}
+	}
+}
+
+func resourceCommandCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var command jcapiv1.Command
+	applyCommand(d)(&command)
+
+	// jcapiv1.Command lacks the ID of the created command
+	var created V1Object
+	if _, err := jumpCloudV1Request(config, http.MethodPost, "/commands", command, &created); err != nil {
+		return fmt.Errorf("error creating command %s: %s", command.Name, err)
+	}
+
+	d.SetId(created.ID)
+	return resourceCommandRead(d, m)
+}
+
+func resourceCommandRead(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	command, _, err := client.CommandsApi.CommandsGet(ctx,
+		d.Id(), "", headerAccept, nil)
+	if err != nil {
+		if err.Error() == "EOF" {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if err := d.Set("name", command.Name); err != nil {
+		return err
+	}
+	if err := d.Set("command", command.Command); err != nil {
+		return err
+	}
+	if err := d.Set("command_type", command.CommandType); err != nil {
+		return err
+	}
+	if err := d.Set("launch_type", command.LaunchType); err != nil {
+		return err
+	}
+	if err := d.Set("user", command.User); err != nil {
+		return err
+	}
+	if command.Timeout != "" {
+		timeout, err := strconv.Atoi(command.Timeout)
+		if err != nil {
+			return fmt.Errorf("error parsing timeout %q of command %s: %s", command.Timeout, d.Id(), err)
+		}
+		if err := d.Set("timeout", timeout); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("files", command.Files); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceCommandUpdate(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	// the schedule may be managed by jumpcloud_system_command_schedule
+	if err := updateCommand(ctx, client, d.Id(), applyCommand(d)); err != nil {
+		return err
+	}
+	return resourceCommandRead(d, m)
+}
+
+func resourceCommandDelete(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	res, err := client.CommandsApi.CommandsDelete(ctx,
+		d.Id(), "", headerAccept, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting command %s: %w", d.Id(), apiError(res, err))
+	}
+	d.SetId("")
+	return nil
+}
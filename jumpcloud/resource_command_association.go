@@ -0,0 +1,51 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceCommandAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Targets a JumpCloud command at systems and system groups. Systems and system groups " +
+			"the command is targeted at outside of Terraform are left alone.",
+		Create: commandAssociation.create,
+		Read:   commandAssociation.read,
+		Update: commandAssociation.update,
+		Delete: commandAssociation.delete,
+		Schema: map[string]*schema.Schema{
+			"command_id": {
+				Description: "The ID of the command.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"system_ids": {
+				Description: "The IDs of the systems the command runs on.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"system_group_ids": {
+				Description: "The IDs of the system groups the command runs on.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: commandAssociation.importer,
+		},
+	}
+}
+
+var commandAssociation = &graphAssociation{
+	path:            "/commands/%s/associations",
+	sourceAttribute: "command_id",
+	sourceName:      "command",
+	targets: map[string]string{
+		"system_ids":       "system",
+		"system_group_ids": "system_group",
+	},
+}
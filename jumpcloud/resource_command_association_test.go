@@ -0,0 +1,130 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccCommandAssociation(t *testing.T) {
+	systemID := os.Getenv("JUMPCLOUD_SYSTEM_ID")
+	if systemID == "" {
+		t.Skip("JUMPCLOUD_SYSTEM_ID must be set to run the command association acceptance test")
+	}
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_command_association.test_association"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommandAssociation(rName, systemID, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "system_ids.#", "1"),
+					resource.TestCheckResourceAttr(fullResourceName, "system_group_ids.#", "0"),
+				),
+			},
+			{
+				Config: testAccCommandAssociation(rName, systemID, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "system_ids.#", "1"),
+					resource.TestCheckResourceAttr(fullResourceName, "system_group_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCommandAssociation(name, systemID string, withGroup bool) string {
+	groupIDs := "[]"
+	if withGroup {
+		groupIDs = "[jumpcloud_system_group.test_group.id]"
+	}
+	return fmt.Sprintf(`
+		resource "jumpcloud_command" "test_command" {
+			name         = "%s"
+			command      = "uptime"
+			command_type = "linux"
+		}
+
+		resource "jumpcloud_system_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_command_association" "test_association" {
+			command_id       = jumpcloud_command.test_command.id
+			system_ids       = ["%s"]
+			system_group_ids = %s
+		}`, name, name, systemID, groupIDs,
+	)
+}
+
+func TestCommandAssociationReconcile(t *testing.T) {
+	bound := map[string]map[string]bool{
+		"system":       {"other": true},
+		"system_group": {},
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/commands/cmd/associations", r.URL.Path)
+		if r.Method == http.MethodPost {
+			var req jcapiv2.GraphManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Op == "add" {
+				bound[string(*req.Type_)][req.Id] = true
+			} else {
+				delete(bound[string(*req.Type_)], req.Id)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		connections := []jcapiv2.GraphConnection{}
+		for id := range bound[r.URL.Query().Get("targets")] {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceCommandAssociation()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"command_id":       "cmd",
+		"system_ids":       []interface{}{"a"},
+		"system_group_ids": []interface{}{"g"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, map[string]bool{"a": true, "other": true}, bound["system"])
+	assert.Equal(t, map[string]bool{"g": true}, bound["system_group"])
+
+	// the system bound outside of Terraform is left alone
+	assert.ElementsMatch(t, []interface{}{"a"}, d.Get("system_ids").(*schema.Set).List())
+
+	// all associations are imported
+	imported := r.TestResourceData()
+	imported.SetId("cmd")
+	states, err := r.Importer.State(imported, config)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{"a", "other"}, states[0].Get("system_ids").(*schema.Set).List())
+	assert.Equal(t, "cmd", states[0].Get("command_id"))
+
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, map[string]bool{"other": true}, bound["system"])
+	assert.Empty(t, bound["system_group"])
+}
@@ -0,0 +1,182 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceCommandResult() *schema.Resource {
+	return &schema.Resource{
+		Description: "Waits for the result of a JumpCloud command on a system and records it.",
+		Create:      resourceCommandResultCreate,
+		Read:        resourceCommandResultRead,
+		Delete:      resourceCommandResultDelete,
+		Schema: map[string]*schema.Schema{
+			"command_id": {
+				Description: "The ID of the command.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"system_id": {
+				Description: "The ID of the system the command runs on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"timeout": {
+				Description: "How long to wait for the result, as a duration string, e.g. `10m`. Defaults to `5m`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "5m",
+				ForceNew:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+					if _, err := time.ParseDuration(v.(string)); err != nil {
+						es = append(es, fmt.Errorf("%q must be a duration string: %s", k, err))
+					}
+					return
+				},
+			},
+			"exit_code": {
+				Description: "The exit code of the command.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"response_time_seconds": {
+				Description: "The number of seconds between the command being requested and its result being received.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"output": {
+				Description: "The output of the command.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"success": {
+				Description: "Whether the command exited with `0` and without error.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// findCommandResult returns the most recent result of a command on a
+// system requested at or after since, if any
+func findCommandResult(ctx context.Context, config *Meta, commandID, systemID string,
+	since time.Time) (*jcapiv1.Commandresult, error) {
+
+	client := jcapiv1.NewAPIClient(convertV2toV1Config(config))
+
+	var found *jcapiv1.Commandresult
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		var results jcapiv1.Commandresultslist
+		var err error
+		results, res, err = client.CommandResultsApi.CommandResultsList(ctx, "", headerAccept,
+			map[string]interface{}{
+				"filter": "systemId:$eq:" + systemID,
+				"sort":   "-requestTime",
+				"limit":  int32(pageSize),
+				"skip":   skip,
+			})
+		for i, result := range results.Results {
+			if requested, err := time.Parse(time.RFC3339, result.RequestTime); err == nil && requested.Before(since) {
+				// sorted by request time, the rest is older still
+				return res, 0, nil
+			}
+			if result.WorkflowId == commandID {
+				found = &results.Results[i]
+				// no need for the older pages
+				return res, 0, nil
+			}
+		}
+		return res, len(results.Results), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing command results: %w", apiError(res, err))
+	}
+	return found, nil
+}
+
+func resourceCommandResultCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	commandID := d.Get("command_id").(string)
+	systemID := d.Get("system_id").(string)
+	timeout, _ := time.ParseDuration(d.Get("timeout").(string))
+	// results of earlier runs of the command are stale, the request
+	// times only have a precision of seconds
+	created := time.Now().Truncate(time.Second)
+
+	var result *jcapiv1.Commandresult
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		var err error
+		result, err = findCommandResult(ctx, config, commandID, systemID, created)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if result == nil {
+			return resource.RetryableError(fmt.Errorf("no result yet for command %s on system %s", commandID, systemID))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(result.Id)
+	return setCommandResult(d, result)
+}
+
+func setCommandResult(d *schema.ResourceData, result *jcapiv1.Commandresult) error {
+	exitCode := 0
+	output := ""
+	errorMessage := ""
+	if result.Response != nil {
+		errorMessage = result.Response.Error_
+		if result.Response.Data != nil {
+			exitCode = int(result.Response.Data.ExitCode)
+			output = result.Response.Data.Output
+		}
+	}
+
+	responseTime := 0.0
+	requested, err1 := time.Parse(time.RFC3339, result.RequestTime)
+	responded, err2 := time.Parse(time.RFC3339, result.ResponseTime)
+	if err1 == nil && err2 == nil {
+		responseTime = responded.Sub(requested).Seconds()
+	}
+
+	if err := d.Set("exit_code", exitCode); err != nil {
+		return err
+	}
+	if err := d.Set("response_time_seconds", responseTime); err != nil {
+		return err
+	}
+	if err := d.Set("output", output); err != nil {
+		return err
+	}
+	if err := d.Set("success", exitCode == 0 && errorMessage == ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceCommandResultRead(d *schema.ResourceData, m interface{}) error {
+	// results are removed by JumpCloud after a while,
+	// the recorded outcome is kept in state
+	return nil
+}
+
+func resourceCommandResultDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,82 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccCommandResult(t *testing.T) {
+	commandID := os.Getenv("JUMPCLOUD_COMMAND_ID")
+	systemID := os.Getenv("JUMPCLOUD_SYSTEM_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if commandID == "" || systemID == "" {
+				t.Skip("JUMPCLOUD_COMMAND_ID and JUMPCLOUD_SYSTEM_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommandResult(commandID, systemID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("jumpcloud_command_result.test_result", "exit_code"),
+					resource.TestCheckResourceAttrSet("jumpcloud_command_result.test_result", "success"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCommandResult(commandID, systemID string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_command_result" "test_result" {
+			command_id = "%s"
+			system_id  = "%s"
+			timeout    = "2m"
+		}`, commandID, systemID,
+	)
+}
+
+func TestFindCommandResult(t *testing.T) {
+	results := []jcapiv1.Commandresult{
+		{Id: "other", WorkflowId: "other-command", RequestTime: "2021-06-01T12:05:00Z"},
+		{Id: "stale", WorkflowId: "command", RequestTime: "2021-06-01T11:00:00Z"},
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/commandresults", r.URL.Path)
+		assert.NoError(t, json.NewEncoder(rw).Encode(jcapiv1.Commandresultslist{Results: results}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	created := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// the result of an earlier run is ignored
+	result, err := findCommandResult(context.TODO(), config, "command", "system", created)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	results = append([]jcapiv1.Commandresult{
+		{Id: "new", WorkflowId: "command", RequestTime: "2021-06-01T12:10:00Z"},
+	}, results...)
+	result, err = findCommandResult(context.TODO(), config, "command", "system", created)
+	assert.NoError(t, err)
+	if assert.NotNil(t, result) {
+		assert.Equal(t, "new", result.Id)
+	}
+}
@@ -0,0 +1,78 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccCommand(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_command.test_command"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommand(rName, "uptime", 60),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "name", rName),
+					resource.TestCheckResourceAttr(fullResourceName, "command", "uptime"),
+					resource.TestCheckResourceAttr(fullResourceName, "command_type", "linux"),
+					resource.TestCheckResourceAttr(fullResourceName, "timeout", "60"),
+				),
+			},
+			{
+				Config: testAccCommand(rName, "uptime && df -h", 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "command", "uptime && df -h"),
+					resource.TestCheckResourceAttr(fullResourceName, "timeout", "300"),
+				),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCommand(name, command string, timeout int) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_command" "test_command" {
+			name         = "%s"
+			command      = "%s"
+			command_type = "linux"
+			user         = "000000000000000000000000"
+			timeout      = %d
+		}`, name, command, timeout,
+	)
+}
+
+func TestApplyCommand(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceCommand().Schema, map[string]interface{}{
+		"name":         "uptime",
+		"command":      "uptime",
+		"command_type": "linux",
+		"timeout":      30,
+		"files":        []interface{}{"file1"},
+	})
+
+	command := jcapiv1.Command{LaunchType: "scheduled", Schedule: "0 3 * * 1"}
+	applyCommand(d)(&command)
+
+	assert.Equal(t, "uptime", command.Name)
+	assert.Equal(t, "linux", command.CommandType)
+	assert.Equal(t, "30", command.Timeout)
+	assert.Equal(t, []string{"file1"}, command.Files)
+	// the schedule isn't touched without a launch_type
+	assert.Equal(t, "scheduled", command.LaunchType)
+	assert.Equal(t, "0 3 * * 1", command.Schedule)
+}
@@ -0,0 +1,217 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceConditionalAccessPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a conditional access policy, allowing, denying or " +
+			"requiring MFA for the logins of user groups depending on their network and device.",
+		Create: resourceConditionalAccessPolicyCreate,
+		Read:   resourceConditionalAccessPolicyRead,
+		Update: resourceConditionalAccessPolicyUpdate,
+		Delete: resourceConditionalAccessPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:  "The name of the policy.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"description": {
+				Description: "The description of the policy.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the policy is applied to logins.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"user_group_ids": {
+				Description: "The IDs of the user groups whose logins the policy applies to.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"ip_list_ids": {
+				Description: "The IDs of IP lists, the policy applies to logins from one of their addresses.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"outside_ip_list_ids": {
+				Description: "The IDs of IP lists, the policy applies to logins from none of their addresses, " +
+					"e.g. from outside the office networks.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"device_trust": {
+				Description: "Whether the policy only applies to logins from devices managed by JumpCloud " +
+					"(`trusted`) or only to logins from other devices (`untrusted`). Applies to both if empty.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"trusted", "untrusted"}, false),
+			},
+			"effect": {
+				Description: "What happens to the logins the policy applies to. " +
+					"Possible values: `allow`, `deny`, `require_mfa`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny", "require_mfa"}, false),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// expandConditionalAccessPolicy builds the API policy from d, every
+// configured condition has to match
+func expandConditionalAccessPolicy(d *schema.ResourceData) AuthnPolicy {
+	conditions := []AuthnPolicyCondition{}
+	if ids := interfacesToStrings(d.Get("ip_list_ids").(*schema.Set).List()); len(ids) > 0 {
+		conditions = append(conditions, AuthnPolicyCondition{IPAddressIn: ids})
+	}
+	if ids := interfacesToStrings(d.Get("outside_ip_list_ids").(*schema.Set).List()); len(ids) > 0 {
+		conditions = append(conditions, AuthnPolicyCondition{Not: &AuthnPolicyCondition{IPAddressIn: ids}})
+	}
+	if trust := d.Get("device_trust").(string); trust != "" {
+		managed := trust == "trusted"
+		conditions = append(conditions, AuthnPolicyCondition{DeviceManaged: &managed})
+	}
+
+	effect := AuthnPolicyEffect{Action: d.Get("effect").(string)}
+	if effect.Action == "require_mfa" {
+		effect = AuthnPolicyEffect{
+			Action:      "allow",
+			Obligations: &AuthnPolicyObligations{MFA: &AuthnPolicyMFA{Required: true}},
+		}
+	}
+
+	return AuthnPolicy{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Disabled:    !d.Get("enabled").(bool),
+		Targets: AuthnPolicyTargets{
+			UserGroups: AuthnPolicyInclusions{Inclusions: interfacesToStrings(d.Get("user_group_ids").(*schema.Set).List())},
+		},
+		Conditions: AuthnPolicyConditions{All: conditions},
+		Effect:     effect,
+	}
+}
+
+// flattenConditionalAccessPolicy sets the conditions and the effect of
+// policy on d. Conditions the resource can't express, e.g. ones added in
+// the console, make reading the policy fail instead of being dropped.
+func flattenConditionalAccessPolicy(d *schema.ResourceData, policy AuthnPolicy) error {
+	ipListIDs, outsideIPListIDs, deviceTrust := []string{}, []string{}, ""
+	for _, condition := range policy.Conditions.All {
+		switch {
+		case len(condition.IPAddressIn) > 0:
+			ipListIDs = append(ipListIDs, condition.IPAddressIn...)
+		case condition.Not != nil && len(condition.Not.IPAddressIn) > 0:
+			outsideIPListIDs = append(outsideIPListIDs, condition.Not.IPAddressIn...)
+		case condition.DeviceManaged != nil && *condition.DeviceManaged:
+			deviceTrust = "trusted"
+		case condition.DeviceManaged != nil:
+			deviceTrust = "untrusted"
+		default:
+			return fmt.Errorf("conditional access policy %s has a condition not supported by the provider", d.Id())
+		}
+	}
+
+	effect := policy.Effect.Action
+	if effect == "allow" && policy.Effect.Obligations != nil && policy.Effect.Obligations.MFA != nil &&
+		policy.Effect.Obligations.MFA.Required {
+		effect = "require_mfa"
+	}
+
+	if err := d.Set("ip_list_ids", ipListIDs); err != nil {
+		return err
+	}
+	if err := d.Set("outside_ip_list_ids", outsideIPListIDs); err != nil {
+		return err
+	}
+	if err := d.Set("device_trust", deviceTrust); err != nil {
+		return err
+	}
+	if err := d.Set("effect", effect); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceConditionalAccessPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandConditionalAccessPolicy(d)
+	var policy AuthnPolicy
+	if _, err := jumpCloudRequest(config, http.MethodPost, "/authn/policies", body, &policy); err != nil {
+		return fmt.Errorf("error creating conditional access policy %s: %w", body.Name, err)
+	}
+
+	d.SetId(policy.ID)
+	return resourceConditionalAccessPolicyRead(d, m)
+}
+
+func resourceConditionalAccessPolicyRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var policy AuthnPolicy
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/authn/policies/"+d.Id(), nil, &policy)
+	if err != nil {
+		return fmt.Errorf("error reading conditional access policy %s: %w", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return err
+	}
+	if err := d.Set("description", policy.Description); err != nil {
+		return err
+	}
+	if err := d.Set("enabled", !policy.Disabled); err != nil {
+		return err
+	}
+	if err := d.Set("user_group_ids", policy.Targets.UserGroups.Inclusions); err != nil {
+		return err
+	}
+	return flattenConditionalAccessPolicy(d, policy)
+}
+
+func resourceConditionalAccessPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandConditionalAccessPolicy(d)
+	if _, err := jumpCloudRequest(config, http.MethodPut, "/authn/policies/"+d.Id(), body, nil); err != nil {
+		return fmt.Errorf("error updating conditional access policy %s: %w", d.Id(), err)
+	}
+	return resourceConditionalAccessPolicyRead(d, m)
+}
+
+func resourceConditionalAccessPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudRequest(config, http.MethodDelete, "/authn/policies/"+d.Id(), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting conditional access policy %s: %w", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,140 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccConditionalAccessPolicy(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_conditional_access_policy.test_policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConditionalAccessPolicy(rName, "require_mfa"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "effect", "require_mfa"),
+					resource.TestCheckResourceAttr(fullResourceName, "outside_ip_list_ids.#", "1"),
+					resource.TestCheckResourceAttr(fullResourceName, "device_trust", "trusted"),
+				),
+			},
+			{
+				Config: testAccConditionalAccessPolicy(rName, "deny"),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "effect", "deny"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccConditionalAccessPolicy(name, effect string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+		}
+
+		resource "jumpcloud_ip_list" "test_list" {
+			name         = "%[1]s"
+			type         = "allow"
+			ip_addresses = ["198.51.100.0/24"]
+		}
+
+		resource "jumpcloud_conditional_access_policy" "test_policy" {
+			name                = "%[1]s"
+			user_group_ids      = [jumpcloud_user_group.test_group.id]
+			outside_ip_list_ids = [jumpcloud_ip_list.test_list.id]
+			device_trust        = "trusted"
+			effect              = "%[2]s"
+		}`, name, effect,
+	)
+}
+
+func TestConditionalAccessPolicy(t *testing.T) {
+	var policy json.RawMessage
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/v2/authn/policies", r.URL.Path)
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			body["id"] = "policy"
+			policy, _ = json.Marshal(body)
+		case http.MethodPut:
+			assert.Equal(t, "/api/v2/authn/policies/policy", r.URL.Path)
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			body["id"] = "policy"
+			policy, _ = json.Marshal(body)
+		case http.MethodGet:
+			assert.Equal(t, "/api/v2/authn/policies/policy", r.URL.Path)
+		}
+		_, _ = rw.Write(policy)
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceConditionalAccessPolicy()
+
+	// require MFA on managed devices outside of the office
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                "Remote MFA",
+		"user_group_ids":      []interface{}{"engineering"},
+		"outside_ip_list_ids": []interface{}{"office"},
+		"device_trust":        "trusted",
+		"effect":              "require_mfa",
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "policy", d.Id())
+	assert.JSONEq(t, `{
+		"id": "policy",
+		"name": "Remote MFA",
+		"disabled": false,
+		"targets": {"userGroups": {"inclusions": ["engineering"]}},
+		"conditions": {"all": [{"not": {"ipAddressIn": ["office"]}}, {"deviceManaged": true}]},
+		"effect": {"action": "allow", "obligations": {"mfa": {"required": true}}}
+	}`, string(policy))
+	assert.Equal(t, "require_mfa", d.Get("effect"))
+	assert.Equal(t, "trusted", d.Get("device_trust"))
+	assert.Equal(t, []interface{}{"office"}, d.Get("outside_ip_list_ids").(*schema.Set).List())
+	assert.Empty(t, d.Get("ip_list_ids").(*schema.Set).List())
+
+	// deny untrusted devices instead
+	assert.NoError(t, d.Set("device_trust", "untrusted"))
+	assert.NoError(t, d.Set("effect", "deny"))
+	assert.NoError(t, r.Update(d, config))
+	assert.JSONEq(t, `{"action": "deny"}`, string(jsonField(t, policy, "effect")))
+	assert.Equal(t, "deny", d.Get("effect"))
+	assert.Equal(t, "untrusted", d.Get("device_trust"))
+
+	// conditions the resource can't express aren't dropped silently
+	policy = json.RawMessage(`{"id": "policy", "name": "Remote MFA", "targets": {"userGroups": {"inclusions": []}},
+		"conditions": {"all": [{"locationIn": {"countries": ["DK"]}}]}, "effect": {"action": "deny"}}`)
+	err := r.Read(d, config)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "has a condition not supported by the provider")
+	}
+}
+
+// jsonField returns the JSON of a top level field of doc
+func jsonField(t *testing.T, doc json.RawMessage, field string) json.RawMessage {
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(doc, &fields))
+	return fields[field]
+}
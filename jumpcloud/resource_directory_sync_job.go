@@ -0,0 +1,106 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceDirectorySyncJob() *schema.Resource {
+	return &schema.Resource{
+		Description: "Triggers an immediate synchronization of a JumpCloud directory integration. " +
+			"Changing `triggers` triggers a new synchronization.",
+		Create: resourceDirectorySyncJobCreate,
+		Read:   resourceDirectorySyncJobRead,
+		Delete: resourceDirectorySyncJobDelete,
+		Schema: map[string]*schema.Schema{
+			"directory_id": {
+				Description: "The ID of the directory integration to synchronize.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"sync_type": {
+				Description:  "What to synchronize. Possible values: `users`, `groups`, `all`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"users", "groups", "all"}, false),
+			},
+			"triggers": {
+				Description: "Arbitrary values that, when changed, trigger a new synchronization.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"last_sync_at": {
+				Description: "When the synchronization last completed, or started if it is still running.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sync_status": {
+				Description: "The status of the synchronization as reported by JumpCloud.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func directorySyncJobsPath(d *schema.ResourceData) string {
+	return "/directories/" + d.Get("directory_id").(string) + "/syncjobs"
+}
+
+func resourceDirectorySyncJobCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var job DirectorySyncJob
+	payload := DirectorySyncJob{Type: d.Get("sync_type").(string)}
+	_, err := jumpCloudRequest(config, http.MethodPost, directorySyncJobsPath(d), payload, &job)
+	if err != nil {
+		return fmt.Errorf("error triggering sync of directory %s: %s", d.Get("directory_id"), err)
+	}
+
+	d.SetId(job.ID)
+	return resourceDirectorySyncJobRead(d, m)
+}
+
+func resourceDirectorySyncJobRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var job DirectorySyncJob
+	ok, err := jumpCloudRequest(config, http.MethodGet, directorySyncJobsPath(d)+"/"+d.Id(), nil, &job)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// jobs are only kept for a while, the recorded outcome stays in state
+		return nil
+	}
+
+	lastSyncAt := job.CompletedAt
+	if lastSyncAt == "" {
+		lastSyncAt = job.StartedAt
+	}
+
+	if err := d.Set("last_sync_at", lastSyncAt); err != nil {
+		return err
+	}
+	if err := d.Set("sync_status", job.Status); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceDirectorySyncJobDelete(d *schema.ResourceData, m interface{}) error {
+	// a sync that has been triggered can't be undone
+	d.SetId("")
+	return nil
+}
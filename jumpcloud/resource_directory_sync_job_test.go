@@ -0,0 +1,50 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDirectorySyncJob(t *testing.T) {
+	directoryID := os.Getenv("JUMPCLOUD_GSUITE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if directoryID == "" {
+				t.Skip("JUMPCLOUD_GSUITE_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDirectorySyncJob(directoryID, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_directory_sync_job.test_sync", "sync_type", "users"),
+					resource.TestCheckResourceAttrSet("jumpcloud_directory_sync_job.test_sync", "sync_status"),
+				),
+			},
+			{
+				Config: testAccDirectorySyncJob(directoryID, "2"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_directory_sync_job.test_sync",
+					"triggers.run", "2"),
+			},
+		},
+	})
+}
+
+func testAccDirectorySyncJob(directoryID, run string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_directory_sync_job" "test_sync" {
+			directory_id = "%s"
+			sync_type    = "users"
+			triggers = {
+				run = "%s"
+			}
+		}`, directoryID, run,
+	)
+}
@@ -0,0 +1,29 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceGSuite() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing the settings of a Google Workspace (G Suite) directory " +
+			"integration. Connecting JumpCloud to Google requires Google's consent screen, so the directory is " +
+			"connected in the console and adopted by its name.",
+		Create: gSuiteSettings.create,
+		Read:   gSuiteSettings.read,
+		Update: gSuiteSettings.update,
+		Delete: gSuiteSettings.delete,
+		Schema: gSuiteSettings.schema(),
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+var gSuiteSettings = &directorySettings{
+	path:          "/gsuites",
+	directoryType: "g_suite",
+	kind:          "Google Workspace",
+	service:       "Google",
+	tenant:        "Google Workspace",
+}
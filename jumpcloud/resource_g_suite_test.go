@@ -0,0 +1,110 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccGSuite(t *testing.T) {
+	name := os.Getenv("JUMPCLOUD_GSUITE_NAME")
+	fullResourceName := "jumpcloud_g_suite.test_directory"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if name == "" {
+				t.Skip("JUMPCLOUD_GSUITE_NAME must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGSuite(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "name", name),
+					resource.TestCheckResourceAttr(fullResourceName, "group_sync", "true"),
+					resource.TestCheckResourceAttrSet(fullResourceName, "domain"),
+				),
+			},
+			{
+				Config: testAccGSuite(name, false),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "group_sync", "false"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGSuite(name string, groupSync bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_g_suite" "test_directory" {
+			name                = "%s"
+			group_sync          = %t
+			user_lockout_action = "suspend"
+		}`, name, groupSync,
+	)
+}
+
+func TestGSuiteCreate(t *testing.T) {
+	directory := DirectorySettings{
+		ID:                           "gs",
+		Name:                         "Workspace",
+		DefaultDomain:                &DirectoryDomain{ID: "domain", Domain: "example.com"},
+		UserLockoutAction:            "suspend",
+		UserPasswordExpirationAction: "maintain",
+	}
+	var patch map[string]interface{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/directories":
+			assert.NoError(t, json.NewEncoder(rw).Encode([]jcapiv2.Directory{
+				{Id: "ldap", Name: "Workspace", Type_: "ldap_server"},
+				{Id: "gs", Name: "Workspace", Type_: "g_suite"},
+				{Id: "o365", Name: "Office", Type_: "office_365"},
+			}))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/gsuites/gs":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			directory.GroupsEnabled = patch["groupsEnabled"].(bool)
+			assert.NoError(t, json.NewEncoder(rw).Encode(directory))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/gsuites/gs":
+			assert.NoError(t, json.NewEncoder(rw).Encode(directory))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceGSuite()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":       "Workspace",
+		"group_sync": true,
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "gs", d.Id())
+	assert.Equal(t, "example.com", d.Get("domain"))
+	assert.Equal(t, true, d.Get("group_sync"))
+	// the lockout actions aren't configured, so JumpCloud's are kept
+	assert.NotContains(t, patch, "userLockoutAction")
+	assert.Equal(t, "maintain", d.Get("user_password_expiration_action"))
+
+	// directories that aren't connected yet can't be adopted
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "Office"})
+	assert.EqualError(t, r.Create(d, config),
+		"no Google Workspace directory named Office, connect it in the JumpCloud console first")
+}
@@ -0,0 +1,151 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceGoogleWorkspaceSyncRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for mapping a Google Workspace group to a JumpCloud user group.",
+		Create:      resourceGoogleWorkspaceSyncRuleCreate,
+		Read:        resourceGoogleWorkspaceSyncRuleRead,
+		Update:      resourceGoogleWorkspaceSyncRuleUpdate,
+		Delete:      resourceGoogleWorkspaceSyncRuleDelete,
+		Schema: map[string]*schema.Schema{
+			"directory_id": {
+				Description: "The ID of the Google Workspace directory integration.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"google_group_email": {
+				Description: "The email address of the Google group to sync.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"jumpcloud_group_id": {
+				Description: "The ID of the JumpCloud user group the Google group is mapped to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"sync_direction": {
+				Description: "The direction of the sync. Possible values: `gws_to_jc`, `jc_to_gws`, `bidirectional`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "gws_to_jc",
+				ValidateFunc: validation.StringInSlice([]string{
+					"gws_to_jc",
+					"jc_to_gws",
+					"bidirectional",
+				}, false),
+			},
+			"enabled": {
+				Description: "Whether the sync rule is active.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: googleWorkspaceSyncRuleImporter,
+		},
+	}
+}
+
+func googleWorkspaceSyncRuleImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.Split(d.Id(), "/")
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'directory_id/rule_id'")
+	}
+
+	_ = d.Set("directory_id", ids[0])
+	d.SetId(ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func googleWorkspaceSyncRulePath(d *schema.ResourceData) string {
+	return "/gsuites/" + d.Get("directory_id").(string) + "/syncrules"
+}
+
+func expandGoogleWorkspaceSyncRule(d *schema.ResourceData) GoogleWorkspaceSyncRule {
+	return GoogleWorkspaceSyncRule{
+		GoogleGroupEmail: d.Get("google_group_email").(string),
+		UserGroupID:      d.Get("jumpcloud_group_id").(string),
+		SyncDirection:    d.Get("sync_direction").(string),
+		Enabled:          d.Get("enabled").(bool),
+	}
+}
+
+func resourceGoogleWorkspaceSyncRuleCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var rule GoogleWorkspaceSyncRule
+	_, err := jumpCloudRequest(config, http.MethodPost, googleWorkspaceSyncRulePath(d),
+		expandGoogleWorkspaceSyncRule(d), &rule)
+	if err != nil {
+		return fmt.Errorf("error creating google workspace sync rule for group %s: %s",
+			d.Get("google_group_email"), err)
+	}
+
+	d.SetId(rule.ID)
+	return resourceGoogleWorkspaceSyncRuleRead(d, m)
+}
+
+func resourceGoogleWorkspaceSyncRuleRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var rule GoogleWorkspaceSyncRule
+	ok, err := jumpCloudRequest(config, http.MethodGet,
+		googleWorkspaceSyncRulePath(d)+"/"+d.Id(), nil, &rule)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("google_group_email", rule.GoogleGroupEmail); err != nil {
+		return err
+	}
+	if err := d.Set("jumpcloud_group_id", rule.UserGroupID); err != nil {
+		return err
+	}
+	if err := d.Set("sync_direction", rule.SyncDirection); err != nil {
+		return err
+	}
+	if err := d.Set("enabled", rule.Enabled); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceGoogleWorkspaceSyncRuleUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodPut,
+		googleWorkspaceSyncRulePath(d)+"/"+d.Id(), expandGoogleWorkspaceSyncRule(d), nil)
+	if err != nil {
+		return fmt.Errorf("error updating google workspace sync rule %s: %s", d.Id(), err)
+	}
+	return resourceGoogleWorkspaceSyncRuleRead(d, m)
+}
+
+func resourceGoogleWorkspaceSyncRuleDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete,
+		googleWorkspaceSyncRulePath(d)+"/"+d.Id(), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting google workspace sync rule %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,57 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccGoogleWorkspaceSyncRule(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	directoryID := os.Getenv("JUMPCLOUD_GSUITE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if directoryID == "" {
+				t.Skip("JUMPCLOUD_GSUITE_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGoogleWorkspaceSyncRule(rName, directoryID, "gws_to_jc"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_google_workspace_sync_rule.test_rule",
+						"google_group_email", rName+"@testorg.com"),
+					resource.TestCheckResourceAttr("jumpcloud_google_workspace_sync_rule.test_rule",
+						"sync_direction", "gws_to_jc"),
+				),
+			},
+			{
+				Config: testAccGoogleWorkspaceSyncRule(rName, directoryID, "bidirectional"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_google_workspace_sync_rule.test_rule",
+					"sync_direction", "bidirectional"),
+			},
+		},
+	})
+}
+
+func testAccGoogleWorkspaceSyncRule(name, directoryID, direction string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+		}
+
+		resource "jumpcloud_google_workspace_sync_rule" "test_rule" {
+			directory_id       = "%[2]s"
+			google_group_email = "%[1]s@testorg.com"
+			jumpcloud_group_id = jumpcloud_user_group.test_group.id
+			sync_direction     = "%[3]s"
+		}`, name, directoryID, direction,
+	)
+}
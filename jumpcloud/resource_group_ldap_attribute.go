@@ -0,0 +1,131 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceGroupLdapAttribute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a custom LDAP attribute of a JumpCloud user group.",
+		Create:      resourceGroupLdapAttributeCreate,
+		Read:        resourceGroupLdapAttributeRead,
+		Update:      resourceGroupLdapAttributeUpdate,
+		Delete:      resourceGroupLdapAttributeDelete,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attribute_name": {
+				Description: "The name of the LDAP attribute.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attribute_value": {
+				Description: "The value of the LDAP attribute. Multiple values are separated by commas if `is_array` is set.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"is_array": {
+				Description: "Whether the attribute is multi-valued.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: groupLdapAttributeImporter,
+		},
+	}
+}
+
+func groupLdapAttributeImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), "/", 2)
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'group_id/attribute_name'")
+	}
+
+	_ = d.Set("group_id", ids[0])
+	_ = d.Set("attribute_name", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func groupLdapAttributePath(d *schema.ResourceData) string {
+	return "/usergroups/" + d.Get("group_id").(string) + "/ldapattributes/" + d.Get("attribute_name").(string)
+}
+
+func putGroupLdapAttribute(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	payload := LdapAttribute{
+		Name:    d.Get("attribute_name").(string),
+		Value:   d.Get("attribute_value").(string),
+		IsArray: d.Get("is_array").(bool),
+	}
+
+	_, err := jumpCloudRequest(config, http.MethodPut, groupLdapAttributePath(d), payload, nil)
+	if err != nil {
+		return fmt.Errorf("error setting LDAP attribute %s on group %s: %s",
+			payload.Name, d.Get("group_id"), err)
+	}
+	return nil
+}
+
+func resourceGroupLdapAttributeCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putGroupLdapAttribute(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("group_id").(string) + "/" + d.Get("attribute_name").(string))
+	return resourceGroupLdapAttributeRead(d, m)
+}
+
+func resourceGroupLdapAttributeRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var attribute LdapAttribute
+	ok, err := jumpCloudRequest(config, http.MethodGet, groupLdapAttributePath(d), nil, &attribute)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("attribute_value", attribute.Value); err != nil {
+		return err
+	}
+	if err := d.Set("is_array", attribute.IsArray); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceGroupLdapAttributeUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putGroupLdapAttribute(d, m); err != nil {
+		return err
+	}
+	return resourceGroupLdapAttributeRead(d, m)
+}
+
+func resourceGroupLdapAttributeDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete, groupLdapAttributePath(d), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error removing LDAP attribute %s from group %s: %s",
+			d.Get("attribute_name"), d.Get("group_id"), err)
+	}
+	d.SetId("")
+	return nil
+}
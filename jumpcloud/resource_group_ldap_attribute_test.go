@@ -0,0 +1,52 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccGroupLdapAttribute(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupLdapAttribute(rName, "engineering"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_group_ldap_attribute.test_attribute", "attribute_name", "department"),
+					resource.TestCheckResourceAttr("jumpcloud_group_ldap_attribute.test_attribute", "attribute_value", "engineering"),
+				),
+			},
+			{
+				Config: testAccGroupLdapAttribute(rName, "sales"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_group_ldap_attribute.test_attribute",
+					"attribute_value", "sales"),
+			},
+			{
+				ResourceName:      "jumpcloud_group_ldap_attribute.test_attribute",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGroupLdapAttribute(name, value string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_group_ldap_attribute" "test_attribute" {
+			group_id        = jumpcloud_user_group.test_group.id
+			attribute_name  = "department"
+			attribute_value = "%s"
+		}`, name, value,
+	)
+}
@@ -0,0 +1,141 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceIPList() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a list of IP addresses and ranges conditional access " +
+			"policies allow or deny.",
+		Create: resourceIPListCreate,
+		Read:   resourceIPListRead,
+		Update: resourceIPListUpdate,
+		Delete: resourceIPListDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:  "The name of the IP list.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"description": {
+				Description: "The description of the IP list.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": {
+				Description:  "Whether the addresses of the list are allowed or denied. Possible values: `allow`, `deny`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+			},
+			"ip_addresses": {
+				Description: "The IPv4 or IPv6 addresses and CIDR ranges of the list, e.g. `203.0.113.7` or `198.51.100.0/24`.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateIPOrCIDR,
+				},
+				Set: schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// validateIPOrCIDR checks entries of IP lists at plan time, the API only
+// rejects the whole list
+func validateIPOrCIDR(v interface{}, k string) (ws []string, es []error) {
+	s := v.(string)
+	if strings.Contains(s, "/") {
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			es = append(es, fmt.Errorf("%s must be an IP address or CIDR range, got invalid CIDR %q", k, s))
+		}
+		return
+	}
+	if net.ParseIP(s) == nil {
+		es = append(es, fmt.Errorf("%s must be an IP address or CIDR range, got %q", k, s))
+	}
+	return
+}
+
+func expandIPList(d *schema.ResourceData) IPList {
+	return IPList{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Type:        d.Get("type").(string),
+		IPs:         interfacesToStrings(d.Get("ip_addresses").(*schema.Set).List()),
+	}
+}
+
+func resourceIPListCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandIPList(d)
+	var list IPList
+	if _, err := jumpCloudRequest(config, http.MethodPost, "/iplists", body, &list); err != nil {
+		return fmt.Errorf("error creating IP list %s: %w", body.Name, err)
+	}
+
+	d.SetId(list.ID)
+	return resourceIPListRead(d, m)
+}
+
+func resourceIPListRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var list IPList
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/iplists/"+d.Id(), nil, &list)
+	if err != nil {
+		return fmt.Errorf("error reading IP list %s: %w", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", list.Name); err != nil {
+		return err
+	}
+	if err := d.Set("description", list.Description); err != nil {
+		return err
+	}
+	if err := d.Set("type", list.Type); err != nil {
+		return err
+	}
+	if err := d.Set("ip_addresses", list.IPs); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceIPListUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudRequest(config, http.MethodPut, "/iplists/"+d.Id(), expandIPList(d), nil); err != nil {
+		return fmt.Errorf("error updating IP list %s: %w", d.Id(), err)
+	}
+	return resourceIPListRead(d, m)
+}
+
+func resourceIPListDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudRequest(config, http.MethodDelete, "/iplists/"+d.Id(), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting IP list %s: %w", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,142 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccIPList(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_ip_list.test_list"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPList(rName, "203.0.113.7", "198.51.100.0/33"),
+				ExpectError: regexp.MustCompile("got invalid CIDR"),
+			},
+			{
+				Config: testAccIPList(rName, "203.0.113.7", "198.51.100.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "name", rName),
+					resource.TestCheckResourceAttr(fullResourceName, "ip_addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccIPList(rName, "2001:db8::/32"),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "ip_addresses.#", "1"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIPList(name string, ips ...string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_ip_list" "test_list" {
+			name         = "%s"
+			type         = "allow"
+			ip_addresses = ["%s"]
+		}`, name, strings.Join(ips, `", "`),
+	)
+}
+
+func TestValidateIPOrCIDR(t *testing.T) {
+	cases := []struct {
+		Value string
+		Valid bool
+	}{
+		{"203.0.113.7", true},
+		{"198.51.100.0/24", true},
+		{"2001:db8::1", true},
+		{"2001:db8::/32", true},
+		{"198.51.100.0/33", false},
+		{"198.51.100/24", false},
+		{"203.0.113.256", false},
+		{"office", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		_, errs := validateIPOrCIDR(c.Value, "ip_addresses")
+		assert.Equal(t, c.Valid, len(errs) == 0, c.Value)
+	}
+}
+
+func TestIPListValidate(t *testing.T) {
+	_, errs := resourceIPList().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":         "office",
+		"type":         "allow",
+		"ip_addresses": []interface{}{"203.0.113.7", "10.0.0.0/40"},
+	}))
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), `got invalid CIDR "10.0.0.0/40"`)
+	}
+}
+
+func TestIPList(t *testing.T) {
+	var list IPList
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/v2/iplists", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&list))
+			list.ID = "list"
+		case http.MethodPut:
+			assert.Equal(t, "/api/v2/iplists/list", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&list))
+			list.ID = "list"
+		case http.MethodGet:
+			assert.Equal(t, "/api/v2/iplists/list", r.URL.Path)
+		case http.MethodDelete:
+			// deleted outside of Terraform
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(list))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceIPList()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":         "office",
+		"type":         "allow",
+		"ip_addresses": []interface{}{"203.0.113.7", "198.51.100.0/24"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "list", d.Id())
+	assert.ElementsMatch(t, []string{"203.0.113.7", "198.51.100.0/24"}, list.IPs)
+	assert.Equal(t, 2, d.Get("ip_addresses").(*schema.Set).Len())
+
+	assert.NoError(t, d.Set("type", "deny"))
+	assert.NoError(t, d.Set("ip_addresses", []string{"203.0.113.7"}))
+	assert.NoError(t, r.Update(d, config))
+	assert.Equal(t, "deny", list.Type)
+	assert.Equal(t, []string{"203.0.113.7"}, list.IPs)
+	assert.Equal(t, "deny", d.Get("type"))
+
+	// a list that is gone already counts as deleted
+	assert.NoError(t, r.Delete(d, config))
+	assert.Empty(t, d.Id())
+}
@@ -0,0 +1,44 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceLdapServerUserGroupAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Binds JumpCloud user groups to an LDAP server, which exposes their members in the LDAP directory. " +
+			"User groups bound to the server outside of Terraform are left alone.",
+		Create: ldapServerUserGroupAssociation.create,
+		Read:   ldapServerUserGroupAssociation.read,
+		Update: ldapServerUserGroupAssociation.update,
+		Delete: ldapServerUserGroupAssociation.delete,
+		Schema: map[string]*schema.Schema{
+			"ldap_server_id": {
+				Description: "The ID of the LDAP server, e.g. from the `jumpcloud_ldap_server` data source.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_group_ids": {
+				Description: "The IDs of the user groups.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: ldapServerUserGroupAssociation.importer,
+		},
+	}
+}
+
+var ldapServerUserGroupAssociation = &graphAssociation{
+	path:            "/ldapservers/%s/associations",
+	sourceAttribute: "ldap_server_id",
+	sourceName:      "LDAP server",
+	targets: map[string]string{
+		"user_group_ids": "user_group",
+	},
+}
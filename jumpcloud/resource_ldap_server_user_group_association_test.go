@@ -0,0 +1,150 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccLdapServerUserGroupAssociation(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_ldap_server_user_group_association.test_association"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLdapServerUserGroupAssociation(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(fullResourceName, "ldap_server_id",
+						"data.jumpcloud_ldap_server.test_server", "id"),
+					resource.TestCheckResourceAttr(fullResourceName, "user_group_ids.#", "1"),
+				),
+			},
+			{ // unbind the group via the api, then check the plan binds it again
+				PreConfig:          unbindLdapServerUserGroupViaAPI(t, rName),
+				Config:             testAccLdapServerUserGroupAssociation(rName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccLdapServerUserGroupAssociation(rName),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "user_group_ids.#", "1"),
+			},
+		},
+	})
+}
+
+func testAccLdapServerUserGroupAssociation(name string) string {
+	return fmt.Sprintf(`
+		data "jumpcloud_ldap_server" "test_server" {}
+
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+		}
+
+		resource "jumpcloud_ldap_server_user_group_association" "test_association" {
+			ldap_server_id = data.jumpcloud_ldap_server.test_server.id
+			user_group_ids = [jumpcloud_user_group.test_group.id]
+		}`, name,
+	)
+}
+
+func unbindLdapServerUserGroupViaAPI(t *testing.T, name string) func() {
+	return func() {
+		config := testAccAPIConfig()
+
+		groupID, err := userGroupIDByName(config, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ldapServerIDs, err := getLdapServerIDsByGroup(config, groupID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ldapServerID := range ldapServerIDs {
+			if err := ldapServerUserGroupAssociation.manage(context.TODO(), config, ldapServerID, "user_group", groupID, "remove"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// getLdapServerIDsByGroup returns the LDAP servers the user group is
+// bound to
+func getLdapServerIDsByGroup(config *Meta, groupID string) ([]string, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	graphconnect, _, err := client.UserGroupAssociationsApi.GraphUserGroupAssociationsList(
+		context.TODO(), groupID, "", "", []string{"ldap_server"}, nil)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, v := range graphconnect {
+		ids = append(ids, v.To.Id)
+	}
+	return ids, nil
+}
+
+func TestLdapServerUserGroupAssociationReconcile(t *testing.T) {
+	bound := map[string]bool{"other": true}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ldapservers/ldap/associations", r.URL.Path)
+		if r.Method == http.MethodPost {
+			var req jcapiv2.GraphManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Op == "add" {
+				bound[req.Id] = true
+			} else {
+				delete(bound, req.Id)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		connections := []jcapiv2.GraphConnection{}
+		for id := range bound {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	boundIDs := func() []string {
+		ids := []string{}
+		for id := range bound {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceLdapServerUserGroupAssociation()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ldap_server_id": "ldap",
+		"user_group_ids": []interface{}{"a", "b"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, []string{"a", "b", "other"}, boundIDs())
+
+	// unbound outside of Terraform
+	delete(bound, "b")
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, []interface{}{"a"}, d.Get("user_group_ids").(*schema.Set).List())
+
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, []string{"other"}, boundIDs())
+}
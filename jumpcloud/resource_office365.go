@@ -0,0 +1,29 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceOffice365() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing the settings of an Office 365 directory integration. " +
+			"Connecting JumpCloud to Microsoft requires the admin consent of the tenant, so the directory is " +
+			"connected in the console and adopted by its name.",
+		Create: office365Settings.create,
+		Read:   office365Settings.read,
+		Update: office365Settings.update,
+		Delete: office365Settings.delete,
+		Schema: office365Settings.schema(),
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+var office365Settings = &directorySettings{
+	path:          "/office365s",
+	directoryType: "office_365",
+	kind:          "Office 365",
+	service:       "Office 365",
+	tenant:        "Office 365 tenant",
+}
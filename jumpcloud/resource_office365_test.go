@@ -0,0 +1,110 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccOffice365(t *testing.T) {
+	name := os.Getenv("JUMPCLOUD_OFFICE365_NAME")
+	fullResourceName := "jumpcloud_office365.test_directory"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if name == "" {
+				t.Skip("JUMPCLOUD_OFFICE365_NAME must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "jumpcloud_office365" "test_directory" {
+						name       = "%s"
+						group_sync = true
+					}`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "name", name),
+					resource.TestCheckResourceAttr(fullResourceName, "group_sync", "true"),
+					resource.TestCheckResourceAttrSet(fullResourceName, "domain"),
+				),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestOffice365CreateUpdate(t *testing.T) {
+	directory := DirectorySettings{
+		ID:                "o365",
+		Name:              "Office",
+		DefaultDomain:     &DirectoryDomain{ID: "domain", Domain: "example.onmicrosoft.com"},
+		UserLockoutAction: "suspend",
+	}
+	var patches []map[string]interface{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/directories":
+			assert.NoError(t, json.NewEncoder(rw).Encode([]jcapiv2.Directory{
+				{Id: "gs", Name: "Office", Type_: "g_suite"},
+				{Id: "o365", Name: "Office", Type_: "office_365"},
+			}))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v2/office365s/o365":
+			var patch map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			patches = append(patches, patch)
+			directory.Name = patch["name"].(string)
+			directory.GroupsEnabled = patch["groupsEnabled"].(bool)
+			if action, ok := patch["userPasswordExpirationAction"]; ok {
+				directory.UserPasswordExpirationAction = action.(string)
+			}
+			assert.NoError(t, json.NewEncoder(rw).Encode(directory))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/office365s/o365":
+			assert.NoError(t, json.NewEncoder(rw).Encode(directory))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceOffice365()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":       "Office",
+		"group_sync": true,
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "o365", d.Id())
+	assert.Equal(t, "example.onmicrosoft.com", d.Get("domain"))
+	assert.Equal(t, true, d.Get("group_sync"))
+	assert.Equal(t, "suspend", d.Get("user_lockout_action"))
+
+	assert.NoError(t, d.Set("name", "Microsoft 365"))
+	assert.NoError(t, d.Set("group_sync", false))
+	assert.NoError(t, d.Set("user_password_expiration_action", "maintain"))
+	assert.NoError(t, r.Update(d, config))
+	assert.Len(t, patches, 2)
+	assert.Equal(t, "Microsoft 365", d.Get("name"))
+	assert.Equal(t, false, d.Get("group_sync"))
+	assert.Equal(t, "maintain", d.Get("user_password_expiration_action"))
+
+	// the directory stays connected
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, "", d.Id())
+}
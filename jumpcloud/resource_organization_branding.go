@@ -0,0 +1,210 @@
+package jumpcloud
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var validateHexColor = validation.StringMatch(regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`),
+	"must be a hex color code, e.g. #1A2B3C")
+
+func resourceOrganizationBranding() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for customizing the JumpCloud user portal of the organization. " +
+			"There is a single branding per organization.",
+		Create:        resourceOrganizationBrandingCreate,
+		Read:          resourceOrganizationBrandingRead,
+		Update:        resourceOrganizationBrandingUpdate,
+		Delete:        resourceOrganizationBrandingDelete,
+		CustomizeDiff: organizationBrandingLogoDiff,
+		Schema: map[string]*schema.Schema{
+			"logo_url": {
+				Description:   "The URL of the logo displayed in the user portal.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"logo_file_path"},
+				ValidateFunc:  validation.IsURLWithHTTPorHTTPS,
+			},
+			"logo_file_path": {
+				Description:   "The path of a local image file uploaded as the logo.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"logo_url"},
+			},
+			"logo_sha256": {
+				Description: "The SHA-256 hash of the contents of the uploaded `logo_file_path`. Changes to the file " +
+					"show up as a change of the hash and upload it again.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"primary_color": {
+				Description:  "The primary color of the user portal, e.g. `#1A2B3C`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateHexColor,
+			},
+			"secondary_color": {
+				Description:  "The secondary color of the user portal, e.g. `#FFFFFF`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateHexColor,
+			},
+			"helpdesk_url": {
+				Description:  "The URL of the helpdesk linked from the user portal.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"helpdesk_label": {
+				Description: "The label of the helpdesk link.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"support_link": {
+				Description: "The support link displayed on the login page.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// readLogoFile returns the contents of the logo file and their hex
+// SHA-256 hash, or nothing without a file
+func readLogoFile(path string) (logo []byte, hash string, err error) {
+	if path == "" {
+		return nil, "", nil
+	}
+	logo, err = os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading logo file %s: %s", path, err)
+	}
+	sum := sha256.Sum256(logo)
+	return logo, hex.EncodeToString(sum[:]), nil
+}
+
+// organizationBrandingLogoDiff plans an upload of the logo file when its
+// contents changed, only its path is configured
+func organizationBrandingLogoDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("logo_file_path") {
+		return d.SetNewComputed("logo_sha256")
+	}
+	_, hash, err := readLogoFile(d.Get("logo_file_path").(string))
+	if err != nil {
+		return err
+	}
+	if hash != d.Get("logo_sha256").(string) {
+		return d.SetNew("logo_sha256", hash)
+	}
+	return nil
+}
+
+// expandOrganizationBranding builds the branding from d, along with the
+// hash of the uploaded logo file
+func expandOrganizationBranding(d *schema.ResourceData) (*OrganizationBranding, string, error) {
+	branding := &OrganizationBranding{
+		LogoURL:        d.Get("logo_url").(string),
+		PrimaryColor:   d.Get("primary_color").(string),
+		SecondaryColor: d.Get("secondary_color").(string),
+		HelpdeskURL:    d.Get("helpdesk_url").(string),
+		HelpdeskLabel:  d.Get("helpdesk_label").(string),
+		SupportLink:    d.Get("support_link").(string),
+	}
+
+	logo, hash, err := readLogoFile(d.Get("logo_file_path").(string))
+	if err != nil {
+		return nil, "", err
+	}
+	if logo != nil {
+		branding.LogoURL = ""
+		branding.Logo = base64.StdEncoding.EncodeToString(logo)
+	}
+	return branding, hash, nil
+}
+
+func putOrganizationBranding(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	branding, hash, err := expandOrganizationBranding(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = jumpCloudRequest(config, http.MethodPut, "/branding", branding, nil)
+	if err != nil {
+		return fmt.Errorf("error updating organization branding: %s", err)
+	}
+	// the hash of the file as uploaded, which differs from the planned one
+	// if the file changed since
+	return d.Set("logo_sha256", hash)
+}
+
+func resourceOrganizationBrandingCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putOrganizationBranding(d, m); err != nil {
+		return err
+	}
+
+	d.SetId("branding")
+	return resourceOrganizationBrandingRead(d, m)
+}
+
+func resourceOrganizationBrandingRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var branding OrganizationBranding
+	_, err := jumpCloudRequest(config, http.MethodGet, "/branding", nil, &branding)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("logo_url", branding.LogoURL); err != nil {
+		return err
+	}
+	if err := d.Set("primary_color", branding.PrimaryColor); err != nil {
+		return err
+	}
+	if err := d.Set("secondary_color", branding.SecondaryColor); err != nil {
+		return err
+	}
+	if err := d.Set("helpdesk_url", branding.HelpdeskURL); err != nil {
+		return err
+	}
+	if err := d.Set("helpdesk_label", branding.HelpdeskLabel); err != nil {
+		return err
+	}
+	if err := d.Set("support_link", branding.SupportLink); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceOrganizationBrandingUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putOrganizationBranding(d, m); err != nil {
+		return err
+	}
+	return resourceOrganizationBrandingRead(d, m)
+}
+
+func resourceOrganizationBrandingDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	// restores the default JumpCloud branding
+	_, err := jumpCloudRequest(config, http.MethodDelete, "/branding", nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error resetting organization branding: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,126 @@
+package jumpcloud
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccOrganizationBranding(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOrganizationBranding("1A2B3C"),
+				ExpectError: regexp.MustCompile("must be a hex color code"),
+			},
+			{
+				Config: testAccOrganizationBranding("#1A2B3C"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_organization_branding.test_branding", "primary_color", "#1A2B3C"),
+					resource.TestCheckResourceAttr("jumpcloud_organization_branding.test_branding", "helpdesk_label", "IT Helpdesk"),
+				),
+			},
+			{
+				Config: testAccOrganizationBranding("#000000"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_organization_branding.test_branding",
+					"primary_color", "#000000"),
+			},
+		},
+	})
+}
+
+func testAccOrganizationBranding(primaryColor string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_organization_branding" "test_branding" {
+			primary_color   = "%s"
+			secondary_color = "#FFFFFF"
+			helpdesk_url    = "https://helpdesk.testorg.com"
+			helpdesk_label  = "IT Helpdesk"
+		}`, primaryColor,
+	)
+}
+
+func TestValidateHexColor(t *testing.T) {
+	cases := []struct {
+		Value string
+		Valid bool
+	}{
+		{"#1A2B3C", true},
+		{"#abcdef", true},
+		{"1A2B3C", false},
+		{"#1A2B3", false},
+		{"#1A2B3G", false},
+		{"#1A2B3C4D", false},
+	}
+
+	for _, c := range cases {
+		_, errs := validateHexColor(c.Value, "primary_color")
+		assert.Equal(t, c.Valid, len(errs) == 0, c.Value)
+	}
+}
+
+func TestOrganizationBrandingLogoFile(t *testing.T) {
+	var uploaded []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/branding", r.URL.Path)
+		rw.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			var branding OrganizationBranding
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&branding))
+			logo, err := base64.StdEncoding.DecodeString(branding.Logo)
+			assert.NoError(t, err)
+			uploaded = append(uploaded, string(logo))
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(OrganizationBranding{}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceOrganizationBranding()
+
+	path := filepath.Join(t.TempDir(), "logo.png")
+	assert.NoError(t, os.WriteFile(path, []byte("logo"), 0600))
+	raw := map[string]interface{}{"logo_file_path": path}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, []string{"logo"}, uploaded)
+	// echo -n logo | sha256sum
+	assert.Equal(t, "3598ce6f965b2481fe26316c06b30950c46ac7f8e7229f104aa78f579997668d", d.Get("logo_sha256"))
+
+	// the file is unchanged
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// the contents of the file changed, but not its path
+	assert.NoError(t, os.WriteFile(path, []byte("new logo"), 0600))
+	diff, err = r.Diff(d.State(), terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+	assert.Contains(t, diff.Attributes, "logo_sha256")
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"logo", "new logo"}, uploaded)
+	assert.NotEqual(t, d.Get("logo_sha256"), r.Data(state).Get("logo_sha256"))
+
+	// the file is gone
+	assert.NoError(t, os.Remove(path))
+	_, err = r.Diff(r.Data(state).State(), terraform.NewResourceConfigRaw(raw), config)
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourcePasswordManagerSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing the organization settings of JumpCloud Password Manager. " +
+			"The settings always exist, destroying the resource only removes it from the state.",
+		Create: resourcePasswordManagerSettingsUpdate,
+		Read:   resourcePasswordManagerSettingsRead,
+		Update: resourcePasswordManagerSettingsUpdate,
+		Delete: resourcePasswordManagerSettingsDelete,
+		Schema: map[string]*schema.Schema{
+			"auto_lock_timeout_minutes": {
+				Description:  "The number of idle minutes after which the vault is locked.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"clipboard_clear_seconds": {
+				Description:  "The number of seconds after which copied passwords are cleared from the clipboard, `0` disables clearing.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"require_master_password_for_view": {
+				Description: "Whether the master password must be entered again to view a password.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"allow_browser_save": {
+				Description: "Whether the browser extension offers to save new credentials.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"enforce_strong_master_password": {
+				Description: "Whether master passwords must meet the strong password requirements.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func expandPasswordManagerSettings(d *schema.ResourceData) PasswordManagerSettings {
+	settings := PasswordManagerSettings{}
+	if v, ok := d.GetOkExists("auto_lock_timeout_minutes"); ok {
+		i := v.(int)
+		settings.AutoLockTimeoutMinutes = &i
+	}
+	if v, ok := d.GetOkExists("clipboard_clear_seconds"); ok {
+		i := v.(int)
+		settings.ClipboardClearSeconds = &i
+	}
+	if v, ok := d.GetOkExists("require_master_password_for_view"); ok {
+		b := v.(bool)
+		settings.RequireMasterPasswordForView = &b
+	}
+	if v, ok := d.GetOkExists("allow_browser_save"); ok {
+		b := v.(bool)
+		settings.AllowBrowserSave = &b
+	}
+	if v, ok := d.GetOkExists("enforce_strong_master_password"); ok {
+		b := v.(bool)
+		settings.EnforceStrongMasterPassword = &b
+	}
+	return settings
+}
+
+func resourcePasswordManagerSettingsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var settings PasswordManagerSettings
+	_, err := jumpCloudRequest(config, http.MethodGet, "/passwordmanager/settings", nil, &settings)
+	if err != nil {
+		return err
+	}
+
+	if settings.AutoLockTimeoutMinutes != nil {
+		if err := d.Set("auto_lock_timeout_minutes", *settings.AutoLockTimeoutMinutes); err != nil {
+			return err
+		}
+	}
+	if settings.ClipboardClearSeconds != nil {
+		if err := d.Set("clipboard_clear_seconds", *settings.ClipboardClearSeconds); err != nil {
+			return err
+		}
+	}
+	if settings.RequireMasterPasswordForView != nil {
+		if err := d.Set("require_master_password_for_view", *settings.RequireMasterPasswordForView); err != nil {
+			return err
+		}
+	}
+	if settings.AllowBrowserSave != nil {
+		if err := d.Set("allow_browser_save", *settings.AllowBrowserSave); err != nil {
+			return err
+		}
+	}
+	if settings.EnforceStrongMasterPassword != nil {
+		if err := d.Set("enforce_strong_master_password", *settings.EnforceStrongMasterPassword); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourcePasswordManagerSettingsUpdate is also used on create, the
+// settings exist for every organization
+func resourcePasswordManagerSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodPatch, "/passwordmanager/settings",
+		expandPasswordManagerSettings(d), nil)
+	if err != nil {
+		return fmt.Errorf("error updating password manager settings: %s", err)
+	}
+
+	d.SetId("password_manager_settings")
+	return resourcePasswordManagerSettingsRead(d, m)
+}
+
+func resourcePasswordManagerSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	// the settings can't be removed, they are left as they are
+	d.SetId("")
+	return nil
+}
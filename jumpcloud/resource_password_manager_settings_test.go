@@ -0,0 +1,42 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccPasswordManagerSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPasswordManagerSettings(15, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_password_manager_settings.test_settings", "auto_lock_timeout_minutes", "15"),
+					resource.TestCheckResourceAttr("jumpcloud_password_manager_settings.test_settings", "allow_browser_save", "true"),
+				),
+			},
+			{
+				Config: testAccPasswordManagerSettings(30, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_password_manager_settings.test_settings", "auto_lock_timeout_minutes", "30"),
+					resource.TestCheckResourceAttr("jumpcloud_password_manager_settings.test_settings", "allow_browser_save", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPasswordManagerSettings(timeout int, allowBrowserSave bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_password_manager_settings" "test_settings" {
+			auto_lock_timeout_minutes = %d
+			clipboard_clear_seconds   = 30
+			allow_browser_save        = %t
+		}`, timeout, allowBrowserSave,
+	)
+}
@@ -0,0 +1,204 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourcePasswordPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing the password complexity requirements of the organization. " +
+			"The policy always exists, destroying the resource only removes it from the state.",
+		Create: resourcePasswordPolicyCreate,
+		Read:   resourcePasswordPolicyRead,
+		Update: resourcePasswordPolicyUpdate,
+		Delete: resourcePasswordPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"min_length": {
+				Description:  "The minimum length of passwords, between 8 and 64.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(8, 64),
+			},
+			"requires_uppercase": {
+				Description: "Whether passwords must contain an uppercase letter.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"requires_lowercase": {
+				Description: "Whether passwords must contain a lowercase letter.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"requires_number": {
+				Description: "Whether passwords must contain a number.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"requires_special": {
+				Description: "Whether passwords must contain a special character.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"password_expires_days": {
+				Description:  "The number of days after which passwords expire, up to 365. `0` disables expiration.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 365),
+			},
+			"max_history": {
+				Description:  "The number of previous passwords that can't be reused, up to 24. `0` allows reuse.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(0, 24),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// expandPasswordPolicy sets the configured requirements on policy, the
+// password policy of the organization as returned by the API. Requirements
+// that aren't configured and fields not managed by the resource, e.g. the
+// lockout settings, are left as they are.
+func expandPasswordPolicy(d *schema.ResourceData, policy map[string]interface{}) {
+	if v, ok := d.GetOkExists("min_length"); ok {
+		policy["enableMinLength"] = true
+		policy["minLength"] = v.(int)
+	}
+	if v, ok := d.GetOkExists("requires_uppercase"); ok {
+		policy["needsUppercase"] = v.(bool)
+	}
+	if v, ok := d.GetOkExists("requires_lowercase"); ok {
+		policy["needsLowercase"] = v.(bool)
+	}
+	if v, ok := d.GetOkExists("requires_number"); ok {
+		policy["needsNumeric"] = v.(bool)
+	}
+	if v, ok := d.GetOkExists("requires_special"); ok {
+		policy["needsSymbolic"] = v.(bool)
+	}
+	if v, ok := d.GetOkExists("password_expires_days"); ok {
+		policy["enablePasswordExpirationInDays"] = v.(int) > 0
+		if v.(int) > 0 {
+			policy["passwordExpirationInDays"] = v.(int)
+		}
+	}
+	if v, ok := d.GetOkExists("max_history"); ok {
+		policy["enableMaxHistory"] = v.(int) > 0
+		if v.(int) > 0 {
+			policy["maxHistory"] = v.(int)
+		}
+	}
+}
+
+func resourcePasswordPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	orgID, err := getOrganizationID(config)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(orgID)
+	return resourcePasswordPolicyUpdate(d, m)
+}
+
+func resourcePasswordPolicyRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var org Organization
+	ok, err := jumpCloudV1Request(config, http.MethodGet, "/organizations/"+d.Id(), nil, &org)
+	if err != nil {
+		return fmt.Errorf("error reading the password policy of organization %s: %w", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	// limits that aren't enabled are read as 0, configuring them shows up
+	// as a change
+	policy := org.Settings.PasswordPolicy
+	minLength := 0
+	if policy.EnableMinLength {
+		minLength = policy.MinLength
+	}
+	if err := d.Set("min_length", minLength); err != nil {
+		return err
+	}
+	if err := d.Set("requires_uppercase", policy.NeedsUppercase); err != nil {
+		return err
+	}
+	if err := d.Set("requires_lowercase", policy.NeedsLowercase); err != nil {
+		return err
+	}
+	if err := d.Set("requires_number", policy.NeedsNumeric); err != nil {
+		return err
+	}
+	if err := d.Set("requires_special", policy.NeedsSymbolic); err != nil {
+		return err
+	}
+	expiresDays := 0
+	if policy.EnablePasswordExpirationInDays {
+		expiresDays = policy.PasswordExpirationInDays
+	}
+	if err := d.Set("password_expires_days", expiresDays); err != nil {
+		return err
+	}
+	maxHistory := 0
+	if policy.EnableMaxHistory {
+		maxHistory = policy.MaxHistory
+	}
+	if err := d.Set("max_history", maxHistory); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resourcePasswordPolicyUpdate is also used on create, the policy exists
+// for every organization. The settings are read first as the API replaces
+// them as a whole.
+func resourcePasswordPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var org struct {
+		Settings map[string]interface{} `json:"settings"`
+	}
+	if _, err := jumpCloudV1Request(config, http.MethodGet, "/organizations/"+d.Id(), nil, &org); err != nil {
+		return fmt.Errorf("error reading the settings of organization %s: %w", d.Id(), err)
+	}
+	if org.Settings == nil {
+		org.Settings = map[string]interface{}{}
+	}
+	policy, _ := org.Settings["passwordPolicy"].(map[string]interface{})
+	if policy == nil {
+		policy = map[string]interface{}{}
+	}
+	expandPasswordPolicy(d, policy)
+	org.Settings["passwordPolicy"] = policy
+
+	if _, err := jumpCloudV1Request(config, http.MethodPut, "/organizations/"+d.Id(), org, nil); err != nil {
+		return fmt.Errorf("error updating the password policy of organization %s: %w", d.Id(), err)
+	}
+	return resourcePasswordPolicyRead(d, m)
+}
+
+func resourcePasswordPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	// the policy can't be removed, it is left as it is
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,119 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccPasswordPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPasswordPolicy(4, 90),
+				ExpectError: regexp.MustCompile(`expected min_length to be in the range \(8 - 64\)`),
+			},
+			{
+				Config: testAccPasswordPolicy(12, 90),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_password_policy.test_policy", "min_length", "12"),
+					resource.TestCheckResourceAttr("jumpcloud_password_policy.test_policy", "password_expires_days", "90"),
+				),
+			},
+			{
+				Config: testAccPasswordPolicy(14, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_password_policy.test_policy", "min_length", "14"),
+					resource.TestCheckResourceAttr("jumpcloud_password_policy.test_policy", "password_expires_days", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPasswordPolicy(minLength, expiresDays int) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_password_policy" "test_policy" {
+			min_length            = %d
+			requires_uppercase    = true
+			requires_number       = true
+			password_expires_days = %d
+			max_history           = 5
+		}`, minLength, expiresDays,
+	)
+}
+
+func TestPasswordPolicyUpdate(t *testing.T) {
+	settings := map[string]interface{}{
+		"passwordPolicy": map[string]interface{}{
+			"enableMaxLoginAttempts": true,
+			"maxLoginAttempts":       float64(5),
+		},
+		"systemUsersCanEdit": true,
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organizations/org", r.URL.Path)
+		if r.Method == http.MethodPut {
+			var org struct {
+				Settings map[string]interface{} `json:"settings"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&org))
+			settings = org.Settings
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(map[string]interface{}{
+			"_id":      "org",
+			"settings": settings,
+		}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/v2"
+	config.DefaultHeader["x-org-id"] = "org"
+	r := resourcePasswordPolicy()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"min_length":            12,
+		"requires_uppercase":    true,
+		"password_expires_days": 90,
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "org", d.Id())
+	assert.Equal(t, 12, d.Get("min_length"))
+	assert.Equal(t, 90, d.Get("password_expires_days"))
+	assert.Equal(t, 0, d.Get("max_history"))
+
+	// disable expiration and limit reuse
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"min_length":            12,
+		"requires_uppercase":    true,
+		"password_expires_days": 0,
+		"max_history":           5,
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	d = r.Data(state)
+	assert.Equal(t, 0, d.Get("password_expires_days"))
+	assert.Equal(t, 5, d.Get("max_history"))
+
+	// settings not managed by the resource are kept
+	policy := settings["passwordPolicy"].(map[string]interface{})
+	assert.Equal(t, true, settings["systemUsersCanEdit"])
+	assert.Equal(t, float64(5), policy["maxLoginAttempts"])
+	assert.Equal(t, false, policy["enablePasswordExpirationInDays"])
+	assert.Equal(t, true, policy["enableMaxHistory"])
+	assert.Equal(t, true, policy["needsUppercase"])
+}
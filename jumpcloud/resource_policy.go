@@ -0,0 +1,306 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourcePolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a JumpCloud policy, e.g. a screen lock or password " +
+			"complexity policy applied to devices.",
+		Create:        resourcePolicyCreate,
+		Read:          resourcePolicyRead,
+		Update:        resourcePolicyUpdate,
+		Delete:        resourcePolicyDelete,
+		CustomizeDiff: policyValuesDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the policy.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"template_id": {
+				Description: "The ID of the policy template the policy is based on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"values": {
+				Description: "The values of the config fields of the template, by field name. Checkbox fields take " +
+					"`true` or `false`, number fields whole numbers and all others strings.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: policyImporter,
+		},
+	}
+}
+
+// getPolicyTemplateFields returns the config fields of the policy template
+func getPolicyTemplateFields(config *Meta, templateID string) ([]jcapiv2.PolicyTemplateConfigField, error) {
+	var template jcapiv2.PolicyTemplateWithDetails
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/policytemplates/"+templateID, nil, &template)
+	if err != nil {
+		return nil, fmt.Errorf("error reading policy template %s: %s", templateID, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("policy template %s not found", templateID)
+	}
+	return template.ConfigFields, nil
+}
+
+// convertPolicyValue converts the string value of a config field to the
+// type the field's display type asks for
+func convertPolicyValue(field jcapiv2.PolicyTemplateConfigField, value string) (interface{}, error) {
+	switch field.DisplayType {
+	case "checkbox":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("value %q of %s must be true or false", value, field.Name)
+		}
+		return b, nil
+	case "number":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %q of %s must be a whole number", value, field.Name)
+		}
+		return i, nil
+	default:
+		return value, nil
+	}
+}
+
+// expandPolicyValues validates values against the config fields of the
+// policy's template and converts them into the values of the API
+func expandPolicyValues(fields []jcapiv2.PolicyTemplateConfigField, values map[string]interface{}) ([]PolicyValue, error) {
+	byName := map[string]jcapiv2.PolicyTemplateConfigField{}
+	names := []string{}
+	for _, field := range fields {
+		byName[field.Name] = field
+		names = append(names, field.Name)
+	}
+	sort.Strings(names)
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := []PolicyValue{}
+	for _, k := range keys {
+		field, ok := byName[k]
+		if !ok {
+			return nil, fmt.Errorf("the policy template has no config field %s, known fields: %s",
+				k, strings.Join(names, ", "))
+		}
+		if field.ReadOnly {
+			return nil, fmt.Errorf("config field %s of the policy template is read-only", k)
+		}
+		value, err := convertPolicyValue(field, values[k].(string))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, PolicyValue{ConfigFieldID: field.Id, Value: value})
+	}
+
+	for _, name := range names {
+		if _, ok := values[name]; !ok && byName[name].Required && !byName[name].ReadOnly {
+			return nil, fmt.Errorf("config field %s of the policy template is required", name)
+		}
+	}
+	return out, nil
+}
+
+// formatPolicyValue is the inverse of convertPolicyValue
+func formatPolicyValue(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// flattenPolicyValues maps the values of a policy to the names of their
+// config fields. Only the fields in managed are kept, so defaults of the
+// template don't show up as drift.
+func flattenPolicyValues(fields []jcapiv2.PolicyTemplateConfigField, values []PolicyValue,
+	managed map[string]interface{}) map[string]interface{} {
+	names := map[string]string{}
+	for _, field := range fields {
+		names[field.Id] = field.Name
+	}
+
+	out := map[string]interface{}{}
+	for _, v := range values {
+		name, ok := names[v.ConfigFieldID]
+		if !ok {
+			continue
+		}
+		if _, ok := managed[name]; !ok {
+			continue
+		}
+		out[name] = formatPolicyValue(v.Value)
+	}
+	return out
+}
+
+// policyValuesDiff validates the values against the template at plan time
+func policyValuesDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("values") && !d.HasChange("template_id") {
+		return nil
+	}
+	if !d.NewValueKnown("values") || !d.NewValueKnown("template_id") {
+		return nil
+	}
+
+	fields, err := getPolicyTemplateFields(m.(*Meta), d.Get("template_id").(string))
+	if err != nil {
+		return err
+	}
+	_, err = expandPolicyValues(fields, d.Get("values").(map[string]interface{}))
+	return err
+}
+
+func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	templateID := d.Get("template_id").(string)
+
+	fields, err := getPolicyTemplateFields(config, templateID)
+	if err != nil {
+		return err
+	}
+	values, err := expandPolicyValues(fields, d.Get("values").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	// jcapiv2.PolicyValue lacks the value, so the policy is created through
+	// the HTTP API directly
+	body := PolicyRequest{
+		Name:     d.Get("name").(string),
+		Template: &jcapiv2.PolicyRequestTemplate{Id: templateID},
+		Values:   values,
+	}
+	var policy PolicyWithDetails
+	if _, err := jumpCloudRequest(config, http.MethodPost, "/policies", body, &policy); err != nil {
+		return fmt.Errorf("error creating policy %s: %s", body.Name, err)
+	}
+
+	d.SetId(policy.ID)
+	return resourcePolicyRead(d, m)
+}
+
+// getPolicy reads the policy along with the config fields of its
+// template. ok is false if the policy doesn't exist.
+func getPolicy(config *Meta, id string) (policy *PolicyWithDetails,
+	fields []jcapiv2.PolicyTemplateConfigField, ok bool, err error) {
+
+	ok, err = jumpCloudRequest(config, http.MethodGet, "/policies/"+id, nil, &policy)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("error reading policy %s: %s", id, err)
+	}
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	fields = policy.ConfigFields
+	if policy.Template != nil && len(fields) == 0 {
+		if fields, err = getPolicyTemplateFields(config, policy.Template.Id); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	return policy, fields, true, nil
+}
+
+// policyImporter imports all values of the policy, including the
+// template's defaults, as there are no configured ones to limit them to
+func policyImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	policy, fields, ok, err := getPolicy(m.(*Meta), d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("policy %s not found", d.Id())
+	}
+
+	all := map[string]interface{}{}
+	for _, field := range fields {
+		all[field.Name] = ""
+	}
+	if err := d.Set("values", flattenPolicyValues(fields, policy.Values, all)); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
+	policy, fields, ok, err := getPolicy(m.(*Meta), d.Id())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return err
+	}
+	if policy.Template != nil {
+		if err := d.Set("template_id", policy.Template.Id); err != nil {
+			return err
+		}
+	}
+	return d.Set("values", flattenPolicyValues(fields, policy.Values, d.Get("values").(map[string]interface{})))
+}
+
+func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	fields, err := getPolicyTemplateFields(config, d.Get("template_id").(string))
+	if err != nil {
+		return err
+	}
+	values, err := expandPolicyValues(fields, d.Get("values").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	body := PolicyRequest{
+		Name:   d.Get("name").(string),
+		Values: values,
+	}
+	if _, err := jumpCloudRequest(config, http.MethodPut, "/policies/"+d.Id(), body, nil); err != nil {
+		return fmt.Errorf("error updating policy %s: %s", d.Id(), err)
+	}
+	return resourcePolicyRead(d, m)
+}
+
+func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudRequest(config, http.MethodDelete, "/policies/"+d.Id(), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting policy %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
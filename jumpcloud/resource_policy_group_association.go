@@ -0,0 +1,44 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourcePolicyGroupAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Applies a JumpCloud policy to system groups. System groups the policy is applied to " +
+			"outside of Terraform are left alone.",
+		Create: policyGroupAssociation.create,
+		Read:   policyGroupAssociation.read,
+		Update: policyGroupAssociation.update,
+		Delete: policyGroupAssociation.delete,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Description: "The ID of the policy.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"system_group_ids": {
+				Description: "The IDs of the system groups.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: policyGroupAssociation.importer,
+		},
+	}
+}
+
+var policyGroupAssociation = &graphAssociation{
+	path:            "/policies/%s/associations",
+	sourceAttribute: "policy_id",
+	sourceName:      "policy",
+	targets: map[string]string{
+		"system_group_ids": "system_group",
+	},
+}
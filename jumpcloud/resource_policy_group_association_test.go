@@ -0,0 +1,159 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccPolicyGroupAssociation(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	templateID := os.Getenv("JUMPCLOUD_POLICY_TEMPLATE_ID")
+	fullResourceName := "jumpcloud_policy_group_association.test_association"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if templateID == "" {
+				t.Skip("JUMPCLOUD_POLICY_TEMPLATE_ID must be set to a template without required config fields " +
+					"for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyGroupAssociation(rName, templateID, "first", "second"),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "system_group_ids.#", "2"),
+			},
+			{
+				Config: testAccPolicyGroupAssociation(rName, templateID, "first"),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "system_group_ids.#", "1"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: policyGroupAssociationImportID(fullResourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPolicyGroupAssociation(name, templateID string, groups ...string) string {
+	groupIDs := ""
+	for _, group := range groups {
+		groupIDs += fmt.Sprintf("jumpcloud_system_group.%s.jc_id, ", group)
+	}
+	return fmt.Sprintf(`
+		resource "jumpcloud_policy" "test_policy" {
+			name        = "%[1]s"
+			template_id = "%[2]s"
+		}
+
+		resource "jumpcloud_system_group" "first" {
+			name = "%[1]s_first"
+		}
+
+		resource "jumpcloud_system_group" "second" {
+			name = "%[1]s_second"
+		}
+
+		resource "jumpcloud_policy_group_association" "test_association" {
+			policy_id        = jumpcloud_policy.test_policy.id
+			system_group_ids = [%[3]s]
+		}`, name, templateID, groupIDs,
+	)
+}
+
+// policyGroupAssociationImportID builds the policy_id:system_group_id
+// import ID of the association from its state
+func policyGroupAssociationImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", name)
+		}
+
+		groupIDs := []string{}
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "system_group_ids.") && k != "system_group_ids.#" {
+				groupIDs = append(groupIDs, v)
+			}
+		}
+		return rs.Primary.ID + ":" + strings.Join(groupIDs, ","), nil
+	}
+}
+
+func TestPolicyGroupAssociationReconcile(t *testing.T) {
+	bound := map[string]bool{"other": true}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/policies/policy/associations", r.URL.Path)
+		if r.Method == http.MethodPost {
+			var req jcapiv2.GraphManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, jcapiv2.GraphType("system_group"), *req.Type_)
+			if req.Op == "add" {
+				bound[req.Id] = true
+			} else {
+				delete(bound, req.Id)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		connections := []jcapiv2.GraphConnection{}
+		for id := range bound {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	boundIDs := func() []string {
+		ids := []string{}
+		for id := range bound {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourcePolicyGroupAssociation()
+
+	// bind two groups
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id":        "policy",
+		"system_group_ids": []interface{}{"a", "b"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, []string{"a", "b", "other"}, boundIDs())
+
+	// remove one of them
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"policy_id":        "policy",
+		"system_group_ids": []interface{}{"a"},
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "other"}, boundIDs())
+	d = r.Data(state)
+
+	// unbound outside of Terraform
+	delete(bound, "a")
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, d.Get("system_group_ids").(*schema.Set).List())
+}
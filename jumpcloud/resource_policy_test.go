@@ -0,0 +1,158 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccPolicy(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	templateID := os.Getenv("JUMPCLOUD_POLICY_TEMPLATE_ID")
+	fullResourceName := "jumpcloud_policy.test_policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if templateID == "" {
+				t.Skip("JUMPCLOUD_POLICY_TEMPLATE_ID must be set to a template without required config fields " +
+					"for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicy(rName, templateID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "name", rName),
+					resource.TestCheckResourceAttr(fullResourceName, "template_id", templateID),
+				),
+			},
+			{
+				Config: testAccPolicy(rName+"_renamed", templateID),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "name", rName+"_renamed"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the defaults of the template are imported as well
+				ImportStateVerifyIgnore: []string{"values"},
+			},
+		},
+	})
+}
+
+func testAccPolicy(name, templateID string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_policy" "test_policy" {
+			name        = "%s"
+			template_id = "%s"
+		}`, name, templateID,
+	)
+}
+
+// lockScreenFields are the config fields of a lock screen policy template
+var lockScreenFields = []jcapiv2.PolicyTemplateConfigField{
+	{Id: "f1", Name: "timeout", DisplayType: "number", Required: true},
+	{Id: "f2", Name: "requirePassword", DisplayType: "checkbox"},
+	{Id: "f3", Name: "message", DisplayType: "text"},
+	{Id: "f4", Name: "managedBy", DisplayType: "text", ReadOnly: true, Required: true},
+}
+
+func TestExpandPolicyValues(t *testing.T) {
+	values, err := expandPolicyValues(lockScreenFields, map[string]interface{}{
+		"timeout":         "300",
+		"requirePassword": "true",
+		"message":         "locked",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []PolicyValue{
+		{ConfigFieldID: "f3", Value: "locked"},
+		{ConfigFieldID: "f2", Value: true},
+		{ConfigFieldID: "f1", Value: int64(300)},
+	}, values)
+
+	for name, values := range map[string]map[string]interface{}{
+		"unknown":   {"timeout": "1", "unknown": "x"},
+		"bool":      {"timeout": "1", "requirePassword": "yes please"},
+		"number":    {"timeout": "5 minutes"},
+		"required":  {"message": "locked"},
+		"read-only": {"timeout": "1", "managedBy": "me"},
+	} {
+		_, err := expandPolicyValues(lockScreenFields, values)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestFlattenPolicyValues(t *testing.T) {
+	// values as decoded from JSON
+	values := []PolicyValue{
+		{ConfigFieldID: "f1", Value: float64(300)},
+		{ConfigFieldID: "f2", Value: true},
+		{ConfigFieldID: "f3", Value: "locked"},
+		{ConfigFieldID: "gone", Value: "x"},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"timeout":         "300",
+		"requirePassword": "true",
+		"message":         "locked",
+	}, flattenPolicyValues(lockScreenFields, values, map[string]interface{}{
+		"timeout": "", "requirePassword": "", "message": "",
+	}))
+
+	// nothing is kept without managed values, e.g. for a policy configured
+	// without any
+	assert.Empty(t, flattenPolicyValues(lockScreenFields, values, nil))
+	assert.Empty(t, flattenPolicyValues(lockScreenFields, values, map[string]interface{}{}))
+
+	// only the managed values are kept
+	assert.Equal(t, map[string]interface{}{"timeout": "300"},
+		flattenPolicyValues(lockScreenFields, values, map[string]interface{}{"timeout": "600"}))
+}
+
+func TestPolicyImportAndRead(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/policies/id", r.URL.Path)
+		rw.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(rw).Encode(PolicyWithDetails{
+			ID:           "id",
+			Name:         "lock screen",
+			Template:     &jcapiv2.PolicyTemplate{Id: "template"},
+			ConfigFields: lockScreenFields,
+			Values: []PolicyValue{
+				{ConfigFieldID: "f1", Value: float64(300)},
+				{ConfigFieldID: "f2", Value: true},
+			},
+		}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourcePolicy()
+
+	// all values are imported
+	d := r.TestResourceData()
+	d.SetId("id")
+	imported, err := policyImporter(d, config)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Read(imported[0], config))
+	assert.Equal(t, map[string]interface{}{"timeout": "300", "requirePassword": "true"}, imported[0].Get("values"))
+
+	// a policy configured without values has none in state
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "lock screen", "template_id": "template"})
+	d.SetId("id")
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, d.Get("values"))
+}
@@ -0,0 +1,141 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceProviderBinding() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for binding an administrator of a JumpCloud MSP provider to a child organization.",
+		Create:      resourceProviderBindingCreate,
+		Read:        resourceProviderBindingRead,
+		Update:      resourceProviderBindingUpdate,
+		Delete:      resourceProviderBindingDelete,
+		Schema: map[string]*schema.Schema{
+			"provider_id": {
+				Description: "The ID of the MSP provider.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"organization_id": {
+				Description: "The ID of the child organization.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"admin_user_id": {
+				Description: "The ID of the provider administrator.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"permissions": {
+				Description: "The permissions granted to the administrator in the child organization.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: providerBindingImporter,
+		},
+	}
+}
+
+func providerBindingImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.Split(d.Id(), "/")
+	if len(ids) != 3 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'provider_id/organization_id/admin_user_id'")
+	}
+
+	_ = d.Set("provider_id", ids[0])
+	_ = d.Set("organization_id", ids[1])
+	_ = d.Set("admin_user_id", ids[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func providerBindingPath(d *schema.ResourceData) string {
+	return "/providers/" + d.Get("provider_id").(string) +
+		"/organizations/" + d.Get("organization_id").(string) +
+		"/administrators/" + d.Get("admin_user_id").(string)
+}
+
+func putProviderBinding(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	permissions := interfacesToStrings(d.Get("permissions").(*schema.Set).List())
+	_, err := jumpCloudRequest(config, http.MethodPut, providerBindingPath(d),
+		ProviderBinding{Permissions: permissions}, nil)
+	if err != nil {
+		return fmt.Errorf("error binding administrator %s to organization %s: %s",
+			d.Get("admin_user_id"), d.Get("organization_id"), err)
+	}
+	return nil
+}
+
+func resourceProviderBindingCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putProviderBinding(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("provider_id").(string) + "/" + d.Get("organization_id").(string) +
+		"/" + d.Get("admin_user_id").(string))
+	return resourceProviderBindingRead(d, m)
+}
+
+func resourceProviderBindingRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var binding ProviderBinding
+	ok, err := jumpCloudRequest(config, http.MethodGet, providerBindingPath(d), nil, &binding)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// the binding or the whole child organization is gone
+		log.Printf("[WARN] provider binding %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("permissions", binding.Permissions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceProviderBindingUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putProviderBinding(d, m); err != nil {
+		return err
+	}
+	return resourceProviderBindingRead(d, m)
+}
+
+func resourceProviderBindingDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	// a binding of a deleted child organization reports 404,
+	// which is as good as a successful revocation
+	_, err := jumpCloudRequest(config, http.MethodDelete, providerBindingPath(d), nil, nil)
+	if isNotFound(err) {
+		log.Printf("[WARN] provider binding %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error revoking provider binding %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,79 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccProviderBinding(t *testing.T) {
+	providerID := os.Getenv("JUMPCLOUD_PROVIDER_ID")
+	orgID := os.Getenv("JUMPCLOUD_CHILD_ORG_ID")
+	adminID := os.Getenv("JUMPCLOUD_PROVIDER_ADMIN_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if providerID == "" || orgID == "" || adminID == "" {
+				t.Skip("JUMPCLOUD_PROVIDER_ID, JUMPCLOUD_CHILD_ORG_ID and JUMPCLOUD_PROVIDER_ADMIN_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderBinding(providerID, orgID, adminID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_provider_binding.test_binding", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("jumpcloud_provider_binding.test_binding",
+						fmt.Sprintf("permissions.%d", schema.HashString("read")), "read"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProviderBinding(providerID, orgID, adminID string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_provider_binding" "test_binding" {
+			provider_id     = "%s"
+			organization_id = "%s"
+			admin_user_id   = "%s"
+			permissions     = ["read"]
+		}`, providerID, orgID, adminID,
+	)
+}
+
+func TestProviderBindingChildOrgDeleted(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/providers/provider/organizations/org/administrators/admin", r.URL.Path)
+		// the child organization is gone along with its bindings
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceProviderBinding()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"provider_id":     "provider",
+		"organization_id": "org",
+		"admin_user_id":   "admin",
+		"permissions":     []interface{}{"read"},
+	})
+	d.SetId("provider/org/admin")
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, d.Id())
+
+	d.SetId("provider/org/admin")
+	assert.NoError(t, r.Delete(d, config))
+	assert.Empty(t, d.Id())
+}
@@ -0,0 +1,151 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceRadiusServer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a JumpCloud RADIUS server, which authenticates the users of " +
+			"a network against JumpCloud.",
+		Create: resourceRadiusServerCreate,
+		Read:   resourceRadiusServerRead,
+		Update: resourceRadiusServerUpdate,
+		Delete: resourceRadiusServerDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the RADIUS server.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"network_source_ip": {
+				Description:  "The public IP address the RADIUS requests of the network come from.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"shared_secret": {
+				Description:  "The secret shared by JumpCloud and the RADIUS clients of the network.",
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"mfa_required": {
+				Description: "Whether users have to complete MFA to authenticate.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"user_password_expiration_action": {
+				Description: "Whether users with an expired password may still authenticate. " +
+					"Possible values: `allow`, `deny`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "allow",
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// radiusServerMFA maps mfa_required to the mfa setting of the API
+func radiusServerMFA(required bool) string {
+	if required {
+		return "REQUIRED"
+	}
+	return "DISABLED"
+}
+
+// expandRadiusServer builds the RADIUS server of the API from d.
+// jcapiv1.Radiusserverput can't carry the shared secret, so the server is
+// also updated with the HTTP API directly.
+func expandRadiusServer(d *schema.ResourceData) jcapiv1.Radiusserver {
+	return jcapiv1.Radiusserver{
+		Name:                         d.Get("name").(string),
+		NetworkSourceIp:              d.Get("network_source_ip").(string),
+		SharedSecret:                 d.Get("shared_secret").(string),
+		Mfa:                          radiusServerMFA(d.Get("mfa_required").(bool)),
+		UserPasswordExpirationAction: d.Get("user_password_expiration_action").(string),
+	}
+}
+
+func resourceRadiusServerCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandRadiusServer(d)
+	var server jcapiv1.Radiusserver
+	if _, err := jumpCloudV1Request(config, http.MethodPost, "/radiusservers", body, &server); err != nil {
+		// the error doesn't include the request, so the secret isn't leaked
+		return fmt.Errorf("error creating RADIUS server %s: %s", body.Name, err)
+	}
+
+	d.SetId(server.Id)
+	return resourceRadiusServerRead(d, m)
+}
+
+func resourceRadiusServerRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var server jcapiv1.Radiusserver
+	ok, err := jumpCloudV1Request(config, http.MethodGet, "/radiusservers/"+d.Id(), nil, &server)
+	if err != nil {
+		return fmt.Errorf("error reading RADIUS server %s: %s", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", server.Name); err != nil {
+		return err
+	}
+	if err := d.Set("network_source_ip", server.NetworkSourceIp); err != nil {
+		return err
+	}
+	// the secret is kept in state if the API doesn't return it
+	if server.SharedSecret != "" {
+		if err := d.Set("shared_secret", server.SharedSecret); err != nil {
+			return err
+		}
+	}
+	// "ENABLED" only asks enrolled users for MFA, so it's not required
+	if err := d.Set("mfa_required", server.Mfa == "REQUIRED" || server.Mfa == "ALWAYS"); err != nil {
+		return err
+	}
+	if server.UserPasswordExpirationAction != "" {
+		if err := d.Set("user_password_expiration_action", server.UserPasswordExpirationAction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceRadiusServerUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandRadiusServer(d)
+	if _, err := jumpCloudV1Request(config, http.MethodPut, "/radiusservers/"+d.Id(), body, nil); err != nil {
+		return fmt.Errorf("error updating RADIUS server %s: %s", d.Id(), err)
+	}
+	return resourceRadiusServerRead(d, m)
+}
+
+func resourceRadiusServerDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudV1Request(config, http.MethodDelete, "/radiusservers/"+d.Id(), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting RADIUS server %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
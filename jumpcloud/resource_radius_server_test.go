@@ -0,0 +1,100 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccRadiusServer(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	firstSecret := acctest.RandString(24)
+	secondSecret := acctest.RandString(24)
+	fullResourceName := "jumpcloud_radius_server.test_server"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadiusServer(rName, firstSecret),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "name", rName),
+					resource.TestCheckResourceAttr(fullResourceName, "shared_secret", firstSecret),
+					resource.TestCheckResourceAttr(fullResourceName, "mfa_required", "true"),
+					resource.TestCheckResourceAttr(fullResourceName, "user_password_expiration_action", "deny"),
+				),
+			},
+			{ // rotate the shared secret
+				Config: testAccRadiusServer(rName, secondSecret),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "shared_secret", secondSecret),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccRadiusServer(name, secret string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_radius_server" "test_server" {
+			name                            = "%s"
+			network_source_ip               = "203.0.113.%d"
+			shared_secret                   = "%s"
+			mfa_required                    = true
+			user_password_expiration_action = "deny"
+		}`, name, acctest.RandIntRange(1, 255), secret,
+	)
+}
+
+func TestRadiusServerSecretRotation(t *testing.T) {
+	var server jcapiv1.Radiusserver
+	var puts int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&server))
+			server.Id = "radius"
+		case http.MethodPut:
+			assert.Equal(t, "/api/radiusservers/radius", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&server))
+			puts++
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(server))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceRadiusServer()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":              "office",
+		"network_source_ip": "203.0.113.1",
+		"shared_secret":     "first",
+		"mfa_required":      true,
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "radius", d.Id())
+	assert.Equal(t, "REQUIRED", server.Mfa)
+	assert.Equal(t, "allow", server.UserPasswordExpirationAction)
+
+	assert.NoError(t, d.Set("shared_secret", "second"))
+	assert.NoError(t, r.Update(d, config))
+	assert.Equal(t, 1, puts)
+	assert.Equal(t, "second", server.SharedSecret)
+	assert.Equal(t, "second", d.Get("shared_secret"))
+	assert.Equal(t, true, d.Get("mfa_required"))
+}
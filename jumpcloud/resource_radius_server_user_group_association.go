@@ -0,0 +1,44 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceRadiusServerUserGroupAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Binds JumpCloud user groups to a RADIUS server, so their members can authenticate against it. " +
+			"User groups bound to the server outside of Terraform are left alone.",
+		Create: radiusServerUserGroupAssociation.create,
+		Read:   radiusServerUserGroupAssociation.read,
+		Update: radiusServerUserGroupAssociation.update,
+		Delete: radiusServerUserGroupAssociation.delete,
+		Schema: map[string]*schema.Schema{
+			"radius_server_id": {
+				Description: "The ID of the RADIUS server.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"user_group_ids": {
+				Description: "The IDs of the user groups.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: radiusServerUserGroupAssociation.importer,
+		},
+	}
+}
+
+var radiusServerUserGroupAssociation = &graphAssociation{
+	path:            "/radiusservers/%s/associations",
+	sourceAttribute: "radius_server_id",
+	sourceName:      "RADIUS server",
+	targets: map[string]string{
+		"user_group_ids": "user_group",
+	},
+}
@@ -0,0 +1,154 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccRadiusServerUserGroupAssociation(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_radius_server_user_group_association.test_association"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadiusServerUserGroupAssociation(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(fullResourceName, "radius_server_id",
+						"jumpcloud_radius_server.test_server", "id"),
+					resource.TestCheckResourceAttr(fullResourceName, "user_group_ids.#", "1"),
+				),
+			},
+			{ // unbind the group via the api, then check the plan binds it again
+				PreConfig:          unbindRadiusServerUserGroupViaAPI(t, rName),
+				Config:             testAccRadiusServerUserGroupAssociation(rName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRadiusServerUserGroupAssociation(rName),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "user_group_ids.#", "1"),
+			},
+		},
+	})
+}
+
+func testAccRadiusServerUserGroupAssociation(name string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_radius_server" "test_server" {
+			name              = "%[1]s"
+			network_source_ip = "203.0.113.%[2]d"
+			shared_secret     = "%[3]s"
+		}
+
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+		}
+
+		resource "jumpcloud_radius_server_user_group_association" "test_association" {
+			radius_server_id = jumpcloud_radius_server.test_server.id
+			user_group_ids   = [jumpcloud_user_group.test_group.id]
+		}`, name, acctest.RandIntRange(1, 255), acctest.RandString(24),
+	)
+}
+
+func unbindRadiusServerUserGroupViaAPI(t *testing.T, name string) func() {
+	return func() {
+		config := testAccAPIConfig()
+
+		groupID, err := userGroupIDByName(config, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		radiusServerIDs, err := getRadiusServerIDsByGroup(config, groupID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, radiusServerID := range radiusServerIDs {
+			if err := radiusServerUserGroupAssociation.manage(context.TODO(), config, radiusServerID, "user_group", groupID, "remove"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// getRadiusServerIDsByGroup returns the RADIUS servers the user group is
+// bound to
+func getRadiusServerIDsByGroup(config *Meta, groupID string) ([]string, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	graphconnect, _, err := client.UserGroupAssociationsApi.GraphUserGroupAssociationsList(
+		context.TODO(), groupID, "", "", []string{"radius_server"}, nil)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, v := range graphconnect {
+		ids = append(ids, v.To.Id)
+	}
+	return ids, nil
+}
+
+func TestRadiusServerUserGroupAssociationReconcile(t *testing.T) {
+	bound := map[string]bool{"other": true}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/radiusservers/radius/associations", r.URL.Path)
+		if r.Method == http.MethodPost {
+			var req jcapiv2.GraphManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Op == "add" {
+				bound[req.Id] = true
+			} else {
+				delete(bound, req.Id)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		connections := []jcapiv2.GraphConnection{}
+		for id := range bound {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	boundIDs := func() []string {
+		ids := []string{}
+		for id := range bound {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceRadiusServerUserGroupAssociation()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"radius_server_id": "radius",
+		"user_group_ids":   []interface{}{"a", "b"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, []string{"a", "b", "other"}, boundIDs())
+
+	// unbound outside of Terraform
+	delete(bound, "b")
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, []interface{}{"a"}, d.Get("user_group_ids").(*schema.Set).List())
+
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, []string{"other"}, boundIDs())
+}
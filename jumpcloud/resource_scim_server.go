@@ -0,0 +1,138 @@
+package jumpcloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceScimServer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a SCIM endpoint identity providers provision users and " +
+			"groups to JumpCloud with.",
+		Create: resourceScimServerCreate,
+		Read:   resourceScimServerRead,
+		Update: resourceScimServerUpdate,
+		Delete: resourceScimServerDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:  "The name of the SCIM server, usually the identity provider provisioning to it.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"enabled": {
+				Description: "Whether the identity provider can provision through the SCIM server.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"base_url": {
+				Description: "The SCIM base URL to configure in the identity provider.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"token": {
+				Description: "The bearer token the identity provider authenticates with. Generated by JumpCloud " +
+					"if not set; it can't be read back from JumpCloud, so it is not known after an import.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(32, 256),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// redactToken keeps the token out of err, JumpCloud may echo rejected
+// values in its error messages
+func redactToken(err error, token string) error {
+	if err == nil || token == "" || !strings.Contains(err.Error(), token) {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), token, "(sensitive value)"))
+}
+
+func resourceScimServerCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := ScimServer{
+		Name:    d.Get("name").(string),
+		Enabled: d.Get("enabled").(bool),
+		Token:   d.Get("token").(string),
+	}
+	var server ScimServer
+	if _, err := jumpCloudRequest(config, http.MethodPost, "/scimservers", body, &server); err != nil {
+		return fmt.Errorf("error creating SCIM server %s: %w", body.Name, redactToken(err, body.Token))
+	}
+
+	d.SetId(server.ID)
+	// the generated token is only part of this response
+	if server.Token != "" {
+		if err := d.Set("token", server.Token); err != nil {
+			return err
+		}
+	}
+	return resourceScimServerRead(d, m)
+}
+
+func resourceScimServerRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var server ScimServer
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/scimservers/"+d.Id(), nil, &server)
+	if err != nil {
+		return fmt.Errorf("error reading SCIM server %s: %w", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", server.Name); err != nil {
+		return err
+	}
+	if err := d.Set("enabled", server.Enabled); err != nil {
+		return err
+	}
+	if err := d.Set("base_url", server.BaseURL); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceScimServerUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := ScimServer{
+		Name:    d.Get("name").(string),
+		Enabled: d.Get("enabled").(bool),
+	}
+	// the token is only sent to rotate it
+	if d.HasChange("token") {
+		body.Token = d.Get("token").(string)
+	}
+	if _, err := jumpCloudRequest(config, http.MethodPut, "/scimservers/"+d.Id(), body, nil); err != nil {
+		return fmt.Errorf("error updating SCIM server %s: %w", d.Id(), redactToken(err, body.Token))
+	}
+	return resourceScimServerRead(d, m)
+}
+
+func resourceScimServerDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudRequest(config, http.MethodDelete, "/scimservers/"+d.Id(), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting SCIM server %s: %w", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
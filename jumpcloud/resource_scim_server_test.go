@@ -0,0 +1,124 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccScimServer(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_scim_server.test_server"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScimServer(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "name", rName),
+					resource.TestCheckResourceAttr(fullResourceName, "enabled", "true"),
+					resource.TestCheckResourceAttrSet(fullResourceName, "base_url"),
+					resource.TestCheckResourceAttrSet(fullResourceName, "token"),
+				),
+			},
+			{
+				Config: testAccScimServer(rName, false),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "enabled", "false"),
+			},
+			{
+				ResourceName:            fullResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+	})
+}
+
+func testAccScimServer(name string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_scim_server" "test_server" {
+			name    = "%s"
+			enabled = %t
+		}`, name, enabled,
+	)
+}
+
+func TestScimServer(t *testing.T) {
+	server := ScimServer{}
+	sentToken := ""
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/v2/scimservers", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&server))
+			server.ID = "scim"
+			server.BaseURL = "https://scim.jumpcloud.com/scim"
+			server.Token = "generated-token"
+		case http.MethodPut:
+			assert.Equal(t, "/api/v2/scimservers/scim", r.URL.Path)
+			var body ScimServer
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sentToken = body.Token
+			if strings.HasPrefix(body.Token, "weak") {
+				rw.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(rw, `{"message": "token %s is too weak"}`, body.Token)
+				return
+			}
+			server.Name, server.Enabled, server.Token = body.Name, body.Enabled, body.Token
+		case http.MethodGet:
+			assert.Equal(t, "/api/v2/scimservers/scim", r.URL.Path)
+			// the token is never returned after it's generated
+			server.Token = ""
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(server))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceScimServer()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "Okta"})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "scim", d.Id())
+	assert.Equal(t, "https://scim.jumpcloud.com/scim", d.Get("base_url"))
+	assert.Equal(t, "generated-token", d.Get("token"))
+	assert.True(t, d.Get("enabled").(bool))
+
+	// the token isn't sent unless it's rotated
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":    "Okta",
+		"enabled": false,
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, "false", state.Attributes["enabled"])
+	assert.Equal(t, "generated-token", state.Attributes["token"])
+	assert.Empty(t, sentToken)
+
+	// a rejected token isn't part of the error
+	diff, err = r.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":  "Okta",
+		"token": "weak-0123456789abcdef0123456789abcdef",
+	}), config)
+	assert.NoError(t, err)
+	_, err = r.Apply(state, diff, config)
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "weak-0123456789abcdef0123456789abcdef")
+		assert.Contains(t, err.Error(), "token (sensitive value) is too weak")
+	}
+}
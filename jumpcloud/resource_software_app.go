@@ -0,0 +1,202 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// softwareAppPackageManagers maps the package_manager of the resource to
+// the one of the API
+var softwareAppPackageManagers = map[string]string{
+	"chocolatey":   "CHOCOLATEY",
+	"apple_custom": "APPLE_CUSTOM",
+}
+
+func resourceSoftwareApp() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a software app JumpCloud installs on systems, either a " +
+			"Chocolatey package on Windows or a custom macOS package. Apps are installed on the systems they are " +
+			"associated with.",
+		Create:        resourceSoftwareAppCreate,
+		Read:          resourceSoftwareAppRead,
+		Update:        resourceSoftwareAppUpdate,
+		Delete:        resourceSoftwareAppDelete,
+		CustomizeDiff: softwareAppDiff,
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description:  "The name of the app shown in the console.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"package_manager": {
+				Description: "Where the package comes from: `chocolatey` for a package of the Chocolatey community " +
+					"repository, `apple_custom` for a macOS package downloaded from `location`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"chocolatey", "apple_custom"}, false),
+			},
+			"package_id": {
+				Description: "The ID of the Chocolatey package, e.g. `googlechrome`. Required for `chocolatey`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"location": {
+				Description:  "The HTTPS URL the macOS package is downloaded from. Required for `apple_custom`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			"version": {
+				Description: "The version of the package to install. The latest version is installed if empty.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"auto_update": {
+				Description: "Whether the package is kept up to date on the systems. Only supported by `chocolatey`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"desired_state": {
+				Description:  "Whether the app is installed on or uninstalled from the systems. Possible values: `install`, `uninstall`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "install",
+				ValidateFunc: validation.StringInSlice([]string{"install", "uninstall"}, false),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// softwareAppDiff rejects settings the package manager of the app
+// doesn't support at plan time
+func softwareAppDiff(d *schema.ResourceDiff, m interface{}) error {
+	name := d.Get("display_name")
+	switch d.Get("package_manager").(string) {
+	case "chocolatey":
+		if d.NewValueKnown("package_id") && d.Get("package_id").(string) == "" {
+			return fmt.Errorf("package_id is required for the chocolatey app %s", name)
+		}
+		if d.Get("location").(string) != "" {
+			return fmt.Errorf("location is only supported by apple_custom apps, not by the chocolatey app %s", name)
+		}
+	case "apple_custom":
+		if d.NewValueKnown("location") && d.Get("location").(string) == "" {
+			return fmt.Errorf("location is required for the apple_custom app %s", name)
+		}
+		if d.Get("auto_update").(bool) {
+			return fmt.Errorf("auto_update is only supported by chocolatey apps, not by the apple_custom app %s", name)
+		}
+	}
+	return nil
+}
+
+func expandSoftwareApp(d *schema.ResourceData) SoftwareApp {
+	desiredState := "Install"
+	if d.Get("desired_state").(string) == "uninstall" {
+		desiredState = "Uninstall"
+	}
+	return SoftwareApp{
+		DisplayName: d.Get("display_name").(string),
+		Settings: []SoftwareAppSettings{{
+			PackageManager: softwareAppPackageManagers[d.Get("package_manager").(string)],
+			PackageID:      d.Get("package_id").(string),
+			Location:       d.Get("location").(string),
+			PackageVersion: d.Get("version").(string),
+			AutoUpdate:     d.Get("auto_update").(bool),
+			DesiredState:   desiredState,
+		}},
+	}
+}
+
+func resourceSoftwareAppCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandSoftwareApp(d)
+	var app SoftwareApp
+	if _, err := jumpCloudRequest(config, http.MethodPost, "/softwareapps", body, &app); err != nil {
+		return fmt.Errorf("error creating software app %s: %w", body.DisplayName, err)
+	}
+
+	d.SetId(app.ID)
+	return resourceSoftwareAppRead(d, m)
+}
+
+func resourceSoftwareAppRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var app SoftwareApp
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/softwareapps/"+d.Id(), nil, &app)
+	if err != nil {
+		return fmt.Errorf("error reading software app %s: %w", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("display_name", app.DisplayName); err != nil {
+		return err
+	}
+	if len(app.Settings) == 0 {
+		return nil
+	}
+
+	settings := app.Settings[0]
+	for packageManager, apiPackageManager := range softwareAppPackageManagers {
+		if settings.PackageManager == apiPackageManager {
+			if err := d.Set("package_manager", packageManager); err != nil {
+				return err
+			}
+		}
+	}
+	if err := d.Set("package_id", settings.PackageID); err != nil {
+		return err
+	}
+	if err := d.Set("location", settings.Location); err != nil {
+		return err
+	}
+	if err := d.Set("version", settings.PackageVersion); err != nil {
+		return err
+	}
+	if err := d.Set("auto_update", settings.AutoUpdate); err != nil {
+		return err
+	}
+	desiredState := "install"
+	if settings.DesiredState == "Uninstall" {
+		desiredState = "uninstall"
+	}
+	if err := d.Set("desired_state", desiredState); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceSoftwareAppUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	body := expandSoftwareApp(d)
+	if _, err := jumpCloudRequest(config, http.MethodPut, "/softwareapps/"+d.Id(), body, nil); err != nil {
+		return fmt.Errorf("error updating software app %s: %w", d.Id(), err)
+	}
+	return resourceSoftwareAppRead(d, m)
+}
+
+func resourceSoftwareAppDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if _, err := jumpCloudRequest(config, http.MethodDelete, "/softwareapps/"+d.Id(), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting software app %s: %w", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,44 @@
+package jumpcloud
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceSoftwareAppAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Targets a JumpCloud software app at system groups, installing it on their systems. " +
+			"System groups the app is targeted at outside of Terraform are left alone.",
+		Create: softwareAppAssociation.create,
+		Read:   softwareAppAssociation.read,
+		Update: softwareAppAssociation.update,
+		Delete: softwareAppAssociation.delete,
+		Schema: map[string]*schema.Schema{
+			"software_app_id": {
+				Description: "The ID of the software app.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"system_group_ids": {
+				Description: "The IDs of the system groups.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: softwareAppAssociation.importer,
+		},
+	}
+}
+
+var softwareAppAssociation = &graphAssociation{
+	path:            "/softwareapps/%s/associations",
+	sourceAttribute: "software_app_id",
+	sourceName:      "software app",
+	targets: map[string]string{
+		"system_group_ids": "system_group",
+	},
+}
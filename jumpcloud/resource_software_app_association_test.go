@@ -0,0 +1,179 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSoftwareAppAssociation(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_software_app_association.test_association"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSoftwareAppAssociation(rName, "first", "second"),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "system_group_ids.#", "2"),
+			},
+			{
+				Config: testAccSoftwareAppAssociation(rName, "first"),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "system_group_ids.#", "1"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: softwareAppAssociationImportID(fullResourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSoftwareAppAssociation(name string, groups ...string) string {
+	groupIDs := ""
+	for _, group := range groups {
+		groupIDs += fmt.Sprintf("jumpcloud_system_group.%s.jc_id, ", group)
+	}
+	return fmt.Sprintf(`
+		resource "jumpcloud_software_app" "test_app" {
+			display_name    = "%[1]s"
+			package_manager = "chocolatey"
+			package_id      = "7zip"
+		}
+
+		resource "jumpcloud_system_group" "first" {
+			name = "%[1]s_first"
+		}
+
+		resource "jumpcloud_system_group" "second" {
+			name = "%[1]s_second"
+		}
+
+		resource "jumpcloud_software_app_association" "test_association" {
+			software_app_id  = jumpcloud_software_app.test_app.id
+			system_group_ids = [%[2]s]
+		}`, name, groupIDs,
+	)
+}
+
+// softwareAppAssociationImportID builds the software_app_id:system_group_id
+// import ID of the association from its state
+func softwareAppAssociationImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", name)
+		}
+
+		groupIDs := []string{}
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "system_group_ids.") && k != "system_group_ids.#" {
+				groupIDs = append(groupIDs, v)
+			}
+		}
+		return rs.Primary.ID + ":" + strings.Join(groupIDs, ","), nil
+	}
+}
+
+func TestSoftwareAppAssociationReconcile(t *testing.T) {
+	bound := map[string]bool{"other": true}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/softwareapps/app/associations", r.URL.Path)
+		if r.Method == http.MethodPost {
+			var req jcapiv2.GraphManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, jcapiv2.GraphType("system_group"), *req.Type_)
+			if req.Op == "add" {
+				bound[req.Id] = true
+			} else {
+				delete(bound, req.Id)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		assert.Equal(t, "system_group", r.URL.Query().Get("targets"))
+		connections := []jcapiv2.GraphConnection{}
+		for id := range bound {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	boundIDs := func() []string {
+		ids := []string{}
+		for id := range bound {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceSoftwareAppAssociation()
+
+	// bind two groups
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"software_app_id":  "app",
+		"system_group_ids": []interface{}{"a", "b"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, []string{"a", "b", "other"}, boundIDs())
+
+	// unbind one of them
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"software_app_id":  "app",
+		"system_group_ids": []interface{}{"a"},
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "other"}, boundIDs())
+	d = r.Data(state)
+
+	// unbound outside of Terraform
+	delete(bound, "a")
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, d.Get("system_group_ids").(*schema.Set).List())
+
+	// destroying only unbinds the configured groups
+	bound["a"] = true
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"software_app_id":  "app",
+		"system_group_ids": []interface{}{"a"},
+	})
+	d.SetId("app")
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, []string{"other"}, boundIDs())
+}
+
+func TestSoftwareAppAssociationImporter(t *testing.T) {
+	r := resourceSoftwareAppAssociation()
+	d := r.TestResourceData()
+	d.SetId("app:a,b")
+
+	imported, err := softwareAppAssociation.importer(d, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "app", imported[0].Id())
+	assert.Equal(t, "app", imported[0].Get("software_app_id"))
+	assert.ElementsMatch(t, []interface{}{"a", "b"}, imported[0].Get("system_group_ids").(*schema.Set).List())
+
+	d.SetId("app")
+	_, err = softwareAppAssociation.importer(d, nil)
+	assert.EqualError(t, err, `invalid ID "app", expected 'software_app_id:system_group_id'`)
+}
@@ -0,0 +1,133 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSoftwareApp(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_software_app.test_app"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSoftwareApp(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "display_name", rName),
+					resource.TestCheckResourceAttr(fullResourceName, "package_id", "7zip"),
+					resource.TestCheckResourceAttr(fullResourceName, "auto_update", "false"),
+				),
+			},
+			{
+				Config: testAccSoftwareApp(rName, true),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "auto_update", "true"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSoftwareApp(name string, autoUpdate bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_software_app" "test_app" {
+			display_name    = "%s"
+			package_manager = "chocolatey"
+			package_id      = "7zip"
+			auto_update     = %t
+		}`, name, autoUpdate,
+	)
+}
+
+func TestSoftwareApp(t *testing.T) {
+	var app SoftwareApp
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/v2/softwareapps", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&app))
+			app.ID = "app"
+		case http.MethodPut:
+			assert.Equal(t, "/api/v2/softwareapps/app", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&app))
+			app.ID = "app"
+		case http.MethodGet:
+			assert.Equal(t, "/api/v2/softwareapps/app", r.URL.Path)
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(app))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceSoftwareApp()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"display_name":    "Installer",
+		"package_manager": "apple_custom",
+		"location":        "https://example.com/installer.pkg",
+		"version":         "1.2.3",
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "app", d.Id())
+	assert.Equal(t, []SoftwareAppSettings{{
+		PackageManager: "APPLE_CUSTOM",
+		Location:       "https://example.com/installer.pkg",
+		PackageVersion: "1.2.3",
+		DesiredState:   "Install",
+	}}, app.Settings)
+	assert.Equal(t, "apple_custom", d.Get("package_manager"))
+	assert.Equal(t, "install", d.Get("desired_state"))
+
+	assert.NoError(t, d.Set("desired_state", "uninstall"))
+	assert.NoError(t, r.Update(d, config))
+	assert.Equal(t, "Uninstall", app.Settings[0].DesiredState)
+	assert.Equal(t, "uninstall", d.Get("desired_state"))
+	assert.Equal(t, "1.2.3", d.Get("version"))
+}
+
+func TestSoftwareAppDiff(t *testing.T) {
+	diff := func(config map[string]interface{}) error {
+		config["display_name"] = "app"
+		_, err := resourceSoftwareApp().Diff(&terraform.InstanceState{}, terraform.NewResourceConfigRaw(config),
+			newMeta(jcapiv2.NewConfiguration()))
+		return err
+	}
+
+	for _, c := range []struct {
+		Config map[string]interface{}
+		Error  string
+	}{
+		{map[string]interface{}{"package_manager": "chocolatey", "package_id": "7zip", "auto_update": true}, ""},
+		{map[string]interface{}{"package_manager": "chocolatey"}, "package_id is required for the chocolatey app app"},
+		{map[string]interface{}{"package_manager": "chocolatey", "package_id": "7zip",
+			"location": "https://example.com/7zip.pkg"}, "location is only supported by apple_custom apps"},
+		{map[string]interface{}{"package_manager": "apple_custom", "location": "https://example.com/app.pkg"}, ""},
+		{map[string]interface{}{"package_manager": "apple_custom"}, "location is required for the apple_custom app app"},
+		{map[string]interface{}{"package_manager": "apple_custom", "location": "https://example.com/app.pkg",
+			"auto_update": true}, "auto_update is only supported by chocolatey apps"},
+	} {
+		err := diff(c.Config)
+		if c.Error == "" {
+			assert.NoError(t, err)
+		} else if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), c.Error)
+		}
+	}
+}
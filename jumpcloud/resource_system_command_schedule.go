@@ -0,0 +1,193 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceSystemCommandSchedule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing the schedule of an existing JumpCloud command.",
+		Create:      resourceSystemCommandScheduleCreate,
+		Read:        resourceSystemCommandScheduleRead,
+		Update:      resourceSystemCommandScheduleUpdate,
+		Delete:      resourceSystemCommandScheduleDelete,
+		Schema: map[string]*schema.Schema{
+			"command_id": {
+				Description: "The ID of the command to schedule.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schedule": {
+				Description: "The cron expression the command is run on, e.g. `0 3 * * 1`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\S+(\s+\S+){4}$`),
+					"must be a cron expression with five fields"),
+			},
+			"schedule_repeat_type": {
+				Description: "How often the schedule repeats. Possible values: `none`, `minute`, `hour`, `day`, `week`, `month`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "none",
+				ValidateFunc: validation.StringInSlice([]string{
+					"none",
+					"minute",
+					"hour",
+					"day",
+					"week",
+					"month",
+				}, false),
+			},
+			"launch_type": {
+				Description: "How the command is launched. Possible values: `trigger`, `event`, `scheduled`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "scheduled",
+				ValidateFunc: validation.StringInSlice([]string{
+					"trigger",
+					"event",
+					"scheduled",
+				}, false),
+			},
+			"active": {
+				Description: "Whether the schedule is active. An inactive schedule falls back to the `trigger` launch type.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// updateCommand rewrites the fields of a command changed by modify;
+// commands are replaced as a whole by the API, so all other fields are
+// read first and sent back unchanged
+func updateCommand(ctx context.Context, client *jcapiv1.APIClient, id string,
+	modify func(*jcapiv1.Command)) error {
+
+	command, res, err := client.CommandsApi.CommandsGet(ctx,
+		id, "", headerAccept, nil)
+	if err != nil {
+		return fmt.Errorf("error reading command %s: %w", id, apiError(res, err))
+	}
+
+	modify(&command)
+
+	req := map[string]interface{}{
+		"body": command,
+	}
+	_, res, err = client.CommandsApi.CommandsPut(ctx,
+		id, "", headerAccept, req)
+	if err != nil {
+		return fmt.Errorf("error updating command %s: %w", id, apiError(res, err))
+	}
+	return nil
+}
+
+func applyCommandSchedule(d *schema.ResourceData) func(*jcapiv1.Command) {
+	return func(command *jcapiv1.Command) {
+		command.Schedule = d.Get("schedule").(string)
+		command.ScheduleRepeatType = d.Get("schedule_repeat_type").(string)
+		command.LaunchType = d.Get("launch_type").(string)
+		if !d.Get("active").(bool) {
+			command.LaunchType = "trigger"
+		}
+	}
+}
+
+func resourceSystemCommandScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	id := d.Get("command_id").(string)
+	if err := updateCommand(ctx, client, id, applyCommandSchedule(d)); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return resourceSystemCommandScheduleRead(d, m)
+}
+
+func resourceSystemCommandScheduleRead(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	command, _, err := client.CommandsApi.CommandsGet(ctx,
+		d.Id(), "", headerAccept, nil)
+	if err != nil {
+		if err.Error() == "EOF" {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if err := d.Set("command_id", d.Id()); err != nil {
+		return err
+	}
+	if err := d.Set("schedule", command.Schedule); err != nil {
+		return err
+	}
+	if command.ScheduleRepeatType != "" {
+		if err := d.Set("schedule_repeat_type", command.ScheduleRepeatType); err != nil {
+			return err
+		}
+	}
+
+	// a paused schedule is stored as a triggered command, keep the
+	// configured launch type in that case
+	launchType, _ := d.Get("launch_type").(string)
+	active := true
+	if command.LaunchType == "trigger" && launchType != "" && launchType != "trigger" {
+		active = false
+	} else {
+		launchType = command.LaunchType
+	}
+	if err := d.Set("launch_type", launchType); err != nil {
+		return err
+	}
+	if err := d.Set("active", active); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceSystemCommandScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	if err := updateCommand(ctx, client, d.Id(), applyCommandSchedule(d)); err != nil {
+		return err
+	}
+	return resourceSystemCommandScheduleRead(d, m)
+}
+
+func resourceSystemCommandScheduleDelete(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	err := updateCommand(ctx, client, d.Id(), func(command *jcapiv1.Command) {
+		command.LaunchType = "trigger"
+		command.Schedule = ""
+		command.ScheduleRepeatType = ""
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
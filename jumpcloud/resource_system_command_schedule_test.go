@@ -0,0 +1,51 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccSystemCommandSchedule(t *testing.T) {
+	commandID := os.Getenv("JUMPCLOUD_COMMAND_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if commandID == "" {
+				t.Skip("JUMPCLOUD_COMMAND_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemCommandSchedule(commandID, "0 3 * * 1", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_system_command_schedule.test_schedule", "schedule", "0 3 * * 1"),
+					resource.TestCheckResourceAttr("jumpcloud_system_command_schedule.test_schedule", "launch_type", "scheduled"),
+				),
+			},
+			{
+				Config: testAccSystemCommandSchedule(commandID, "0 4 * * 1", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_system_command_schedule.test_schedule", "schedule", "0 4 * * 1"),
+					resource.TestCheckResourceAttr("jumpcloud_system_command_schedule.test_schedule", "active", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSystemCommandSchedule(commandID, schedule string, active bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_system_command_schedule" "test_schedule" {
+			command_id           = "%s"
+			schedule             = "%s"
+			schedule_repeat_type = "week"
+			active               = %t
+		}`, commandID, schedule, active,
+	)
+}
@@ -3,26 +3,51 @@ package jumpcloud
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
-func resourceGroupsSystem() *schema.Resource {
+func resourceSystemGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGroupsSystemCreate,
-		Read:   resourceGroupsSystemRead,
-		Update: resourceGroupsSystemUpdate,
-		Delete: resourceGroupsSystemDelete,
+		Create: resourceSystemGroupCreate,
+		Read:   resourceSystemGroupRead,
+		Update: resourceSystemGroupUpdate,
+		Delete: resourceSystemGroupDelete,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A description of the group, at most 1024 characters.",
+				ValidateFunc: validateGroupDescription,
+			},
 			"jc_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"members": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "The IDs of the systems in this group. Systems added outside of Terraform are removed, unless the attribute is left out.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"member_hostnames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The hostnames of the systems in this group",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -30,33 +55,38 @@ func resourceGroupsSystem() *schema.Resource {
 	}
 }
 
-func resourceGroupsSystemCreate(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+func resourceSystemGroupCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
 
-	body := jcapiv2.SystemGroupData{Name: d.Get("name").(string)}
-
-	req := map[string]interface{}{
-		"body": body,
+	// jcapiv2.SystemGroupData has no description, so the group is
+	// created through the HTTP API directly
+	body := SystemGroupPost{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
 	}
-	group, res, err := client.SystemGroupsApi.GroupsSystemPost(context.TODO(),
-		"", headerAccept, req)
+
+	var group SystemGroup
+	_, err := jumpCloudRequest(config, http.MethodPost, "/systemgroups", body, &group)
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error creating system group %s: %s - response = %+v",
-			(req["body"].(jcapiv2.SystemGroupData)).Name, err, res)
+		return fmt.Errorf("error creating system group %s: %w", body.Name, err)
 	}
 
 	d.SetId(group.Name)
 	d.Set("name", group.Name)
-	d.Set("jc_id", group.Id)
-	return resourceGroupsSystemRead(d, m)
+	d.Set("jc_id", group.ID)
+
+	// a new group has no members yet
+	if err := syncSystemGroupMembers(requestContext(config), config, group.ID, []string{}, d.Get("members").(*schema.Set)); err != nil {
+		return err
+	}
+	return resourceSystemGroupRead(d, m)
 }
 
 // Helper to look up a system group by name
-func resourceGroupsSystemList_match(d *schema.ResourceData, m interface{}) (jcapiv2.SystemGroup, error) {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+func resourceSystemGroupList_match(d *schema.ResourceData, m interface{}) (jcapiv2.SystemGroup, error) {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
 
 	var filter []string
 
@@ -66,7 +96,7 @@ func resourceGroupsSystemList_match(d *schema.ResourceData, m interface{}) (jcap
 		"filter": filter,
 	}
 
-	result, _, err := client.SystemGroupsApi.GroupsSystemList(context.TODO(),
+	result, _, err := client.SystemGroupsApi.GroupsSystemList(ctx,
 		"", headerAccept, optional)
 	if err == nil {
 		if len(result) < 1 {
@@ -79,16 +109,15 @@ func resourceGroupsSystemList_match(d *schema.ResourceData, m interface{}) (jcap
 	}
 }
 
-func resourceGroupsSystemRead(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+func resourceSystemGroupRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
 
 	var id string
 
 	id = d.Get("jc_id").(string)
 
 	if id == "" {
-		id_lookup, err := resourceGroupsSystemList_match(d, m)
+		id_lookup, err := resourceSystemGroupList_match(d, m)
 		if err != nil {
 			return fmt.Errorf("Unable to locate ID for group %s, %+v",
 				d.Get("name"), err)
@@ -99,59 +128,149 @@ func resourceGroupsSystemRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("jc_id", id_lookup.Id)
 	}
 
-	group, res, err := client.SystemGroupsApi.GroupsSystemGet(context.TODO(),
-		id, "", headerAccept, nil)
+	group, ok, err := systemGroupReadHelper(requestContext(config), config, id)
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error reading system group ID %s: %s - response = %+v",
-			d.Id(), err, res)
+		return fmt.Errorf("error reading system group ID %s: %w", d.Id(), err)
+	}
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
 	}
 
 	d.SetId(group.Name)
 	d.Set("name", group.Name)
-	d.Set("jc_id", group.Id)
+	d.Set("description", group.Description)
+	d.Set("jc_id", group.ID)
+	return setSystemGroupMembers(d, config, group.ID)
+}
+
+// systemGroupReadHelper reads a system group through the HTTP API, which
+// unlike jcapiv2 returns all of its fields
+func systemGroupReadHelper(ctx context.Context, config *Meta, id string) (sg *SystemGroup,
+	ok bool, err error) {
+
+	var group SystemGroup
+	ok, err = jumpCloudRequestContext(ctx, config, http.MethodGet, "/systemgroups/"+id, nil, &group)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	return &group, true, nil
+}
+
+func setSystemGroupMembers(d *schema.ResourceData, config *Meta, id string) error {
+	ctx := requestContext(config)
+
+	memberIDs, err := getSystemGroupMemberIDs(ctx, config, id)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("members", memberIDs); err != nil {
+		return err
+	}
+
+	hostnames, err := systemIDsToHostnames(config, memberIDs)
+	if err != nil {
+		return err
+	}
+	return d.Set("member_hostnames", hostnames)
+}
+
+// syncSystemGroupMembers adds and removes systems until the group's
+// members match desired; current is read if nil
+func syncSystemGroupMembers(ctx context.Context, config *Meta, id string, current []string, desired *schema.Set) error {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	if current == nil {
+		var err error
+		if current, err = getSystemGroupMemberIDs(ctx, config, id); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range desired.List() {
+		if !stringInSlice(v.(string), current) {
+			if err := manageSystemGroupMember(ctx, client, id, v.(string), "add"); err != nil {
+				return err
+			}
+		}
+	}
+	for _, systemID := range current {
+		if !desired.Contains(systemID) {
+			if err := manageSystemGroupMember(ctx, client, id, systemID, "remove"); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
-func resourceGroupsSystemUpdate(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+func manageSystemGroupMember(ctx context.Context, client *jcapiv2.APIClient, groupID, systemID, action string) error {
+	req := map[string]interface{}{
+		"body": jcapiv2.SystemGroupMembersReq{
+			Op:    action,
+			Type_: "system",
+			Id:    systemID,
+		},
+	}
+
+	res, err := client.SystemGroupMembersMembershipApi.GraphSystemGroupMembersPost(
+		ctx, groupID, "", headerAccept, req)
+	if err != nil {
+		return fmt.Errorf("error managing system group member, action: %s, system id: %s: %w",
+			action, systemID, apiError(res, err))
+	}
+	return nil
+}
+
+func resourceSystemGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
 
 	var id string
 	id = d.Get("jc_id").(string)
 
-	body := jcapiv2.SystemGroupData{Name: d.Get("name").(string)}
-
-	req := map[string]interface{}{
-		"body": body,
+	// PATCH, as a PUT without the tags would remove the ones managed by
+	// jumpcloud_system_group_tag
+	body := SystemGroupPost{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
 	}
 
-	group, res, err := client.SystemGroupsApi.GroupsSystemPut(context.TODO(),
-		id, "", headerAccept, req)
+	var group SystemGroup
+	_, err := jumpCloudRequest(config, http.MethodPatch, "/systemgroups/"+id, body, &group)
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error updating system group %s: %s - response = %+v",
-			d.Get("name"), err, res)
+		return fmt.Errorf("error updating system group %s: %w", d.Get("name"), err)
 	}
 
 	d.SetId(group.Name)
 	d.Set("name", group.Name)
-	d.Set("jc_id", group.Id)
-	return resourceGroupsSystemRead(d, m)
+	d.Set("jc_id", group.ID)
+
+	// members left out of the configuration keep their value from state,
+	// so only a configured change syncs them and a rename leaves the
+	// systems of jumpcloud_system_group_membership alone. The current
+	// members are fetched from the API, so out-of-band changes are
+	// reconciled along with the configured ones.
+	if d.HasChange("members") {
+		if err := syncSystemGroupMembers(requestContext(config), config, group.ID, nil, d.Get("members").(*schema.Set)); err != nil {
+			return err
+		}
+	}
+	return resourceSystemGroupRead(d, m)
 }
 
-func resourceGroupsSystemDelete(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+func resourceSystemGroupDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
 
 	var id string
 	id = d.Get("jc_id").(string)
 
-	res, err := client.SystemGroupsApi.GroupsSystemDelete(context.TODO(),
+	res, err := client.SystemGroupsApi.GroupsSystemDelete(ctx,
 		id, "", headerAccept, nil)
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error deleting system group:%s; response = %+v", err, res)
+		return fmt.Errorf("error deleting system group %s: %w", d.Id(), apiError(res, err))
 	}
 	d.SetId("")
 	return nil
@@ -0,0 +1,118 @@
+package jumpcloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceSystemGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the members of a system group independently of the group. The resource is authoritative: " +
+			"systems added to the group outside of Terraform are removed. Don't combine it with `members` on the " +
+			"`jumpcloud_system_group` of the same group, or both will keep undoing each other's changes.",
+		Create: resourceSystemGroupMembershipCreate,
+		Read:   resourceSystemGroupMembershipRead,
+		Update: resourceSystemGroupMembershipUpdate,
+		Delete: resourceSystemGroupMembershipDelete,
+		Schema: map[string]*schema.Schema{
+			"system_group_id": {
+				Description: "The ID of the system group, i.e. the `jc_id` of a `jumpcloud_system_group`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"system_ids": {
+				Description: "The IDs of the systems in the group.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: systemGroupMembershipImporter,
+		},
+		// systems are added and removed one by one
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func systemGroupMembershipImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("system_group_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSystemGroupMembershipCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutCreate, func(ctx context.Context) error {
+		groupID := d.Get("system_group_id").(string)
+
+		// the group may already have members, they are replaced
+		if err := syncSystemGroupMembers(ctx, config, groupID, nil, d.Get("system_ids").(*schema.Set)); err != nil {
+			return err
+		}
+		d.SetId(groupID)
+		return systemGroupMembershipRead(ctx, d, config)
+	})
+}
+
+func resourceSystemGroupMembershipRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutRead, func(ctx context.Context) error {
+		return systemGroupMembershipRead(ctx, d, config)
+	})
+}
+
+func systemGroupMembershipRead(ctx context.Context, d *schema.ResourceData, config *Meta) error {
+	_, ok, err := systemGroupReadHelper(ctx, config, d.Id())
+	if err != nil {
+		return fmt.Errorf("error reading system group ID %s: %s", d.Id(), err)
+	}
+	if !ok {
+		// the group is gone, and its membership with it
+		d.SetId("")
+		return nil
+	}
+
+	// systems added or removed outside of Terraform show up as drift
+	memberIDs, err := getSystemGroupMemberIDs(ctx, config, d.Id())
+	if err != nil {
+		return err
+	}
+	return d.Set("system_ids", memberIDs)
+}
+
+func resourceSystemGroupMembershipUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutUpdate, func(ctx context.Context) error {
+		if err := syncSystemGroupMembers(ctx, config, d.Id(), nil, d.Get("system_ids").(*schema.Set)); err != nil {
+			return err
+		}
+		return systemGroupMembershipRead(ctx, d, config)
+	})
+}
+
+func resourceSystemGroupMembershipDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	return withTimeout(d, config, schema.TimeoutDelete, func(ctx context.Context) error {
+		// only the systems in state are removed, the group itself is left alone
+		for _, v := range d.Get("system_ids").(*schema.Set).List() {
+			if err := manageSystemGroupMember(ctx, client, d.Id(), v.(string), "remove"); err != nil {
+				return err
+			}
+		}
+		d.SetId("")
+		return nil
+	})
+}
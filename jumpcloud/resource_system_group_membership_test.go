@@ -0,0 +1,194 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSystemGroupMembership(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	systemID := os.Getenv("JUMPCLOUD_SYSTEM_ID")
+	fullResourceName := "jumpcloud_system_group_membership.test_membership"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if systemID == "" {
+				t.Skip("JUMPCLOUD_SYSTEM_ID must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemGroupMembership(rName, fmt.Sprintf("%q", systemID)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "system_ids.#", "1"),
+					testCheckTypeSetElemAttr(fullResourceName, "system_ids.*", systemID),
+				),
+			},
+			{
+				Config: testAccSystemGroupMembership(rName, ""),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "system_ids.#", "0"),
+			},
+			{ // add the system via the api, it has to be removed again
+				PreConfig: addSystemGroupMemberViaAPI(t, rName, systemID),
+				Config:    testAccSystemGroupMembership(rName, ""),
+				Check:     resource.TestCheckResourceAttr(fullResourceName, "system_ids.#", "0"),
+			},
+			{
+				ResourceName:      fullResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSystemGroupMembership(name string, systemIDs string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_system_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_system_group_membership" "test_membership" {
+			system_group_id = jumpcloud_system_group.test_group.jc_id
+			system_ids      = [%s]
+		}`, name, systemIDs,
+	)
+}
+
+// fakeSystemGroup serves the members of system group "group"
+type fakeSystemGroup struct {
+	mu      sync.Mutex
+	members map[string]bool
+}
+
+func (f *fakeSystemGroup) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/systemgroups/group":
+		rw.Write([]byte(`{"id": "group", "name": "group"}`))
+	case r.URL.Path == "/systemgroups/group/members" && r.Method == http.MethodGet:
+		connections := []jcapiv2.GraphConnection{}
+		for id := range f.members {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		json.NewEncoder(rw).Encode(connections)
+	case r.URL.Path == "/systemgroups/group/members" && r.Method == http.MethodPost:
+		var req jcapiv2.SystemGroupMembersReq
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Op == "add" {
+			f.members[req.Id] = true
+		} else {
+			delete(f.members, req.Id)
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeSystemGroup) memberIDs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := []string{}
+	for id := range f.members {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestSystemGroupMembershipReconcile(t *testing.T) {
+	group := &fakeSystemGroup{members: map[string]bool{"stale": true}}
+	testServer := httptest.NewServer(group)
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceSystemGroupMembership()
+
+	// add: members the group already had are replaced
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"system_group_id": "group",
+		"system_ids":      []interface{}{"a", "b"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "group", d.Id())
+	assert.Equal(t, []string{"a", "b"}, group.memberIDs())
+
+	// remove
+	assert.NoError(t, d.Set("system_ids", []string{"a"}))
+	assert.NoError(t, r.Update(d, config))
+	assert.Equal(t, []string{"a"}, group.memberIDs())
+
+	// drift: a system added outside of Terraform shows up on read ...
+	group.members["c"] = true
+	assert.NoError(t, r.Read(d, config))
+	assert.ElementsMatch(t, []interface{}{"a", "c"}, d.Get("system_ids").(*schema.Set).List())
+
+	// ... and is removed once the configuration is applied again
+	assert.NoError(t, d.Set("system_ids", []string{"a"}))
+	assert.NoError(t, r.Update(d, config))
+	assert.Equal(t, []string{"a"}, group.memberIDs())
+
+	assert.NoError(t, r.Delete(d, config))
+	assert.Empty(t, group.memberIDs())
+}
+
+func TestSystemGroupMembershipReadGone(t *testing.T) {
+	testServer := httptest.NewServer(http.NotFoundHandler())
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceSystemGroupMembership()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"system_group_id": "group"})
+	d.SetId("group")
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, d.Id())
+}
+
+func TestSystemGroupMembershipUpdateTimeout(t *testing.T) {
+	// the API never answers
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL
+
+	r := resourceSystemGroupMembership()
+	timeout := 50 * time.Millisecond
+	r.Timeouts.Update = &timeout
+	d := r.Data(&terraform.InstanceState{ID: "group"})
+
+	start := time.Now()
+	err = r.Update(d, config)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "update timed out after 50ms")
+}
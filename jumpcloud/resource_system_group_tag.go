@@ -0,0 +1,134 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// groupTagsMutex serializes the read-modify-write cycles on the tags of
+// a user or system group
+var groupTagsMutex = mutexkv.NewMutexKV()
+
+func resourceSystemGroupTag() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a single tag of a JumpCloud system group.",
+		Create:      resourceSystemGroupTagCreate,
+		Read:        resourceSystemGroupTagRead,
+		Delete:      resourceSystemGroupTagDelete,
+		Schema: map[string]*schema.Schema{
+			"system_group_id": {
+				Description: "The ID of the system group, i.e. `jc_id` of the `resource_system_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"tag": {
+				Description: "The tag.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: systemGroupTagImporter,
+		},
+	}
+}
+
+func systemGroupTagImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), "/", 2)
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'system_group_id/tag'")
+	}
+
+	_ = d.Set("system_group_id", ids[0])
+	_ = d.Set("tag", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateSystemGroupTags rewrites the tags of a system group, ok is false
+// if the group doesn't exist
+func updateSystemGroupTags(config *Meta, groupID string,
+	modify func([]string) []string) (ok bool, err error) {
+
+	groupTagsMutex.Lock(groupID)
+	defer groupTagsMutex.Unlock(groupID)
+
+	group, ok, err := systemGroupReadHelper(requestContext(config), config, groupID)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	payload := GroupTags{Tags: modify(group.Tags)}
+	_, err = jumpCloudRequest(config, http.MethodPatch, "/systemgroups/"+groupID, payload, nil)
+	if err != nil {
+		return true, fmt.Errorf("error updating tags of system group %s: %s", groupID, err)
+	}
+	return true, nil
+}
+
+func resourceSystemGroupTagCreate(d *schema.ResourceData, m interface{}) error {
+	tag := d.Get("tag").(string)
+
+	groupID := d.Get("system_group_id").(string)
+	ok, err := updateSystemGroupTags(m.(*Meta), groupID,
+		func(tags []string) []string {
+			if stringInSlice(tag, tags) {
+				return tags
+			}
+			return append(tags, tag)
+		})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("system group %s not found", groupID)
+	}
+
+	d.SetId(groupID + "/" + tag)
+	return resourceSystemGroupTagRead(d, m)
+}
+
+func resourceSystemGroupTagRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	group, ok, err := systemGroupReadHelper(requestContext(config), config, d.Get("system_group_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if !ok || !stringInSlice(d.Get("tag").(string), group.Tags) {
+		// the group or the tag is gone
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceSystemGroupTagDelete(d *schema.ResourceData, m interface{}) error {
+	tag := d.Get("tag").(string)
+
+	ok, err := updateSystemGroupTags(m.(*Meta), d.Get("system_group_id").(string),
+		func(tags []string) []string {
+			out := []string{}
+			for _, t := range tags {
+				if t != tag {
+					out = append(out, t)
+				}
+			}
+			return out
+		})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// the tag is gone with the group
+		log.Printf("[WARN] system group of tag %s not found, removing from state", d.Id())
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,76 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSystemGroupTag(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemGroupTag(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_system_group_tag.test_tag.0", "tag", "production"),
+					resource.TestCheckResourceAttr("jumpcloud_system_group_tag.test_tag.1", "tag", "linux"),
+				),
+			},
+			{
+				ResourceName:      "jumpcloud_system_group_tag.test_tag.0",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSystemGroupTag(name string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_system_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_system_group_tag" "test_tag" {
+			count           = 2
+			system_group_id = jumpcloud_system_group.test_group.jc_id
+			tag             = ["production", "linux"][count.index]
+		}`, name,
+	)
+}
+
+func TestSystemGroupTagGroupDeleted(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/systemgroups/group", r.URL.Path)
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceSystemGroupTag()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"system_group_id": "group",
+		"tag":             "production",
+	})
+	assert.EqualError(t, r.Create(d, config), "system group group not found")
+
+	// the tag is gone with the group
+	d.SetId("group/production")
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, "", d.Id())
+}
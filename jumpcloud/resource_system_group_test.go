@@ -1,48 +1,96 @@
 package jumpcloud
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
-	"github.com/hashicorp/terraform/helper/acctest"
-	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
 func TestAccSystemGroup(t *testing.T) {
 	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
-	posixName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
-	gid := acctest.RandIntRange(1, 1000)
+	systemID := os.Getenv("JUMPCLOUD_SYSTEM_ID")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if systemID == "" {
+				t.Skip("JUMPCLOUD_SYSTEM_ID must be set for this acceptance test")
+			}
+		},
 		Providers:    testAccProviders,
 		CheckDestroy: nil,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccSystemGroup(rName, gid, posixName),
+				Config: testAccSystemGroup(rName, fmt.Sprintf("%q", systemID)),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("jumpcloud_system_group.test_group", "name", rName),
-					resource.TestCheckResourceAttr("jumpcloud_system_group.test_group",
-						"attributes.posix_groups", fmt.Sprintf("%d:%s", gid, posixName)),
+					resource.TestCheckResourceAttr("jumpcloud_system_group.test_group", "members.#", "1"),
+					testCheckTypeSetElemAttr("jumpcloud_system_group.test_group", "members.*", systemID),
+					resource.TestCheckResourceAttr("jumpcloud_system_group.test_group", "member_hostnames.#", "1"),
 				),
 			},
+			{
+				Config: testAccSystemGroup(rName, ""),
+				Check:  resource.TestCheckResourceAttr("jumpcloud_system_group.test_group", "members.#", "0"),
+			},
+			{ // add the system via the api, it has to be removed again
+				PreConfig: addSystemGroupMemberViaAPI(t, rName, systemID),
+				Config:    testAccSystemGroup(rName, ""),
+				Check:     resource.TestCheckResourceAttr("jumpcloud_system_group.test_group", "members.#", "0"),
+			},
+			{
+				ResourceName:      "jumpcloud_system_group.test_group",
+				ImportState:       true,
+				ImportStateId:     rName,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
-func testAccSystemGroup(name string, gid int, posixName string) string {
+func testAccSystemGroup(name string, members string) string {
 	return fmt.Sprintf(`
 		resource "jumpcloud_system_group" "test_group" {
-    		name = "%s"
-		}`, name, gid, posixName,
+			name    = "%s"
+			members = [%s]
+		}`, name, members,
 	)
 }
 
+func addSystemGroupMemberViaAPI(t *testing.T, name, systemID string) func() {
+	return func() {
+		config := newMeta(jcapiv2.NewConfiguration())
+		config.AddDefaultHeader("x-api-key", os.Getenv("JUMPCLOUD_API_KEY"))
+		client := jcapiv2.NewAPIClient(config.Configuration)
+
+		groups, _, err := client.SystemGroupsApi.GroupsSystemList(context.Background(), "", headerAccept, map[string]interface{}{
+			"filter": []string{"name:eq:" + name},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(groups) != 1 {
+			t.Fatalf("expected one system group named %s, got %d", name, len(groups))
+		}
+
+		if err := manageSystemGroupMember(context.TODO(), client, groups[0].Id, systemID, "add"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func TestResourceSystemGroup(t *testing.T) {
 	suite.Run(t, new(ResourceSystemGroupSuite))
 }
@@ -75,14 +123,107 @@ func (s *ResourceSystemGroupSuite) TestTrueSystemGroupRead() {
 			rw.Write(c.Payload)
 		}))
 
-		config := &jcapiv2.Configuration{
+		config := newMeta(&jcapiv2.Configuration{
 			BasePath: testServer.URL,
-		}
+		})
 
-		ug, ok, err := systemGroupReadHelper(config, "id")
+		ug, ok, err := systemGroupReadHelper(context.TODO(), config, "id")
 		s.A.Equal(c.OK, ok)
 		s.A.Equal(c.SystemGroupNil, ug == nil)
 		s.A.Equal(c.ErrorNil, err == nil)
 		testServer.Close()
 	}
 }
+
+func (s *ResourceSystemGroupSuite) TestSystemGroupUpdateKeepsTags() {
+	group := SystemGroup{ID: "id", Type: "system_group", Name: "old", Tags: []string{"tag"}}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systemgroups/id":
+			if r.Method != http.MethodGet {
+				s.A.Equal(http.MethodPatch, r.Method)
+				var body map[string]interface{}
+				s.A.NoError(json.NewDecoder(r.Body).Decode(&body))
+				s.A.NotContains(body, "tags")
+				group.Name = body["name"].(string)
+				group.Description = body["description"].(string)
+			}
+			s.A.NoError(json.NewEncoder(rw).Encode(group))
+		case "/systemgroups/id/members":
+			rw.Write([]byte("[]"))
+		default:
+			s.Failf("unexpected request", "%s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceSystemGroup()
+
+	d := schema.TestResourceDataRaw(s.T(), r.Schema, map[string]interface{}{"name": "old"})
+	d.SetId("old")
+	s.A.NoError(d.Set("jc_id", "id"))
+	s.A.NoError(r.Read(d, config))
+
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "new",
+		"description": "renamed",
+	}), config)
+	s.A.NoError(err)
+	state, err := r.Apply(d.State(), diff, config)
+	s.A.NoError(err)
+	d = r.Data(state)
+
+	s.A.Equal("new", d.Id())
+	s.A.Equal("renamed", d.Get("description"))
+	s.A.Equal([]string{"tag"}, group.Tags)
+}
+
+func (s *ResourceSystemGroupSuite) TestSystemGroupRenameKeepsMembers() {
+	name, members := "old", []string{"a"}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systemgroups/id":
+			if r.Method == http.MethodPatch {
+				name = "new"
+			}
+			s.A.NoError(json.NewEncoder(rw).Encode(SystemGroup{ID: "id", Name: name}))
+		case "/systemgroups/id/members":
+			s.A.Equal(http.MethodGet, r.Method, "members must not be changed")
+			connections := []jcapiv2.GraphConnection{}
+			for _, id := range members {
+				connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+			}
+			s.A.NoError(json.NewEncoder(rw).Encode(connections))
+		case "/systems":
+			rw.Write([]byte(`{"totalCount": 0, "results": []}`))
+		default:
+			s.Failf("unexpected request", "%s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceSystemGroup()
+
+	d := schema.TestResourceDataRaw(s.T(), r.Schema, map[string]interface{}{"name": "old"})
+	d.SetId("old")
+	s.A.NoError(d.Set("jc_id", "id"))
+	s.A.NoError(r.Read(d, config))
+
+	// added by jumpcloud_system_group_membership after the refresh
+	members = append(members, "b")
+
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{"name": "new"}), config)
+	s.A.NoError(err)
+	state, err := r.Apply(d.State(), diff, config)
+	s.A.NoError(err)
+	d = r.Data(state)
+
+	s.A.Equal("new", d.Id())
+	s.A.ElementsMatch([]interface{}{"a", "b"}, d.Get("members").(*schema.Set).List())
+}
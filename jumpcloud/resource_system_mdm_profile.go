@@ -0,0 +1,168 @@
+package jumpcloud
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceSystemMDMProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a custom JumpCloud MDM configuration profile.",
+		Create:      resourceSystemMDMProfileCreate,
+		Read:        resourceSystemMDMProfileRead,
+		Update:      resourceSystemMDMProfileUpdate,
+		Delete:      resourceSystemMDMProfileDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the profile.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"payload_type": {
+				Description: "The payload type of the profile, e.g. `com.apple.wifi.managed`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"profile_content": {
+				Description:  "The raw content of the profile, a mobileconfig plist for macOS or custom XML for Windows.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateXMLContent,
+			},
+			"target_type": {
+				Description:  "What the profile is pushed to. Possible values: `system`, `system_group`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"system", "system_group"}, false),
+			},
+			"target_id": {
+				Description: "The ID of the system or system group the profile is pushed to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"signed": {
+				Description: "Whether JumpCloud signs the profile before pushing it.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+// validateXMLContent checks that a value is well-formed XML, which
+// covers XML plists as well
+func validateXMLContent(v interface{}, k string) (ws []string, es []error) {
+	decoder := xml.NewDecoder(strings.NewReader(v.(string)))
+	elements := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			es = append(es, fmt.Errorf("%q is not valid XML: %s", k, err))
+			return
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			elements++
+		}
+	}
+
+	if elements == 0 {
+		es = append(es, errors.New(k+" does not contain any XML element"))
+	}
+	return
+}
+
+func expandMDMProfile(d *schema.ResourceData) MDMProfile {
+	return MDMProfile{
+		Name:        d.Get("name").(string),
+		PayloadType: d.Get("payload_type").(string),
+		Content:     d.Get("profile_content").(string),
+		TargetType:  d.Get("target_type").(string),
+		TargetID:    d.Get("target_id").(string),
+		Signed:      d.Get("signed").(bool),
+	}
+}
+
+func resourceSystemMDMProfileCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var profile MDMProfile
+	payload := expandMDMProfile(d)
+	_, err := jumpCloudRequest(config, http.MethodPost, "/mdm/profiles", payload, &profile)
+	if err != nil {
+		return fmt.Errorf("error creating MDM profile %s: %s", payload.Name, err)
+	}
+
+	d.SetId(profile.ID)
+	return resourceSystemMDMProfileRead(d, m)
+}
+
+func resourceSystemMDMProfileRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var profile MDMProfile
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/mdm/profiles/"+d.Id(), nil, &profile)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", profile.Name); err != nil {
+		return err
+	}
+	if err := d.Set("payload_type", profile.PayloadType); err != nil {
+		return err
+	}
+	if err := d.Set("profile_content", profile.Content); err != nil {
+		return err
+	}
+	if err := d.Set("target_type", profile.TargetType); err != nil {
+		return err
+	}
+	if err := d.Set("target_id", profile.TargetID); err != nil {
+		return err
+	}
+	if err := d.Set("signed", profile.Signed); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceSystemMDMProfileUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodPut, "/mdm/profiles/"+d.Id(), expandMDMProfile(d), nil)
+	if err != nil {
+		return fmt.Errorf("error updating MDM profile %s: %s", d.Id(), err)
+	}
+	return resourceSystemMDMProfileRead(d, m)
+}
+
+func resourceSystemMDMProfileDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete, "/mdm/profiles/"+d.Id(), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting MDM profile %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,89 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMDMProfileContent = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>PayloadDisplayName</key>
+	<string>%s</string>
+</dict>
+</plist>
+`
+
+func TestAccSystemMDMProfile(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	systemID := os.Getenv("JUMPCLOUD_SYSTEM_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if systemID == "" {
+				t.Skip("JUMPCLOUD_SYSTEM_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemMDMProfile(rName, systemID, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_system_mdm_profile.test_profile", "name", rName),
+					resource.TestCheckResourceAttr("jumpcloud_system_mdm_profile.test_profile", "target_type", "system"),
+					resource.TestCheckResourceAttr("jumpcloud_system_mdm_profile.test_profile", "signed", "false"),
+				),
+			},
+			{
+				Config: testAccSystemMDMProfile(rName, systemID, true),
+				Check: resource.TestCheckResourceAttr("jumpcloud_system_mdm_profile.test_profile",
+					"signed", "true"),
+			},
+			{
+				ResourceName:      "jumpcloud_system_mdm_profile.test_profile",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSystemMDMProfile(name, systemID string, signed bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_system_mdm_profile" "test_profile" {
+			name            = "%[1]s"
+			payload_type    = "com.apple.ManagedClient.preferences"
+			profile_content = <<EOT
+%[4]sEOT
+			target_type     = "system"
+			target_id       = "%[2]s"
+			signed          = %[3]t
+		}`, name, systemID, signed, fmt.Sprintf(testMDMProfileContent, name),
+	)
+}
+
+func TestValidateXMLContent(t *testing.T) {
+	cases := []struct {
+		Content string
+		Valid   bool
+	}{
+		{fmt.Sprintf(testMDMProfileContent, "test"), true},
+		{`<SyncML><SyncBody/></SyncML>`, true},
+		{`<plist><dict></plist>`, false},
+		{`not xml at all`, false},
+		{``, false},
+	}
+
+	for _, c := range cases {
+		_, errs := validateXMLContent(c.Content, "profile_content")
+		assert.Equal(t, c.Valid, len(errs) == 0, c.Content)
+	}
+}
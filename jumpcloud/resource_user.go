@@ -1,23 +1,28 @@
 package jumpcloud
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 
 	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
-	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 func resourceUser() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceUserCreate,
 		Read:   resourceUserRead,
 		Update: resourceUserUpdate,
 		Delete: resourceUserDelete,
+		// a locked account can't be modified
+		CustomizeDiff: customdiff.All(userAccountLockedDiff, userMFADiff),
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:     schema.TypeString,
@@ -67,9 +72,84 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
-			"phone_number": {
+			// removed employee fields are cleared, so they can't be combined
+			// with jumpcloud_user_attribute_sync syncing the same fields
+			"department": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"company": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cost_center": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// unique across the users of the organization
+			"employee_identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"employee_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"job_title": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// JumpCloud locks accounts after too many failed logins,
+			// left unset the lock state is only tracked, setting it
+			// to false unlocks the account
+			"account_locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			// set by the sync of an external LDAP directory, empty otherwise
+			"external_dn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mfa": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"exclusion": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"exclusion_until": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsRFC3339Time,
+							// the API returns the time in UTC
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								oldTime, oldErr := time.Parse(time.RFC3339, old)
+								newTime, newErr := time.Parse(time.RFC3339, new)
+								return oldErr == nil && newErr == nil && oldTime.Equal(newTime)
+							},
+						},
+						"configured": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// sets keep the order of the numbers and addresses the API
+			// returns them in from showing up as drift
+			"phone_number": {
+				Type:     schema.TypeSet,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"number": {
@@ -89,6 +169,53 @@ func resourceUser() *schema.Resource {
 					},
 				},
 			},
+			"address": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"home",
+								"work",
+								"other",
+							}, false),
+						},
+						"street_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"locality": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"postal_code": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			// custom attributes, e.g. for SSO attribute mappings. Computed, so
+			// attributes set outside of Terraform are kept while the argument
+			// is left out; an explicitly empty map is still a change and
+			// removes all of them.
+			"attributes": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			// Currently, only the options necessary for our use case are implemented
 			// JumpCloud offers a lot more
 		},
@@ -96,12 +223,69 @@ func resourceUser() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 	}
+
+	// version 1 made phone_number a set
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceUserV0(r.Schema).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceUserStateUpgradeV0,
+		},
+	}
+	return r
+}
+
+// resourceUserV0 is the user of version 0 of the state, where phone_number
+// was a list
+func resourceUserV0(current map[string]*schema.Schema) *schema.Resource {
+	v0 := map[string]*schema.Schema{}
+	for k, v := range current {
+		v0[k] = v
+	}
+	phoneNumber := *current["phone_number"]
+	phoneNumber.Type = schema.TypeList
+	v0["phone_number"] = &phoneNumber
+	return &schema.Resource{Schema: v0}
+}
+
+// resourceUserStateUpgradeV0 turns the list of phone numbers into a set.
+// Both are arrays in the JSON state, so the state is kept as it is; the
+// legacy flatmap state is decoded by the type of version 0 beforehand.
+func resourceUserStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return rawState, nil
+}
+
+// userEmployeeFields maps the employee metadata fields of the resource to
+// the ones of the v1 user body
+var userEmployeeFields = map[string]string{
+	"department":          "department",
+	"company":             "company",
+	"cost_center":         "costCenter",
+	"employee_identifier": "employeeIdentifier",
+	"employee_type":       "employeeType",
+	"job_title":           "jobTitle",
+	"location":            "location",
+}
+
+// userConflictError explains the conflict JumpCloud responds with when
+// another user has the employee_identifier of d already
+func userConflictError(d *schema.ResourceData, err error) error {
+	var apiErr *jumpCloudAPIError
+	id := d.Get("employee_identifier").(string)
+	if id == "" || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		return err
+	}
+	return fmt.Errorf("employee_identifier %s is already used by another user, it must be unique: %w", id, err)
 }
 
-// We receive a v2config from the TF base code but need a v1config to continue. So, we take the only
-// preloaded element (the x-api-key) and populate the v1config with it.
-func convertV2toV1Config(v2config *jcapiv2.Configuration) *jcapiv1.Configuration {
+// We receive a v2config from the TF base code but need a v1config to continue. So, we take the
+// preloaded elements (the base path, x-api-key and x-org-id) and populate the v1config with them.
+func convertV2toV1Config(v2config *Meta) *jcapiv1.Configuration {
 	configv1 := jcapiv1.NewConfiguration()
+	configv1.BasePath = strings.TrimSuffix(v2config.BasePath, "/v2")
+	configv1.UserAgent = v2config.UserAgent
+	configv1.HTTPClient = v2config.HTTPClient
 	configv1.AddDefaultHeader("x-api-key", v2config.DefaultHeader["x-api-key"])
 	if v2config.DefaultHeader["x-org-id"] != "" {
 		configv1.AddDefaultHeader("x-org-id", v2config.DefaultHeader["x-org-id"])
@@ -110,15 +294,22 @@ func convertV2toV1Config(v2config *jcapiv2.Configuration) *jcapiv1.Configuration
 }
 
 func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
-	configv1 := convertV2toV1Config(m.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 
 	var phoneNumbers []jcapiv1.SystemuserputpostPhoneNumbers
-	phoneNumbersRaw, _ := json.Marshal(expandPhoneNumbers(d.Get("phone_number").([]interface{})))
+	phoneNumbersRaw, _ := json.Marshal(expandPhoneNumbers(d.Get("phone_number").(*schema.Set).List()))
 	if err := json.Unmarshal(phoneNumbersRaw, &phoneNumbers); err != nil {
 		return err
 	}
 
+	var addresses []jcapiv1.SystemuserputpostAddresses
+	addressesRaw, _ := json.Marshal(expandUserAddresses(d.Get("address").(*schema.Set).List()))
+	if err := json.Unmarshal(addressesRaw, &addresses); err != nil {
+		return err
+	}
+
 	payload := jcapiv1.Systemuserputpost{
 		Username:                    d.Get("username").(string),
 		Email:                       d.Get("email").(string),
@@ -130,26 +321,43 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 		LdapBindingUser:             d.Get("ldap_binding_user").(bool),
 		Sudo:                        d.Get("sudo").(bool),
 		Suspended:                   d.Get("suspended").(bool),
+		AccountLocked:               d.Get("account_locked").(bool),
 		PasswordNeverExpires:        d.Get("password_never_expires").(bool),
 		PhoneNumbers:                phoneNumbers,
+		Addresses:                   addresses,
+		Attributes:                  expandUserAttributes(d.Get("attributes").(map[string]interface{})),
+		Department:                  d.Get("department").(string),
+		Company:                     d.Get("company").(string),
+		CostCenter:                  d.Get("cost_center").(string),
+		EmployeeIdentifier:          d.Get("employee_identifier").(string),
+		EmployeeType:                d.Get("employee_type").(string),
+		JobTitle:                    d.Get("job_title").(string),
+		Location:                    d.Get("location").(string),
 	}
 	req := map[string]interface{}{
 		"body": payload,
 	}
-	returnstruc, _, err := client.SystemusersApi.SystemusersPost(context.TODO(),
+	returnstruc, res, err := client.SystemusersApi.SystemusersPost(ctx,
 		"", "", req)
 	if err != nil {
-		return err
+		return fmt.Errorf("error creating user %s: %w", payload.Username, userConflictError(d, apiError(res, err)))
 	}
 	d.SetId(returnstruc.Id)
+
+	if _, ok := d.GetOk("mfa"); ok {
+		if err := updateUserMFA(m.(*Meta), d); err != nil {
+			return err
+		}
+	}
 	return resourceUserRead(d, m)
 }
 
 func resourceUserRead(d *schema.ResourceData, m interface{}) error {
-	configv1 := convertV2toV1Config(m.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 
-	res, _, err := client.SystemusersApi.SystemusersGet(context.TODO(),
+	res, _, err := client.SystemusersApi.SystemusersGet(ctx,
 		d.Id(), "", "", nil)
 
 	// If the object does not exist in our infrastructure, we unset the ID
@@ -194,21 +402,62 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("suspended", res.Suspended); err != nil {
 		return err
 	}
+	if err := d.Set("account_locked", res.AccountLocked); err != nil {
+		return err
+	}
+	if err := d.Set("mfa", flattenUserMFA(res.Mfa)); err != nil {
+		return err
+	}
 	if err := d.Set("phone_number", flattenPhoneNumbers(res.PhoneNumbers)); err != nil {
 		return err
 	}
+	if err := d.Set("address", flattenUserAddresses(res.Addresses)); err != nil {
+		return err
+	}
+	if err := d.Set("external_dn", res.ExternalDn); err != nil {
+		return err
+	}
+	if err := d.Set("department", res.Department); err != nil {
+		return err
+	}
+	if err := d.Set("company", res.Company); err != nil {
+		return err
+	}
+	if err := d.Set("cost_center", res.CostCenter); err != nil {
+		return err
+	}
+	if err := d.Set("employee_identifier", res.EmployeeIdentifier); err != nil {
+		return err
+	}
+	if err := d.Set("employee_type", res.EmployeeType); err != nil {
+		return err
+	}
+	if err := d.Set("job_title", res.JobTitle); err != nil {
+		return err
+	}
+	if err := d.Set("location", res.Location); err != nil {
+		return err
+	}
+	if err := d.Set("attributes", flattenUserAttributes(res.Attributes)); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
-	configv1 := convertV2toV1Config(m.(*jcapiv2.Configuration))
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	configv1 := convertV2toV1Config(config)
 	client := jcapiv1.NewAPIClient(configv1)
 
-	var phoneNumbers []jcapiv1.SystemuserputPhoneNumbers
-	phoneNumbersRaw, _ := json.Marshal(expandPhoneNumbers(d.Get("phone_number").([]interface{})))
-	if err := json.Unmarshal(phoneNumbersRaw, &phoneNumbers); err != nil {
-		return err
+	// unlock first, the account can't be modified while it is locked
+	if d.HasChange("account_locked") && !d.Get("account_locked").(bool) {
+		res, err := client.SystemusersApi.SystemusersUnlock(ctx,
+			d.Id(), "", headerAccept, nil)
+		if err != nil {
+			return fmt.Errorf("error unlocking user %s: %w", d.Id(), apiError(res, err))
+		}
 	}
 
 	payload := jcapiv1.Systemuserput{
@@ -221,8 +470,15 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 		LdapBindingUser:             d.Get("ldap_binding_user").(bool),
 		Sudo:                        d.Get("sudo").(bool),
 		Suspended:                   d.Get("suspended").(bool),
+		AccountLocked:               d.Get("account_locked").(bool),
 		PasswordNeverExpires:        d.Get("password_never_expires").(bool),
-		PhoneNumbers:                phoneNumbers,
+		Department:                  d.Get("department").(string),
+		Company:                     d.Get("company").(string),
+		CostCenter:                  d.Get("cost_center").(string),
+		EmployeeIdentifier:          d.Get("employee_identifier").(string),
+		EmployeeType:                d.Get("employee_type").(string),
+		JobTitle:                    d.Get("job_title").(string),
+		Location:                    d.Get("location").(string),
 	}
 
 	// Dynamically set the display name if there's a change
@@ -240,24 +496,119 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 	req := map[string]interface{}{
 		"body": payload,
 	}
-	_, _, err := client.SystemusersApi.SystemusersPut(context.TODO(),
+	_, res, err := client.SystemusersApi.SystemusersPut(ctx,
 		d.Id(), "", "", req)
+	// the email may have changed
+	config.Settings.userCache.forget(d.Id())
 	if err != nil {
-		return err
+		return fmt.Errorf("error updating user %s: %w", d.Id(), userConflictError(d, apiError(res, err)))
+	}
+
+	// jcapiv1.Systemuserput omits empty strings, so removed employee
+	// fields are cleared by a request of their own
+	cleared := map[string]string{}
+	for key, field := range userEmployeeFields {
+		if d.HasChange(key) && d.Get(key).(string) == "" {
+			cleared[field] = ""
+		}
+	}
+	if len(cleared) > 0 {
+		if _, err := jumpCloudV1Request(config, http.MethodPut, "/systemusers/"+d.Id(), cleared, nil); err != nil {
+			return fmt.Errorf("error clearing the employee fields of user %s: %s", d.Id(), err)
+		}
+	}
+
+	// jcapiv1.Systemuserput omits false, so lifting a suspension
+	// needs a request of its own
+	if d.HasChange("suspended") && !d.Get("suspended").(bool) {
+		_, err := jumpCloudV1Request(config, http.MethodPut, "/systemusers/"+d.Id(),
+			map[string]bool{"suspended": false}, nil)
+		if err != nil {
+			return fmt.Errorf("error lifting the suspension of user %s: %s", d.Id(), err)
+		}
+	}
+
+	// jcapiv1.Systemuserput omits an empty list, so the attributes are
+	// replaced by a request of their own to be able to remove all of them
+	if d.HasChange("attributes") {
+		body := map[string][]interface{}{
+			"attributes": expandUserAttributes(d.Get("attributes").(map[string]interface{})),
+		}
+		if _, err := jumpCloudV1Request(config, http.MethodPut, "/systemusers/"+d.Id(), body, nil); err != nil {
+			return fmt.Errorf("error updating the attributes of user %s: %s", d.Id(), err)
+		}
+	}
+
+	// jcapiv1.Systemuserput omits empty lists as well, so the phone numbers
+	// and addresses are replaced by a request of their own
+	if d.HasChanges("phone_number", "address") {
+		body := map[string]interface{}{
+			"phoneNumbers": expandPhoneNumbers(d.Get("phone_number").(*schema.Set).List()),
+			"addresses":    expandUserAddresses(d.Get("address").(*schema.Set).List()),
+		}
+		if _, err := jumpCloudV1Request(config, http.MethodPut, "/systemusers/"+d.Id(), body, nil); err != nil {
+			return fmt.Errorf("error updating the phone numbers and addresses of user %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("mfa") {
+		if err := updateUserMFA(config, d); err != nil {
+			return err
+		}
 	}
 	return resourceUserRead(d, m)
 }
 
+// userAccountLockedDiff rejects changes to a user whose account stays
+// locked, JumpCloud refuses to modify locked accounts
+func userAccountLockedDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	oldLocked, newLocked := d.GetChange("account_locked")
+	if !oldLocked.(bool) || !newLocked.(bool) {
+		return nil
+	}
+
+	for _, key := range d.GetChangedKeysPrefix("") {
+		if key != "account_locked" {
+			return fmt.Errorf("user %s is locked and can't be modified, "+
+				"set account_locked to false to unlock it along with the change", d.Get("username"))
+		}
+	}
+	return nil
+}
+
+// userMFADiff validates a changed MFA exclusion
+func userMFADiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("mfa") {
+		return nil
+	}
+	return checkMFAExclusion(expandUserMFA(d.Get("mfa").([]interface{})), time.Now())
+}
+
+// updateUserMFA sends the mfa block of d, jcapiv1.Mfa can't lift
+// an exclusion since it omits false
+func updateUserMFA(config *Meta, d *schema.ResourceData) error {
+	body := UserMFAPut{MFA: expandUserMFA(d.Get("mfa").([]interface{}))}
+	_, err := jumpCloudV1Request(config, http.MethodPut, "/systemusers/"+d.Id(), body, nil)
+	if err != nil {
+		return fmt.Errorf("error updating the MFA configuration of user %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
 func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
-	configv1 := convertV2toV1Config(m.(*jcapiv2.Configuration))
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
 	client := jcapiv1.NewAPIClient(configv1)
 
-	res, _, err := client.SystemusersApi.SystemusersDelete(context.TODO(),
+	_, res, err := client.SystemusersApi.SystemusersDelete(ctx,
 		d.Id(), "", headerAccept, nil)
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error deleting user group:%s; response = %+v", err, res)
+		return fmt.Errorf("error deleting user %s: %w", d.Id(), apiError(res, err))
 	}
+	m.(*Meta).Settings.userCache.forget(d.Id())
 	d.SetId("")
 	return nil
 }
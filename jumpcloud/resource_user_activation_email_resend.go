@@ -0,0 +1,80 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceUserActivationEmailResend() *schema.Resource {
+	return &schema.Resource{
+		Description: "Resends the activation email of a JumpCloud user. Changing `triggers` sends the email again.",
+		Create:      resourceUserActivationEmailResendCreate,
+		Read:        resourceUserActivationEmailResendRead,
+		Delete:      resourceUserActivationEmailResendDelete,
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "The ID of the `resource_user` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary values that, when changed, resend the activation email.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"last_sent_at": {
+				Description: "The RFC 3339 timestamp the activation email was last sent at.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceUserActivationEmailResendCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	userID := d.Get("user_id").(string)
+
+	_, err := jumpCloudV1Request(config, http.MethodPost, "/systemusers/"+userID+"/resend/email", nil, nil)
+	if isNotFound(err) {
+		return fmt.Errorf("error resending activation email of user %s: user not found", userID)
+	}
+	if err != nil {
+		return fmt.Errorf("error resending activation email of user %s: %s", userID, err)
+	}
+
+	d.SetId(userID)
+	if err := d.Set("last_sent_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	return resourceUserActivationEmailResendRead(d, m)
+}
+
+func resourceUserActivationEmailResendRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	ok, err := jumpCloudV1Request(config, http.MethodGet, "/systemusers/"+d.Id(), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// the user is gone
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceUserActivationEmailResendDelete(d *schema.ResourceData, m interface{}) error {
+	// a sent email can't be taken back
+	d.SetId("")
+	return nil
+}
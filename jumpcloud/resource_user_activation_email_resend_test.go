@@ -0,0 +1,50 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccUserActivationEmailResend(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserActivationEmailResend(rName, "1"),
+				Check:  resource.TestCheckResourceAttrSet("jumpcloud_user_activation_email_resend.test_resend", "last_sent_at"),
+			},
+			{
+				Config: testAccUserActivationEmailResend(rName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_activation_email_resend.test_resend", "triggers.email", "2"),
+					resource.TestCheckResourceAttrSet("jumpcloud_user_activation_email_resend.test_resend", "last_sent_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserActivationEmailResend(name, trigger string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username  = "%[1]s"
+			email     = "%[1]s@testorg.com"
+			firstname = "Firstname"
+			lastname  = "Lastname"
+		}
+
+		resource "jumpcloud_user_activation_email_resend" "test_resend" {
+			user_id = jumpcloud_user.test_user.id
+			triggers = {
+				email = "%[2]s"
+			}
+		}`, name, trigger,
+	)
+}
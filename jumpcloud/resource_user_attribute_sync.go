@@ -0,0 +1,185 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// userProfileFields are the string fields of a user that can be synced
+// by name, any other name is synced as a custom attribute
+var userProfileFields = []string{
+	"company",
+	"costCenter",
+	"department",
+	"description",
+	"displayname",
+	"employeeIdentifier",
+	"employeeType",
+	"firstname",
+	"jobTitle",
+	"lastname",
+	"location",
+	"middlename",
+}
+
+func resourceUserAttributeSync() *schema.Resource {
+	return &schema.Resource{
+		Description: "Keeps a set of attributes of a JumpCloud user in sync with an external source, e.g. an HR system. " +
+			"Attributes that aren't listed are left untouched.",
+		Create: resourceUserAttributeSyncUpdate,
+		Read:   resourceUserAttributeSyncRead,
+		Update: resourceUserAttributeSyncUpdate,
+		Delete: resourceUserAttributeSyncDelete,
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "The ID of the `resource_user` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attributes": {
+				Description: "The attributes to sync, by JumpCloud attribute name, e.g. `department` or `jobTitle`. " +
+					"Names that aren't user profile fields are synced as custom attributes.",
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// expandUserAttributeSync builds the update of the given attributes on top
+// of the user's current custom attributes
+func expandUserAttributeSync(user jcapiv1.Systemuserreturn, attributes map[string]interface{}) (jcapiv1.Systemuserput, error) {
+	var payload jcapiv1.Systemuserput
+
+	profile := map[string]interface{}{}
+	custom := user.Attributes
+	customChanged := false
+	for name, value := range attributes {
+		if stringInSlice(name, userProfileFields) {
+			profile[name] = value
+			continue
+		}
+		custom = setUserAttribute(custom, name, value.(string))
+		customChanged = true
+	}
+
+	raw, err := json.Marshal(profile)
+	if err != nil {
+		return payload, err
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return payload, err
+	}
+
+	if customChanged {
+		payload.Attributes = custom
+	}
+	return payload, nil
+}
+
+// flattenUserAttributeSync returns the current values of the given
+// attribute names
+func flattenUserAttributeSync(user jcapiv1.Systemuserreturn, names []string) (map[string]interface{}, error) {
+	raw, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	attributes := map[string]interface{}{}
+	for _, name := range names {
+		if stringInSlice(name, userProfileFields) {
+			if v, ok := fields[name].(string); ok {
+				attributes[name] = v
+			}
+			continue
+		}
+		if v, ok := findUserAttribute(user.Attributes, name); ok {
+			attributes[name] = v
+		}
+	}
+	return attributes, nil
+}
+
+func resourceUserAttributeSyncRead(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	user, _, err := client.SystemusersApi.SystemusersGet(ctx,
+		d.Get("user_id").(string), "", "", nil)
+	if err != nil {
+		// see resourceUserRead, a missing user results in an EOF error
+		if err.Error() == "EOF" {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	var names []string
+	for name := range d.Get("attributes").(map[string]interface{}) {
+		names = append(names, name)
+	}
+
+	attributes, err := flattenUserAttributeSync(user, names)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("attributes", attributes); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resourceUserAttributeSyncUpdate is also used on create, the user
+// already exists
+func resourceUserAttributeSyncUpdate(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+	userID := d.Get("user_id").(string)
+
+	// the custom attributes are merged, see updateUserAttributes
+	userAttributesMutex.Lock(userID)
+	defer userAttributesMutex.Unlock(userID)
+
+	user, res, err := client.SystemusersApi.SystemusersGet(ctx,
+		userID, "", "", nil)
+	if err != nil {
+		return fmt.Errorf("error reading user %s: %w", userID, apiError(res, err))
+	}
+
+	payload, err := expandUserAttributeSync(user, d.Get("attributes").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	req := map[string]interface{}{
+		"body": payload,
+	}
+	_, res, err = client.SystemusersApi.SystemusersPut(ctx,
+		userID, "", "", req)
+	if err != nil {
+		return fmt.Errorf("error syncing attributes of user %s: %w", userID, apiError(res, err))
+	}
+
+	d.SetId(userID)
+	return resourceUserAttributeSyncRead(d, m)
+}
+
+func resourceUserAttributeSyncDelete(d *schema.ResourceData, m interface{}) error {
+	// the synced values are left on the user
+	d.SetId("")
+	return nil
+}
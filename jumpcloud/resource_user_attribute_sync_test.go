@@ -0,0 +1,87 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccUserAttributeSync(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserAttributeSync(rName, "Engineering"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_attribute_sync.test_sync", "attributes.department", "Engineering"),
+					resource.TestCheckResourceAttr("jumpcloud_user_attribute_sync.test_sync", "attributes.hrId", "E-1001"),
+				),
+			},
+			{
+				Config: testAccUserAttributeSync(rName, "Sales"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_attribute_sync.test_sync",
+					"attributes.department", "Sales"),
+			},
+		},
+	})
+}
+
+func testAccUserAttributeSync(name, department string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username  = "%[1]s"
+			email     = "%[1]s@testorg.com"
+			firstname = "Firstname"
+			lastname  = "Lastname"
+		}
+
+		resource "jumpcloud_user_attribute_sync" "test_sync" {
+			user_id = jumpcloud_user.test_user.id
+			attributes = {
+				department = "%[2]s"
+				jobTitle   = "Engineer"
+				hrId       = "E-1001"
+			}
+		}`, name, department,
+	)
+}
+
+func TestUserAttributeSync(t *testing.T) {
+	user := jcapiv1.Systemuserreturn{
+		Department: "Sales",
+		Location:   "Copenhagen",
+		Attributes: []interface{}{
+			map[string]interface{}{"name": "hrId", "value": "E-1"},
+			map[string]interface{}{"name": "badge", "value": "42"},
+		},
+	}
+
+	payload, err := expandUserAttributeSync(user, map[string]interface{}{
+		"department": "Engineering",
+		"hrId":       "E-2",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Engineering", payload.Department)
+	assert.Empty(t, payload.Location)
+	value, _ := findUserAttribute(payload.Attributes, "hrId")
+	assert.Equal(t, "E-2", value)
+	value, _ = findUserAttribute(payload.Attributes, "badge")
+	assert.Equal(t, "42", value)
+
+	payload, err = expandUserAttributeSync(user, map[string]interface{}{"jobTitle": "Engineer"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Engineer", payload.JobTitle)
+	assert.Nil(t, payload.Attributes)
+
+	attributes, err := flattenUserAttributeSync(user, []string{"department", "badge", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"department": "Sales", "badge": "42"}, attributes)
+}
@@ -0,0 +1,220 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceUserCustomFields() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing the custom fields the users of the organization can have. " +
+			"There is a single set of custom fields per organization.",
+		Create:        resourceUserCustomFieldsUpdate,
+		Read:          resourceUserCustomFieldsRead,
+		Update:        resourceUserCustomFieldsUpdate,
+		Delete:        resourceUserCustomFieldsDelete,
+		CustomizeDiff: userCustomFieldsDiff,
+		Schema: map[string]*schema.Schema{
+			"fields": {
+				Description: "The custom fields of the organization.",
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the field.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"type": {
+							Description:  "The type of the field, one of `string`, `number`, `boolean` or `date`. Defaults to `string`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "string",
+							ValidateFunc: validation.StringInSlice([]string{"string", "number", "boolean", "date"}, false),
+						},
+						"required": {
+							Description: "Whether every user must have a value for the field.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"searchable": {
+							Description: "Whether users can be searched by the field.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func expandUserCustomFields(fields []interface{}) UserCustomFields {
+	out := UserCustomFields{Fields: []UserCustomField{}}
+	for _, v := range fields {
+		field := v.(map[string]interface{})
+		out.Fields = append(out.Fields, UserCustomField{
+			Name:       field["name"].(string),
+			Type:       field["type"].(string),
+			Required:   field["required"].(bool),
+			Searchable: field["searchable"].(bool),
+		})
+	}
+	return out
+}
+
+func flattenUserCustomFields(fields []UserCustomField) []interface{} {
+	out := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, map[string]interface{}{
+			"name":       field.Name,
+			"type":       field.Type,
+			"required":   field.Required,
+			"searchable": field.Searchable,
+		})
+	}
+	return out
+}
+
+// removedCustomFields returns the names of the fields in old that aren't in new
+func removedCustomFields(old, new []interface{}) []string {
+	kept := map[string]bool{}
+	for _, v := range new {
+		kept[v.(map[string]interface{})["name"].(string)] = true
+	}
+
+	var removed []string
+	for _, v := range old {
+		name := v.(map[string]interface{})["name"].(string)
+		if !kept[name] {
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
+
+// countUsersWithAttributes counts the users with a value for each of names
+func countUsersWithAttributes(users []jcapiv1.Systemuserreturn, names []string) map[string]int {
+	counts := map[string]int{}
+	for _, user := range users {
+		for _, name := range names {
+			if value, ok := findUserAttribute(user.Attributes, name); ok && value != "" {
+				counts[name]++
+			}
+		}
+	}
+	return counts
+}
+
+// userCustomFieldsDiff fails the plan if fields that users still have
+// a value for are about to be removed. The users are only listed if
+// fields are removed.
+func userCustomFieldsDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("fields") {
+		return nil
+	}
+
+	old, new := d.GetChange("fields")
+	return checkRemovedCustomFields(m, removedCustomFields(old.([]interface{}), new.([]interface{})))
+}
+
+// checkRemovedCustomFields fails if users still have a value for any of
+// the removed fields
+func checkRemovedCustomFields(m interface{}, removed []string) error {
+	if len(removed) == 0 {
+		return nil
+	}
+
+	config := m.(*Meta)
+	client := jcapiv1.NewAPIClient(convertV2toV1Config(config))
+	ctx := requestContext(config)
+	counts := map[string]int{}
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		var users jcapiv1.Systemuserslist
+		var err error
+		users, res, err = client.SystemusersApi.SystemusersList(ctx, "", headerAccept, map[string]interface{}{
+			"limit":  int32(pageSize),
+			"skip":   skip,
+			"fields": "attributes",
+		})
+		for name, count := range countUsersWithAttributes(users.Results, removed) {
+			counts[name] += count
+		}
+		return res, len(users.Results), err
+	})
+	if err != nil {
+		return fmt.Errorf("error listing users: %w", apiError(res, err))
+	}
+
+	var inUse []string
+	for _, name := range removed {
+		if counts[name] > 0 {
+			inUse = append(inUse, fmt.Sprintf("%s (%d users)", name, counts[name]))
+		}
+	}
+	if len(inUse) > 0 {
+		return fmt.Errorf("custom fields %s still have values, clear them on the users before removing the fields",
+			strings.Join(inUse, ", "))
+	}
+	return nil
+}
+
+func resourceUserCustomFieldsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var fields UserCustomFields
+	_, err := jumpCloudRequest(config, http.MethodGet, "/customfields/users", nil, &fields)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("fields", flattenUserCustomFields(fields.Fields)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resourceUserCustomFieldsUpdate is also used on create, the set of
+// custom fields is replaced as a whole
+func resourceUserCustomFieldsUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodPut, "/customfields/users",
+		expandUserCustomFields(d.Get("fields").([]interface{})), nil)
+	if err != nil {
+		return fmt.Errorf("error updating user custom fields: %s", err)
+	}
+
+	d.SetId("user_custom_fields")
+	return resourceUserCustomFieldsRead(d, m)
+}
+
+func resourceUserCustomFieldsDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	// destroying removes all fields, plans don't run CustomizeDiff then
+	removed := removedCustomFields(d.Get("fields").([]interface{}), nil)
+	if err := checkRemovedCustomFields(m, removed); err != nil {
+		return err
+	}
+
+	_, err := jumpCloudRequest(config, http.MethodPut, "/customfields/users",
+		UserCustomFields{Fields: []UserCustomField{}}, nil)
+	if err != nil {
+		return fmt.Errorf("error removing user custom fields: %s", err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,126 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccUserCustomFields(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserCustomFields(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_custom_fields.test_fields", "fields.#", "2"),
+					resource.TestCheckResourceAttr("jumpcloud_user_custom_fields.test_fields", "fields.1.type", "number"),
+				),
+			},
+			{
+				Config: testAccUserCustomFields(rName, false),
+				Check:  resource.TestCheckResourceAttr("jumpcloud_user_custom_fields.test_fields", "fields.#", "1"),
+			},
+		},
+	})
+}
+
+func testAccUserCustomFields(name string, withCostCenter bool) string {
+	costCenter := ""
+	if withCostCenter {
+		costCenter = fmt.Sprintf(`
+			fields {
+				name = "%sCostCenter"
+				type = "number"
+			}`, name)
+	}
+
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_custom_fields" "test_fields" {
+			fields {
+				name       = "%sDivision"
+				searchable = true
+			}
+			%s
+		}`, name, costCenter,
+	)
+}
+
+func TestUserCustomFields(t *testing.T) {
+	old := []interface{}{
+		map[string]interface{}{"name": "division"},
+		map[string]interface{}{"name": "costCenter"},
+		map[string]interface{}{"name": "badge"},
+	}
+	new := []interface{}{
+		map[string]interface{}{"name": "division"},
+	}
+	removed := removedCustomFields(old, new)
+	assert.Equal(t, []string{"costCenter", "badge"}, removed)
+
+	users := []jcapiv1.Systemuserreturn{
+		{Attributes: []interface{}{map[string]interface{}{"name": "costCenter", "value": "42"}}},
+		{Attributes: []interface{}{map[string]interface{}{"name": "costCenter", "value": ""}}},
+		{Attributes: []interface{}{
+			map[string]interface{}{"name": "costCenter", "value": "7"},
+			map[string]interface{}{"name": "division", "value": "sales"},
+		}},
+	}
+	assert.Equal(t, map[string]int{"costCenter": 2}, countUsersWithAttributes(users, removed))
+}
+
+func TestUserCustomFieldsRemoveInUse(t *testing.T) {
+	var updated bool
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/systemusers":
+			assert.NoError(t, json.NewEncoder(rw).Encode(jcapiv1.Systemuserslist{Results: []jcapiv1.Systemuserreturn{
+				{Attributes: []interface{}{map[string]interface{}{"name": "costCenter", "value": "42"}}},
+			}}))
+		case "/v2/customfields/users":
+			if r.Method == http.MethodPut {
+				updated = true
+			}
+			assert.NoError(t, json.NewEncoder(rw).Encode(UserCustomFields{Fields: []UserCustomField{
+				{Name: "division", Type: "string"}, {Name: "costCenter", Type: "number"},
+			}}))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/v2"
+	r := resourceUserCustomFields()
+
+	d := r.TestResourceData()
+	d.SetId("user_custom_fields")
+	assert.NoError(t, r.Read(d, config))
+
+	_, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"fields": []interface{}{map[string]interface{}{"name": "division"}},
+	}), config)
+	assert.EqualError(t, err,
+		"custom fields costCenter (1 users) still have values, clear them on the users before removing the fields")
+
+	// destroying removes all fields
+	err = r.Delete(d, config)
+	assert.EqualError(t, err,
+		"custom fields costCenter (1 users) still have values, clear them on the users before removing the fields")
+	assert.False(t, updated)
+}
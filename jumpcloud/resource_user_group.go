@@ -3,12 +3,18 @@ package jumpcloud
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"io"
+	"log"
 	"net/http"
-	"slices"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
 )
 
 func resourceUserGroup() *schema.Resource {
@@ -17,91 +23,206 @@ func resourceUserGroup() *schema.Resource {
 		Read:   resourceUserGroupRead,
 		Update: resourceUserGroupUpdate,
 		Delete: resourceUserGroupDelete,
+		// member syncs page through the group and its users, which takes
+		// a while for large groups
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: customdiff.All(
+			userGroupMembershipExpiryDiff,
+			userGroupPosixDiff,
+			userGroupAuthenticationDiff,
+		),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"attributes": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Computed:      true,
+				Description:   "`posix_groups` in the `gid:name` form.",
+				Deprecated:    "use posix_gid and posix_name instead",
+				ConflictsWith: []string{"posix_gid", "posix_name"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"posix_groups": {
 							Type: schema.TypeString,
-							// PosixGroups cannot be edited after group creation.
-							ForceNew: true,
+							// PosixGroups cannot be edited after group creation,
+							// see userGroupPosixDiff
 							Optional: true,
 						},
-						// enable_samba has a more complicated lifecycle,
-						// Commenting out for now as it is ignored in CRU by the JCAPI
-						// From Jumpcloud UI:
-						// Samba Authentication must be configured in the
-						// JumpCloud LDAP Directory and LDAP sync must be enabled
-						// on this group before Samba Authentication can be enabled.
-						// "enable_samba": {
-						// 	Type:     schema.TypeBool,
-						// 	Optional: true,
-						// },
+					},
+				},
+			},
+			"posix_gid": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				// PosixGroups cannot be edited after group creation,
+				// see userGroupPosixDiff
+				ValidateFunc:  validation.IntBetween(minPosixGID, maxPosixGID),
+				RequiredWith:  []string{"posix_name"},
+				ConflictsWith: []string{"attributes"},
+				Description:   "The POSIX group ID of the group, between 1 and 2147483647.",
+			},
+			"posix_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				RequiredWith:  []string{"posix_gid"},
+				ConflictsWith: []string{"attributes"},
+				Description:   "The POSIX group name of the group. Required when `posix_gid` is set.",
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A description of the group, at most 1024 characters.",
+				ValidateFunc: validateGroupDescription,
+			},
+			"enable_ldap_user_authentication": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow the members of this group to authenticate against JumpCloud LDAP. Requires an LDAP server to be associated with the group.",
+			},
+			"ldap_server_associated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether an LDAP server is associated with the group, which `enable_ldap_user_authentication` " +
+					"requires. Only checked while LDAP user authentication is enabled.",
+			},
+			"enable_samba": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Allow the members of this group to authenticate with Samba. Requires " +
+					"`enable_ldap_user_authentication` and Samba to be configured on the JumpCloud LDAP server.",
+			},
+			"sudo": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Sudo access of the members of this group on the systems bound to them. Without this block, sudo is disabled.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Grant the members sudo.",
+						},
+						"without_password": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Let the members use sudo without entering their password. Requires `enabled`.",
+						},
 					},
 				},
 			},
 			"members": {
-				Type:             schema.TypeList,
-				Optional:         true,
-				Computed:         true,
-				DiffSuppressFunc: EqualIgnoringOrder,
-				Description:      "This is a set of user emails associated with this group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "This is a set of user emails associated with this group",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"unresolved_members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "The IDs of members that are no JumpCloud user anymore, e.g. users deleted while " +
+					"still in the group. They are left in the group and not part of `members`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"membership_expiry": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Description:  "A map of member emails to the RFC 3339 timestamp their membership expires at. Expired members are removed from the group.",
+				ValidateFunc: validateMembershipExpiry,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary values that, when changed, force the full membership of the group to be reconciled against `members`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: userGroupImporter,
 		},
 	}
 }
 
-func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+// jumpCloudIDRegexp matches the IDs JumpCloud assigns to its objects
+var jumpCloudIDRegexp = regexp.MustCompile(`^[0-9a-f]{24}$`)
 
-	body := jcapiv2.UserGroupPost{Name: d.Get("name").(string)}
+// userGroupImporter imports a user group by its ID or, for anything that
+// doesn't look like one, by its name
+func userGroupImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if jumpCloudIDRegexp.MatchString(d.Id()) {
+		return []*schema.ResourceData{d}, nil
+	}
 
-	// For Attributes.PosixGroups, only the first member of the slice
-	// is considered by the JCAPI
-	if attr, ok := expandAttributes(d.Get("attributes")); ok {
-		body.Attributes = attr
+	id, err := userGroupIDByName(m.(*Meta), d.Id())
+	if err != nil {
+		return nil, err
 	}
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
 
-	req := map[string]interface{}{
-		"body": body,
+func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutCreate, func(ctx context.Context) error {
+		return userGroupCreate(ctx, d, config)
+	})
+}
+
+func userGroupCreate(ctx context.Context, d *schema.ResourceData, config *Meta) error {
+
+	body := UserGroupPost{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Attributes:  userGroupRequestAttributes(d),
 	}
-	group, res, err := client.UserGroupsApi.GroupsUserPost(context.TODO(),
-		"", headerAccept, req)
+
+	// jcapiv2.UserGroupPost can't carry all of the group's attributes,
+	// so the group is created through the HTTP API directly
+	var group UserGroup
+	_, err := jumpCloudRequestContext(ctx, config, http.MethodPost, "/usergroups", body, &group)
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error creating user group %s: %s - response = %+v",
-			(req["body"].(jcapiv2.UserGroupPost)).Name, err, res)
+		return fmt.Errorf("error creating user group %s: %w", body.Name, err)
 	}
 
-	d.SetId(group.Id)
+	d.SetId(group.ID)
 
-	memberIds, err := userEmailsToIDs(config, d.Get("members").([]interface{}))
+	members, _ := unexpiredMembers(d.Get("members").(*schema.Set).List(),
+		d.Get("membership_expiry").(map[string]interface{}), time.Now())
+	memberIds, err := userEmailsToIDs(ctx, config, members)
 	if err != nil {
 		return err
 	}
 
-	for _, memberId := range memberIds {
-		err := manageGroupMember(client, d, memberId, "add")
-		if err != nil {
-			return err
-		}
+	if err := manageGroupMembers(ctx, config, d, memberIds, "add"); err != nil {
+		return err
 	}
-	return resourceUserGroupRead(d, m)
+	return userGroupRead(ctx, d, config)
 }
 
 // resourceUserGroupRead uses a helper function that consumes the
@@ -109,9 +230,14 @@ func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) error {
 // as they are required for resourceUserGroupUpdate and the current
 // implementation of the JC SDK doesn't support their retrieval
 func resourceUserGroupRead(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutRead, func(ctx context.Context) error {
+		return userGroupRead(ctx, d, config)
+	})
+}
 
-	group, ok, err := userGroupReadHelper(config, d.Id())
+func userGroupRead(ctx context.Context, d *schema.ResourceData, config *Meta) error {
+	group, ok, err := userGroupReadHelper(ctx, config, d.Id())
 	if err != nil {
 		return err
 	}
@@ -126,43 +252,78 @@ func resourceUserGroupRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("name", group.Name); err != nil {
 		return err
 	}
-	if err := d.Set("attributes", flattenAttributes(&group.Attributes)); err != nil {
+	if err := d.Set("description", group.Description); err != nil {
+		return err
+	}
+	if err := d.Set("attributes", flattenAttributes(&group.Attributes.UserGroupAttributes)); err != nil {
+		return err
+	}
+	if err := d.Set("enable_ldap_user_authentication", group.Attributes.EnableLdapUserAuthentication); err != nil {
+		return err
+	}
+	ldapServerAssociated := false
+	if group.Attributes.EnableLdapUserAuthentication {
+		if ldapServerAssociated, err = userGroupHasLdapServer(ctx, config, d.Id()); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("ldap_server_associated", ldapServerAssociated); err != nil {
+		return err
+	}
+	if err := d.Set("enable_samba", group.Attributes.SambaEnabled); err != nil {
+		return err
+	}
+	if err := d.Set("sudo", flattenUserGroupSudo(group.Attributes.Sudo, d.Get("sudo").([]interface{}))); err != nil {
+		return err
+	}
+	// only the first posix group is considered by the JCAPI
+	var posixGroup jcapiv2.UserGroupAttributesPosixGroups
+	if len(group.Attributes.PosixGroups) > 0 {
+		posixGroup = group.Attributes.PosixGroups[0]
+	}
+	if err := d.Set("posix_gid", int(posixGroup.Id)); err != nil {
+		return err
+	}
+	if err := d.Set("posix_name", posixGroup.Name); err != nil {
 		return err
 	}
 
-	client := jcapiv2.NewAPIClient(config)
-	memberIDs, err := getUserGroupMemberIDs(client, d.Id())
+	memberIDs, err := getUserGroupMemberIDs(ctx, config, d.Id())
 	if err != nil {
 		return err
 	}
-	memberEmails, err := userIDsToEmails(config, memberIDs)
+	memberEmails, unresolved, err := userIDsToEmails(ctx, config, memberIDs)
 	if err != nil {
 		return err
 	}
+	if len(unresolved) > 0 {
+		log.Printf("[WARN] members %s of user group %s are no JumpCloud users anymore",
+			strings.Join(unresolved, ", "), d.Id())
+	}
 	if err := d.Set("members", memberEmails); err != nil {
 		return err
 	}
+	if err := d.Set("unresolved_members", unresolved); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func userGroupReadHelper(config *jcapiv2.Configuration, id string) (ug *UserGroup,
+func userGroupReadHelper(ctx context.Context, config *Meta, id string) (ug *UserGroup,
 	ok bool, err error) {
 
-	req, err := http.NewRequest(http.MethodGet,
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
 		config.BasePath+"/usergroups/"+id, nil)
 	if err != nil {
 		return
 	}
 
-	req.Header.Add("x-api-key", config.DefaultHeader["x-api-key"])
-	if config.DefaultHeader["x-org-id"] != "" {
-		req.Header.Add("x-org-id", config.DefaultHeader["x-org-id"])
-	}
+	addDefaultHeaders(req, config)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := config.Settings.httpClient.Do(req)
 	if err != nil {
 		return
 	}
@@ -171,78 +332,282 @@ func userGroupReadHelper(config *jcapiv2.Configuration, id string) (ug *UserGrou
 	if res.StatusCode == http.StatusNotFound {
 		return
 	}
+	if res.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(res.Body)
+		return nil, false, newAPIError(res, body)
+	}
 
 	ok = true
 	err = json.NewDecoder(res.Body).Decode(&ug)
 	return
 }
 
+// userGroupIDByName looks up the ID of the user group called name.
+// Group names aren't unique in JumpCloud, so an ambiguous name is an error.
+func userGroupIDByName(config *Meta, name string) (string, error) {
+	var groups []UserGroup
+	_, err := jumpCloudRequest(config, http.MethodGet,
+		"/usergroups?limit=100&filter="+url.QueryEscape("name:eq:"+name), nil, &groups)
+	if err != nil {
+		return "", fmt.Errorf("error listing user groups named %s: %s", name, err)
+	}
+
+	var ids []string
+	for _, group := range groups {
+		// the filter isn't case sensitive
+		if group.Name == name {
+			ids = append(ids, group.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no user group found with name: %s", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d user groups are named %s (%s), refer to the group by ID instead",
+			len(ids), name, strings.Join(ids, ", "))
+	}
+}
+
 func resourceUserGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutUpdate, func(ctx context.Context) error {
+		return userGroupUpdate(ctx, d, config)
+	})
+}
+
+func userGroupUpdate(ctx context.Context, d *schema.ResourceData, config *Meta) error {
 
-	body := jcapiv2.UserGroupPost{Name: d.Get("name").(string)}
-	if attr, ok := expandAttributes(d.Get("attributes")); ok {
-		body.Attributes = attr
-	} else {
-		return errors.New("unable to update, attributes not expandable")
+	// a change to triggers alone only re-syncs the membership below
+	if d.HasChange("name") || d.HasChange("description") || d.HasChange("attributes") ||
+		d.HasChange("enable_ldap_user_authentication") || d.HasChange("enable_samba") || d.HasChange("sudo") {
+		if err := userGroupUpdateHelper(ctx, d, config); err != nil {
+			return err
+		}
 	}
 
-	req := map[string]interface{}{
-		"body": body,
+	if d.HasChange("triggers") {
+		log.Printf("[INFO] triggers of user group %s changed, reconciling its full membership", d.Id())
 	}
-	// behaves like PUT, will fail if
-	// attributes.posixGroups isn't sent, see GODOC
-	_, res, err := client.UserGroupsApi.GroupsUserPatch(context.TODO(),
-		d.Id(), "", headerAccept, req)
+
+	// the current members are always fetched from the API, so out-of-band
+	// changes are reconciled along with the configured ones
+
+	currentIDs, err := getUserGroupMemberIDs(ctx, config, d.Id())
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error deleting user group:%s; response = %+v", err, res)
+		return err
+	}
+	// members without a user have no email to be configured by, so
+	// they are neither removed nor counted against the removal limit
+	unresolved := schema.NewSet(schema.HashString, d.Get("unresolved_members").([]interface{}))
+	oldMemberIDs := schema.NewSet(schema.HashString, nil)
+	for _, id := range currentIDs {
+		if !unresolved.Contains(id) {
+			oldMemberIDs.Add(id)
+		}
 	}
 
-	oldMemberIDs, err := getUserGroupMemberIDs(client, d.Id())
+	// memberships may have expired since the plan was made
+	members, _ := unexpiredMembers(d.Get("members").(*schema.Set).List(),
+		d.Get("membership_expiry").(map[string]interface{}), time.Now())
+	desiredIDs, err := userEmailsToIDs(ctx, config, members)
 	if err != nil {
 		return err
 	}
+	newMemberIDs := schema.NewSet(schema.HashString, nil)
+	for _, id := range desiredIDs {
+		newMemberIDs.Add(id)
+	}
 
-	newMemberIDs, err := userEmailsToIDs(config, d.Get("members").([]interface{}))
-	if err != nil {
+	var removedMemberIDs []string
+	for _, id := range oldMemberIDs.Difference(newMemberIDs).List() {
+		removedMemberIDs = append(removedMemberIDs, id.(string))
+	}
+
+	// checked before any change is made, so the group is left untouched
+	if err := checkMemberRemovalLimit(d.Get("name").(string), len(removedMemberIDs),
+		config.Settings.MaxMemberRemovalPerApply); err != nil {
 		return err
 	}
 
 	//add any new users
-	for _, newMemberID := range newMemberIDs {
-		if !slices.Contains(oldMemberIDs, newMemberID) {
-			err := manageGroupMember(client, d, newMemberID, "add")
-			if err != nil {
-				return err
-			}
-		}
+	var addedMemberIDs []string
+	for _, id := range newMemberIDs.Difference(oldMemberIDs).List() {
+		addedMemberIDs = append(addedMemberIDs, id.(string))
+	}
+	if err := manageGroupMembers(ctx, config, d, addedMemberIDs, "add"); err != nil {
+		return err
 	}
 
 	//remove any old users
-	for _, oldMemberID := range oldMemberIDs {
-		if !slices.Contains(newMemberIDs, oldMemberID) {
-			err := manageGroupMember(client, d, oldMemberID, "remove")
-			if err != nil {
-				return err
-			}
-		}
+	if err := manageGroupMembers(ctx, config, d, removedMemberIDs, "remove"); err != nil {
+		return err
 	}
 
-	return resourceUserGroupRead(d, m)
+	return userGroupRead(ctx, d, config)
 }
 
-func resourceUserGroupDelete(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+// userGroupUpdateHelper updates the name, description and attributes of
+// the group. The update behaves like a PUT, so the tags managed by
+// jumpcloud_user_group_tag are read and sent along with them.
+func userGroupUpdateHelper(ctx context.Context, d *schema.ResourceData, config *Meta) error {
+	groupTagsMutex.Lock(d.Id())
+	defer groupTagsMutex.Unlock(d.Id())
+
+	group, ok, err := userGroupReadHelper(ctx, config, d.Id())
+	if err != nil {
+		return fmt.Errorf("error reading user group %s: %w", d.Id(), err)
+	}
+	if !ok {
+		return fmt.Errorf("user group %s not found", d.Id())
+	}
 
-	res, err := client.UserGroupsApi.GroupsUserDelete(context.TODO(),
-		d.Id(), "", headerAccept, nil)
+	body := UserGroupPost{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Attributes:  userGroupRequestAttributes(d),
+		Tags:        group.Tags,
+	}
+
+	// will fail if attributes.posixGroups isn't sent, see GODOC
+	_, err = jumpCloudRequestContext(ctx, config, http.MethodPatch, "/usergroups/"+d.Id(), body, nil)
 	if err != nil {
-		// TODO: sort out error essentials
-		return fmt.Errorf("error deleting user group:%s; response = %+v", err, res)
+		return fmt.Errorf("error updating user group %s: %w", d.Id(), err)
 	}
-	d.SetId("")
 	return nil
 }
+
+// userGroupRequestAttributes returns the attributes sent with the creation
+// and every update of the group. The update replaces the attributes as a
+// whole, so the posix group read into state is resent even if only the
+// name changed; groups without one are sent without posix groups.
+func userGroupRequestAttributes(d *schema.ResourceData) *UserGroupAttributes {
+	attributes := &UserGroupAttributes{
+		EnableLdapUserAuthentication: d.Get("enable_ldap_user_authentication").(bool),
+	}
+	// For Attributes.PosixGroups, only the first member of the slice
+	// is considered by the JCAPI
+	if attr, ok := expandUserGroupAttributes(d.Get("attributes"),
+		d.Get("posix_gid").(int), d.Get("posix_name").(string)); ok {
+		attributes.UserGroupAttributes = *attr
+	}
+	attributes.SambaEnabled = d.Get("enable_samba").(bool)
+	attributes.Sudo = expandUserGroupSudo(d.Get("sudo").([]interface{}))
+	return attributes
+}
+
+// userGroupMembershipExpiryDiff removes the members whose membership
+// has expired from the plan
+func userGroupMembershipExpiryDiff(d *schema.ResourceDiff, m interface{}) error {
+	expiry := d.Get("membership_expiry").(map[string]interface{})
+	if len(expiry) == 0 || !d.NewValueKnown("members") {
+		return nil
+	}
+
+	members, expired := unexpiredMembers(d.Get("members").(*schema.Set).List(), expiry, time.Now())
+	if len(expired) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] membership of %v in user group %s has expired", expired, d.Get("name"))
+	return d.SetNew("members", members)
+}
+
+// userGroupHasLdapServer reports whether an LDAP server is associated
+// with the group
+func userGroupHasLdapServer(ctx context.Context, config *Meta, id string) (bool, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	optionals := map[string]interface{}{
+		"groupId": id,
+		"limit":   int32(1),
+	}
+	graphconnect, res, err := client.UserGroupAssociationsApi.GraphUserGroupAssociationsList(
+		ctx, id, "", "", []string{"ldap_server"}, optionals)
+	if err != nil {
+		return false, fmt.Errorf("error listing LDAP servers of user group %s: %w", id, apiError(res, err))
+	}
+	return len(graphconnect) > 0, nil
+}
+
+// posixGroupOf is the posix group a group is configured with: posix_gid
+// and posix_name or, without a gid, the first of the deprecated
+// attributes.posix_groups. gid is 0 if there is none.
+func posixGroupOf(attributes interface{}, gid int, name string) (int, string) {
+	if gid != 0 {
+		return gid, name
+	}
+	if attr, ok := expandAttributes(attributes); ok {
+		return int(attr.PosixGroups[0].Id), attr.PosixGroups[0].Name
+	}
+	return 0, ""
+}
+
+// userGroupPosixDiff fails at plan time if the posix group of an existing
+// group is changed, which the JCAPI doesn't allow. Moving between
+// attributes.posix_groups and posix_gid and posix_name, or dropping either,
+// keeps the posix group and is fine.
+func userGroupPosixDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, k := range []string{"attributes", "posix_gid", "posix_name"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	oldAttributes, newAttributes := d.GetChange("attributes")
+	oldGID, newGID := d.GetChange("posix_gid")
+	oldName, newName := d.GetChange("posix_name")
+
+	currentGID, currentName := posixGroupOf(oldAttributes, oldGID.(int), oldName.(string))
+	gid, name := posixGroupOf(newAttributes, newGID.(int), newName.(string))
+	// posix_gid is computed, so an edit of the deprecated attributes
+	// alone leaves it at the state value and has to be looked at on its own
+	if d.HasChange("attributes") {
+		if attr, ok := expandAttributes(newAttributes); ok {
+			gid, name = int(attr.PosixGroups[0].Id), attr.PosixGroups[0].Name
+		}
+	}
+	if gid == 0 || (gid == currentGID && name == currentName) {
+		return nil
+	}
+
+	if currentGID == 0 {
+		return fmt.Errorf("user group %s has no POSIX group and one can't be added after the group was created, "+
+			"create a new group with posix_gid %d instead", d.Get("name"), gid)
+	}
+	return fmt.Errorf("the POSIX group %d:%s of user group %s can't be changed to %d:%s after the group was created, "+
+		"create a new group instead", currentGID, currentName, d.Get("name"), gid, name)
+}
+
+// userGroupAuthenticationDiff fails at plan time on settings the JCAPI
+// rejects: Samba authentication needs the group to be synced to JumpCloud
+// LDAP first, and passwordless sudo needs sudo.
+func userGroupAuthenticationDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("enable_samba").(bool) && !d.Get("enable_ldap_user_authentication").(bool) {
+		return fmt.Errorf("enable_samba on user group %s requires enable_ldap_user_authentication, "+
+			"Samba authentication can only be enabled on groups synced to JumpCloud LDAP", d.Get("name"))
+	}
+	if d.Get("sudo.0.without_password").(bool) && !d.Get("sudo.0.enabled").(bool) {
+		return fmt.Errorf("sudo.without_password on user group %s requires sudo.enabled", d.Get("name"))
+	}
+	return nil
+}
+
+func resourceUserGroupDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	return withTimeout(d, config, schema.TimeoutDelete, func(ctx context.Context) error {
+		res, err := client.UserGroupsApi.GroupsUserDelete(ctx,
+			d.Id(), "", headerAccept, nil)
+		if err != nil {
+			return fmt.Errorf("error deleting user group %s: %w", d.Id(), apiError(res, err))
+		}
+		d.SetId("")
+		return nil
+	})
+}
@@ -0,0 +1,204 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// accessExpiryWarning is how long before the expiry expiring_soon is set
+const accessExpiryWarning = 7 * 24 * time.Hour
+
+func resourceUserGroupAccessExpiry() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Binds a JumpCloud user group to an application until the given expiry. Once expired, the next apply unbinds the group.",
+		Create:        resourceUserGroupAccessExpiryCreate,
+		Read:          resourceUserGroupAccessExpiryRead,
+		Update:        resourceUserGroupAccessExpiryUpdate,
+		Delete:        resourceUserGroupAccessExpiryDelete,
+		CustomizeDiff: userGroupAccessExpiryDiff,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Description: "The ID of the user group.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"expires_at": {
+				Description:  "The RFC 3339 timestamp the access expires at.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"active": {
+				Description: "Whether the group is currently bound to the application.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			// plans can't carry warnings, so the upcoming expiry shows up
+			// as a change of this attribute instead
+			"expiring_soon": {
+				Description: "Whether the access expires within the next 7 days. It changes to `true` in the first plan " +
+					"within that time, which makes the upcoming expiry visible in the plan.",
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userGroupAccessExpiryImporter,
+		},
+	}
+}
+
+func userGroupAccessExpiryImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), "/")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'application_id/group_id'")
+	}
+	_ = d.Set("application_id", s[0])
+	_ = d.Set("group_id", s[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// accessExpired reports whether expiresAt lies before now and how much time is left
+func accessExpired(expiresAt string, now time.Time) (bool, time.Duration, error) {
+	t, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false, 0, fmt.Errorf("error parsing expires_at %s: %s", expiresAt, err)
+	}
+	remaining := t.Sub(now)
+	return remaining <= 0, remaining, nil
+}
+
+// userGroupAccessExpiryDiff plans the unbinding of expired groups by flipping active
+func userGroupAccessExpiryDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("expires_at") {
+		return nil
+	}
+
+	expired, remaining, err := accessExpired(d.Get("expires_at").(string), time.Now())
+	if err != nil {
+		return err
+	}
+
+	if expired {
+		log.Printf("[WARN] Access of user group %s to application %s expired at %s and will be removed",
+			d.Get("group_id"), d.Get("application_id"), d.Get("expires_at"))
+	} else if remaining < accessExpiryWarning {
+		log.Printf("[WARN] Access of user group %s to application %s expires in %s",
+			d.Get("group_id"), d.Get("application_id"), remaining.Round(time.Minute))
+	}
+	if err := d.SetNew("expiring_soon", accessExpiringSoon(expired, remaining)); err != nil {
+		return err
+	}
+	return d.SetNew("active", !expired)
+}
+
+// accessExpiringSoon reports whether unexpired access is within
+// accessExpiryWarning of its expiry
+func accessExpiringSoon(expired bool, remaining time.Duration) bool {
+	return !expired && remaining < accessExpiryWarning
+}
+
+func resourceUserGroupAccessExpiryCreate(d *schema.ResourceData, m interface{}) error {
+	if err := syncUserGroupAccessExpiry(d, m); err != nil {
+		return err
+	}
+	d.SetId(d.Get("application_id").(string) + "/" + d.Get("group_id").(string))
+	return resourceUserGroupAccessExpiryRead(d, m)
+}
+
+func resourceUserGroupAccessExpiryUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := syncUserGroupAccessExpiry(d, m); err != nil {
+		return err
+	}
+	return resourceUserGroupAccessExpiryRead(d, m)
+}
+
+// syncUserGroupAccessExpiry binds the group while the access is valid and unbinds it afterwards
+func syncUserGroupAccessExpiry(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+	groupID := d.Get("group_id").(string)
+
+	expired, remaining, err := accessExpired(d.Get("expires_at").(string), time.Now())
+	if err != nil {
+		return err
+	}
+	// only set on apply, see userGroupAccessExpiryDiff
+	if err := d.Set("expiring_soon", accessExpiringSoon(expired, remaining)); err != nil {
+		return err
+	}
+
+	current, err := getApplicationUserGroupIDs(ctx, config, applicationID)
+	if err != nil {
+		return err
+	}
+	bound := stringInSlice(groupID, current)
+
+	switch {
+	case !expired && !bound:
+		return manageApplicationUserGroup(ctx, client, applicationID, groupID, "add")
+	case expired && bound:
+		return manageApplicationUserGroup(ctx, client, applicationID, groupID, "remove")
+	}
+	return nil
+}
+
+func resourceUserGroupAccessExpiryRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, d.Get("application_id").(string))
+	if err != nil {
+		return err
+	}
+	bound := stringInSlice(d.Get("group_id").(string), current)
+
+	if !bound && d.Get("active").(bool) {
+		// unbound outside of Terraform before the expiry
+		expired, _, err := accessExpired(d.Get("expires_at").(string), time.Now())
+		if err == nil && !expired {
+			d.SetId("")
+			return nil
+		}
+	}
+
+	if err := d.Set("active", bound); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceUserGroupAccessExpiryDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	applicationID := d.Get("application_id").(string)
+	groupID := d.Get("group_id").(string)
+
+	current, err := getApplicationUserGroupIDs(ctx, config, applicationID)
+	if err != nil {
+		return err
+	}
+	if stringInSlice(groupID, current) {
+		if err := manageApplicationUserGroup(ctx, client, applicationID, groupID, "remove"); err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
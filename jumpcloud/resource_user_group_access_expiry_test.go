@@ -0,0 +1,112 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccUserGroupAccessExpiry(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	applicationID := os.Getenv("JUMPCLOUD_SAML_APPLICATION_ID")
+	future := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SAML_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupAccessExpiry(rName, applicationID, future),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_access_expiry.test_expiry",
+					"active", "true"),
+			},
+			{
+				Config: testAccUserGroupAccessExpiry(rName, applicationID, past),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_access_expiry.test_expiry",
+					"active", "false"),
+			},
+		},
+	})
+}
+
+func testAccUserGroupAccessExpiry(name, applicationID, expiresAt string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_user_group_access_expiry" "test_expiry" {
+			application_id = "%s"
+			group_id       = jumpcloud_user_group.test_group.id
+			expires_at     = "%s"
+		}`, name, applicationID, expiresAt,
+	)
+}
+
+func TestAccessExpired(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	expired, remaining, err := accessExpired("2021-06-03T12:00:00Z", now)
+	assert.NoError(t, err)
+	assert.False(t, expired)
+	assert.Equal(t, 48*time.Hour, remaining)
+
+	expired, _, err = accessExpired("2021-06-01T12:00:00Z", now)
+	assert.NoError(t, err)
+	assert.True(t, expired)
+
+	expired, _, err = accessExpired("2021-06-01T13:00:00+02:00", now)
+	assert.NoError(t, err)
+	assert.True(t, expired)
+
+	_, _, err = accessExpired("2021-06-01", now)
+	assert.Error(t, err)
+}
+
+func TestUserGroupAccessExpiryPlansExpiringSoon(t *testing.T) {
+	r := resourceUserGroupAccessExpiry()
+	plan := func(expiresAt time.Time) *terraform.InstanceDiff {
+		state := &terraform.InstanceState{ID: "app/group", Attributes: map[string]string{
+			"id":             "app/group",
+			"application_id": "app",
+			"group_id":       "group",
+			"expires_at":     expiresAt.Format(time.RFC3339),
+			"active":         "true",
+			"expiring_soon":  "false",
+		}}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"application_id": "app",
+			"group_id":       "group",
+			"expires_at":     expiresAt.Format(time.RFC3339),
+		})
+		diff, err := r.Diff(state, config, nil)
+		assert.NoError(t, err)
+		return diff
+	}
+
+	assert.Nil(t, plan(time.Now().Add(30*24*time.Hour)))
+
+	diff := plan(time.Now().Add(3 * 24 * time.Hour))
+	if assert.NotNil(t, diff) {
+		assert.Equal(t, "true", diff.Attributes["expiring_soon"].New)
+		assert.Nil(t, diff.Attributes["active"])
+	}
+
+	diff = plan(time.Now().Add(-time.Hour))
+	if assert.NotNil(t, diff) {
+		assert.Nil(t, diff.Attributes["expiring_soon"])
+		assert.Equal(t, "false", diff.Attributes["active"].New)
+	}
+}
@@ -3,6 +3,7 @@ package jumpcloud
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -59,7 +60,7 @@ func resourceUserGroupAssociation() *schema.Resource {
 	}
 }
 
-func modifyUserGroupAssociation(client *jcapiv2.APIClient,
+func modifyUserGroupAssociation(ctx context.Context, client *jcapiv2.APIClient,
 	d *schema.ResourceData, action string) diag.Diagnostics {
 
 	payload := jcapiv2.UserGroupGraphManagementReq{
@@ -73,16 +74,17 @@ func modifyUserGroupAssociation(client *jcapiv2.APIClient,
 	}
 
 	_, err := client.UserGroupAssociationsApi.GraphUserGroupAssociationsPost(
-		context.TODO(), d.Get("group_id").(string), "", "", req)
+		ctx, d.Get("group_id").(string), "", "", req)
 
 	return diag.FromErr(err)
 }
 
 func resourceUserGroupAssociationCreate(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+	config := meta.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
 
-	diags := modifyUserGroupAssociation(client, d, "add")
+	diags := modifyUserGroupAssociation(ctx, client, d, "add")
 	if diags.HasError() {
 		return fmt.Errorf("Error creating user group association: %v", diags)
 	}
@@ -90,38 +92,52 @@ func resourceUserGroupAssociationCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceUserGroupAssociationRead(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
-
-	optionals := map[string]interface{}{
-		"groupId": d.Get("group_id").(string),
-		"limit":   int32(100),
-	}
+	config := meta.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	found := false
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		optionals := map[string]interface{}{
+			"groupId": d.Get("group_id").(string),
+			"limit":   int32(pageSize),
+			"skip":    skip,
+		}
 
-	graphconnect, _, err := client.UserGroupAssociationsApi.GraphUserGroupAssociationsList(
-		context.TODO(), d.Get("group_id").(string), "", "", []string{d.Get("type").(string)}, optionals)
+		var graphconnect []jcapiv2.GraphConnection
+		var err error
+		graphconnect, res, err = client.UserGroupAssociationsApi.GraphUserGroupAssociationsList(
+			ctx, d.Get("group_id").(string), "", "", []string{d.Get("type").(string)}, optionals)
+
+		// the ID of the specified object is buried in a complex construct
+		for _, v := range graphconnect {
+			if v.To.Id == d.Get("object_id") {
+				found = true
+				// no need for the other pages
+				return res, 0, nil
+			}
+		}
+		return res, len(graphconnect), err
+	})
 	if err != nil {
-		return err
+		return apiError(res, err)
 	}
 
-	// the ID of the specified object is buried in a complex construct
-	for _, v := range graphconnect {
-		if v.To.Id == d.Get("object_id") {
-			resourceId := d.Get("group_id").(string) + "/" + d.Get("object_id").(string)
-			d.SetId(resourceId)
-			return nil
-		}
+	if !found {
+		// element does not exist; unset ID
+		d.SetId("")
+		return nil
 	}
-
-	// element does not exist; unset ID
-	d.SetId("")
+	d.SetId(d.Get("group_id").(string) + "/" + d.Get("object_id").(string))
 	return nil
 }
 
 func resourceUserGroupAssociationDelete(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
-	diags := modifyUserGroupAssociation(client, d, "remove")
+	config := meta.(*Meta)
+	ctx := requestContext(config)
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	diags := modifyUserGroupAssociation(ctx, client, d, "remove")
 	if diags.HasError() {
 		return fmt.Errorf("Error deleting user group association: %v", diags)
 	}
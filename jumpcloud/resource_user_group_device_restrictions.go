@@ -0,0 +1,155 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceUserGroupDeviceRestrictions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for restricting the devices members of a JumpCloud user group can authenticate from.",
+		Create:      resourceUserGroupDeviceRestrictionsUpdate,
+		Read:        resourceUserGroupDeviceRestrictionsRead,
+		Update:      resourceUserGroupDeviceRestrictionsUpdate,
+		Delete:      resourceUserGroupDeviceRestrictionsDelete,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"allow_macos": {
+				Description: "Whether members can authenticate from macOS devices.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"allow_windows": {
+				Description: "Whether members can authenticate from Windows devices.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"allow_linux": {
+				Description: "Whether members can authenticate from Linux devices.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"allow_ios": {
+				Description: "Whether members can authenticate from iOS devices.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"allow_android": {
+				Description: "Whether members can authenticate from Android devices.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"trusted_devices_only": {
+				Description: "Whether members can only authenticate from devices managed by JumpCloud.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"require_encrypted_storage": {
+				Description: "Whether members can only authenticate from devices with encrypted storage.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userGroupDeviceRestrictionsImporter,
+		},
+	}
+}
+
+func userGroupDeviceRestrictionsImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("group_id", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func userGroupDeviceRestrictionsPath(d *schema.ResourceData) string {
+	return "/usergroups/" + d.Get("group_id").(string) + "/restrictions"
+}
+
+func resourceUserGroupDeviceRestrictionsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var restrictions UserGroupDeviceRestrictions
+	ok, err := jumpCloudRequest(config, http.MethodGet, userGroupDeviceRestrictionsPath(d), nil, &restrictions)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// the group is gone
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("allow_macos", restrictions.AllowMacOS); err != nil {
+		return err
+	}
+	if err := d.Set("allow_windows", restrictions.AllowWindows); err != nil {
+		return err
+	}
+	if err := d.Set("allow_linux", restrictions.AllowLinux); err != nil {
+		return err
+	}
+	if err := d.Set("allow_ios", restrictions.AllowIOS); err != nil {
+		return err
+	}
+	if err := d.Set("allow_android", restrictions.AllowAndroid); err != nil {
+		return err
+	}
+	if err := d.Set("trusted_devices_only", restrictions.TrustedDevicesOnly); err != nil {
+		return err
+	}
+	if err := d.Set("require_encrypted_storage", restrictions.RequireEncryptedStorage); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resourceUserGroupDeviceRestrictionsUpdate is also used on create, every
+// group has restrictions, allowing everything by default
+func resourceUserGroupDeviceRestrictionsUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	payload := UserGroupDeviceRestrictions{
+		AllowMacOS:              d.Get("allow_macos").(bool),
+		AllowWindows:            d.Get("allow_windows").(bool),
+		AllowLinux:              d.Get("allow_linux").(bool),
+		AllowIOS:                d.Get("allow_ios").(bool),
+		AllowAndroid:            d.Get("allow_android").(bool),
+		TrustedDevicesOnly:      d.Get("trusted_devices_only").(bool),
+		RequireEncryptedStorage: d.Get("require_encrypted_storage").(bool),
+	}
+
+	_, err := jumpCloudRequest(config, http.MethodPut, userGroupDeviceRestrictionsPath(d), payload, nil)
+	if err != nil {
+		return fmt.Errorf("error setting device restrictions of group %s: %s", d.Get("group_id"), err)
+	}
+
+	d.SetId(d.Get("group_id").(string))
+	return resourceUserGroupDeviceRestrictionsRead(d, m)
+}
+
+func resourceUserGroupDeviceRestrictionsDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	// lifts all restrictions
+	_, err := jumpCloudRequest(config, http.MethodDelete, userGroupDeviceRestrictionsPath(d), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error removing device restrictions of group %s: %s", d.Get("group_id"), err)
+	}
+	d.SetId("")
+	return nil
+}
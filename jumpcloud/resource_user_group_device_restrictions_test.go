@@ -0,0 +1,53 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccUserGroupDeviceRestrictions(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupDeviceRestrictions(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group_device_restrictions.test_restrictions", "allow_android", "false"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_device_restrictions.test_restrictions", "allow_macos", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_device_restrictions.test_restrictions", "trusted_devices_only", "true"),
+				),
+			},
+			{
+				Config: testAccUserGroupDeviceRestrictions(rName, true),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_device_restrictions.test_restrictions",
+					"allow_android", "true"),
+			},
+			{
+				ResourceName:      "jumpcloud_user_group_device_restrictions.test_restrictions",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserGroupDeviceRestrictions(name string, allowAndroid bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_user_group_device_restrictions" "test_restrictions" {
+			group_id             = jumpcloud_user_group.test_group.id
+			allow_android        = %t
+			trusted_devices_only = true
+		}`, name, allowAndroid,
+	)
+}
@@ -0,0 +1,119 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceUserGroupManager() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for delegating the management of a JumpCloud user group to a user.",
+		Create:      resourceUserGroupManagerCreate,
+		Read:        resourceUserGroupManagerRead,
+		Update:      resourceUserGroupManagerUpdate,
+		Delete:      resourceUserGroupManagerDelete,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"manager_user_id": {
+				Description: "The ID of the `resource_user` object managing the group.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"can_manage_members": {
+				Description: "Whether the manager can add and remove members of the group.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userGroupManagerImporter,
+		},
+	}
+}
+
+func userGroupManagerImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.Split(d.Id(), "/")
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'group_id/manager_user_id'")
+	}
+
+	_ = d.Set("group_id", ids[0])
+	_ = d.Set("manager_user_id", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func userGroupManagerPath(d *schema.ResourceData) string {
+	return "/usergroups/" + d.Get("group_id").(string) + "/managers/" + d.Get("manager_user_id").(string)
+}
+
+func putUserGroupManager(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	payload := UserGroupManager{CanManageMembers: d.Get("can_manage_members").(bool)}
+	_, err := jumpCloudRequest(config, http.MethodPut, userGroupManagerPath(d), payload, nil)
+	if err != nil {
+		return fmt.Errorf("error assigning user %s as manager of group %s: %s",
+			d.Get("manager_user_id"), d.Get("group_id"), err)
+	}
+	return nil
+}
+
+func resourceUserGroupManagerCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupManager(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("group_id").(string) + "/" + d.Get("manager_user_id").(string))
+	return resourceUserGroupManagerRead(d, m)
+}
+
+func resourceUserGroupManagerRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var manager UserGroupManager
+	ok, err := jumpCloudRequest(config, http.MethodGet, userGroupManagerPath(d), nil, &manager)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("can_manage_members", manager.CanManageMembers); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceUserGroupManagerUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupManager(d, m); err != nil {
+		return err
+	}
+	return resourceUserGroupManagerRead(d, m)
+}
+
+func resourceUserGroupManagerDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	// only the delegation is removed, the group and the user are kept
+	_, err := jumpCloudRequest(config, http.MethodDelete, userGroupManagerPath(d), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error removing user %s as manager of group %s: %s",
+			d.Get("manager_user_id"), d.Get("group_id"), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,57 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccUserGroupManager(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupManager(rName, true),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_manager.test_manager",
+					"can_manage_members", "true"),
+			},
+			{
+				Config: testAccUserGroupManager(rName, false),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_manager.test_manager",
+					"can_manage_members", "false"),
+			},
+			{
+				ResourceName:      "jumpcloud_user_group_manager.test_manager",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserGroupManager(name string, canManageMembers bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username  = "%[1]s"
+			email     = "%[1]s@testorg.com"
+			firstname = "Firstname"
+			lastname  = "Lastname"
+		}
+
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+		}
+
+		resource "jumpcloud_user_group_manager" "test_manager" {
+			group_id           = jumpcloud_user_group.test_group.id
+			manager_user_id    = jumpcloud_user.test_user.id
+			can_manage_members = %[2]t
+		}`, name, canManageMembers,
+	)
+}
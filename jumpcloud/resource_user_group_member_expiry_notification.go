@@ -0,0 +1,123 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// membershipExpiringEvent is the webhook event fired ahead of expiring memberships
+const membershipExpiringEvent = "user_group.membership_expiring"
+
+func resourceUserGroupMemberExpiryNotification() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for notifying a webhook before memberships of a JumpCloud user group expire.",
+		Create:      resourceUserGroupMemberExpiryNotificationCreate,
+		Read:        resourceUserGroupMemberExpiryNotificationRead,
+		Update:      resourceUserGroupMemberExpiryNotificationUpdate,
+		Delete:      resourceUserGroupMemberExpiryNotificationDelete,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"notify_days_before": {
+				Description:  "The number of days before a membership expires the webhook is notified.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 365),
+			},
+			"webhook_url": {
+				Description:  "The HTTPS URL the notifications are posted to.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			"secret": {
+				Description: "The secret the notifications are signed with. It can't be read back from JumpCloud.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+	}
+}
+
+func expandMembershipExpiryWebhook(d *schema.ResourceData) MembershipExpiryWebhook {
+	return MembershipExpiryWebhook{
+		Event:            membershipExpiringEvent,
+		GroupID:          d.Get("group_id").(string),
+		NotifyDaysBefore: d.Get("notify_days_before").(int),
+		URL:              d.Get("webhook_url").(string),
+		Secret:           d.Get("secret").(string),
+	}
+}
+
+func resourceUserGroupMemberExpiryNotificationCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var webhook MembershipExpiryWebhook
+	_, err := jumpCloudRequest(config, http.MethodPost, "/webhooks", expandMembershipExpiryWebhook(d), &webhook)
+	if err != nil {
+		return fmt.Errorf("error creating expiry notification of group %s: %s", d.Get("group_id"), err)
+	}
+
+	d.SetId(webhook.ID)
+	return resourceUserGroupMemberExpiryNotificationRead(d, m)
+}
+
+func resourceUserGroupMemberExpiryNotificationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var webhook MembershipExpiryWebhook
+	ok, err := jumpCloudRequest(config, http.MethodGet, "/webhooks/"+d.Id(), nil, &webhook)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	// the secret isn't returned, the configured one is kept
+	if err := d.Set("group_id", webhook.GroupID); err != nil {
+		return err
+	}
+	if err := d.Set("notify_days_before", webhook.NotifyDaysBefore); err != nil {
+		return err
+	}
+	if err := d.Set("webhook_url", webhook.URL); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceUserGroupMemberExpiryNotificationUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodPut, "/webhooks/"+d.Id(), expandMembershipExpiryWebhook(d), nil)
+	if err != nil {
+		return fmt.Errorf("error updating expiry notification %s: %s", d.Id(), err)
+	}
+	return resourceUserGroupMemberExpiryNotificationRead(d, m)
+}
+
+func resourceUserGroupMemberExpiryNotificationDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete, "/webhooks/"+d.Id(), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error deleting expiry notification %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,50 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccUserGroupMemberExpiryNotification(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupMemberExpiryNotification(rName, 7),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group_member_expiry_notification.test_notification",
+						"notify_days_before", "7"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_member_expiry_notification.test_notification",
+						"webhook_url", "https://hooks.testorg.com/"+rName),
+				),
+			},
+			{
+				Config: testAccUserGroupMemberExpiryNotification(rName, 14),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_member_expiry_notification.test_notification",
+					"notify_days_before", "14"),
+			},
+		},
+	})
+}
+
+func testAccUserGroupMemberExpiryNotification(name string, days int) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+		}
+
+		resource "jumpcloud_user_group_member_expiry_notification" "test_notification" {
+			group_id           = jumpcloud_user_group.test_group.id
+			notify_days_before = %[2]d
+			webhook_url        = "https://hooks.testorg.com/%[1]s"
+			secret             = "%[1]s"
+		}`, name, days,
+	)
+}
@@ -3,6 +3,8 @@ package jumpcloud
 import (
 	"context"
 	"fmt"
+	"log"
+	"net/http"
 	"strings"
 	"time"
 
@@ -12,45 +14,87 @@ import (
 
 func resourceUserGroupMembership() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a resource for managing user group memberships.",
-		Create:      resourceUserGroupMembershipCreate,
-		Read:        resourceUserGroupMembershipRead,
-		Update:      nil, // No update routine, as association cannot be updated
-		Delete:      resourceUserGroupMembershipDelete,
+		Description: "Provides a resource for managing user group memberships. With `user_group_id` and `members` only " +
+			"the declared members are managed, unless `exclusive` is set.",
+		Create: resourceUserGroupMembershipCreate,
+		Read:   resourceUserGroupMembershipRead,
+		Update: resourceUserGroupMembershipUpdate,
+		Delete: resourceUserGroupMembershipDelete,
 		Schema: map[string]*schema.Schema{
 			"userid": {
-				Description: "The ID of the `resource_user` object.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:  "The ID of the `resource_user` object.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"groupid"},
+				Deprecated:   "use user_group_id and members instead",
 			},
 			"groupid": {
-				Description: "The ID of the `resource_user_group` object.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:  "The ID of the `resource_user_group` object.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"userid"},
+				ExactlyOneOf: []string{"groupid", "user_group_id"},
+				Deprecated:   "use user_group_id and members instead",
+			},
+			"user_group_id": {
+				Description:  "The ID of the user group.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"members"},
+			},
+			"members": {
+				Description: "The emails of the users in the group.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"exclusive": {
+				Description: "Whether members of the group that aren't in `members` are removed. By default they " +
+					"are left alone, so several resources can contribute members to the same group.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: userGroupMembershipImporter,
 		},
+		// syncing the members of large groups, e.g. in exclusive mode,
+		// takes a while
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
 func userGroupMembershipImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if !strings.Contains(d.Id(), "/") {
+		// the members are left empty, so the next apply adds the configured
+		// ones without touching anybody else
+		_ = d.Set("user_group_id", d.Id())
+		return []*schema.ResourceData{d}, nil
+	}
+
 	ids := strings.Split(d.Id(), "/")
 	if len(ids) != 2 {
-		return nil, fmt.Errorf("Invalid import format. Expected 'groupid/userid'")
+		return nil, fmt.Errorf("Invalid import format. Expected 'user_group_id' or 'groupid/userid'")
 	}
 	groupID, userID := ids[0], ids[1]
 
 	_ = d.Set("groupid", groupID)
 	_ = d.Set("userid", userID)
 
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+	config := m.(*Meta)
+	ctx := requestContext(config)
 
-	isMember, err := checkUserGroupMembership(client, groupID, userID)
+	isMember, err := checkUserGroupMembership(ctx, config, groupID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -63,36 +107,41 @@ func userGroupMembershipImporter(d *schema.ResourceData, m interface{}) ([]*sche
 	return nil, fmt.Errorf("User %s is not a member of group %s", userID, groupID)
 }
 
-func checkUserGroupMembership(client *jcapiv2.APIClient, groupID, userID string) (bool, error) {
-	for i := 0; ; i++ {
+func checkUserGroupMembership(ctx context.Context, config *Meta, groupID, userID string) (bool, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	isMember := false
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
 		optionals := map[string]interface{}{
 			"groupId": groupID,
-			"limit":   int32(100),
-			"skip":    int32(i * 100),
+			"limit":   int32(pageSize),
+			"skip":    skip,
 		}
 
-		graphconnect, _, err := client.UserGroupMembersMembershipApi.GraphUserGroupMembersList(
-			context.TODO(), groupID, "", "", optionals)
-		if err != nil {
-			return false, err
-		}
+		var graphconnect []jcapiv2.GraphConnection
+		var err error
+		graphconnect, res, err = client.UserGroupMembersMembershipApi.GraphUserGroupMembersList(
+			ctx, groupID, "", "", optionals)
 
+		// The Userids are hidden in a super-complex construct, see
+		// https://github.com/TheJumpCloud/jcapi-go/blob/master/v2/docs/GraphConnection.md
 		for _, v := range graphconnect {
 			if v.To.Id == userID {
-				return true, nil
+				isMember = true
+				// no need for the other pages
+				return res, 0, nil
 			}
 		}
-
-		if len(graphconnect) < 100 {
-			break
-		} else {
-			time.Sleep(100 * time.Millisecond)
-		}
+		return res, len(graphconnect), err
+	})
+	if err != nil {
+		return false, apiError(res, err)
 	}
-	return false, nil
+	return isMember, nil
 }
 
-func modifyUserGroupMembership(client *jcapiv2.APIClient,
+func modifyUserGroupMembership(ctx context.Context, client *jcapiv2.APIClient,
 	d *schema.ResourceData, action string) error {
 
 	payload := jcapiv2.UserGroupMembersReq{
@@ -106,64 +155,245 @@ func modifyUserGroupMembership(client *jcapiv2.APIClient,
 	}
 
 	_, err := client.UserGroupMembersMembershipApi.GraphUserGroupMembersPost(
-		context.TODO(), d.Get("groupid").(string), "", "", req)
+		ctx, d.Get("groupid").(string), "", "", req)
 
 	return err
 }
 
+// isLegacyUserGroupMembership reports whether d manages a single user by
+// userid and groupid rather than the members of user_group_id
+func isLegacyUserGroupMembership(d *schema.ResourceData) bool {
+	return d.Get("groupid").(string) != ""
+}
+
 func resourceUserGroupMembershipCreate(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutCreate, func(ctx context.Context) error {
+		return userGroupMembershipCreate(ctx, d, config)
+	})
+}
+
+func userGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, config *Meta) error {
+	if !isLegacyUserGroupMembership(d) {
+		d.SetId(d.Get("user_group_id").(string))
+		if err := syncUserGroupMembership(ctx, config, d, nil); err != nil {
+			return err
+		}
+		return userGroupMembershipRead(ctx, d, config)
+	}
+	client := jcapiv2.NewAPIClient(config.Configuration)
 
-	err := modifyUserGroupMembership(client, d, "add")
+	err := modifyUserGroupMembership(ctx, client, d, "add")
 	if err != nil {
 		return err
 	}
-	return resourceUserGroupMembershipRead(d, m)
+	return userGroupMembershipRead(ctx, d, config)
 }
 
 func resourceUserGroupMembershipRead(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutRead, func(ctx context.Context) error {
+		return userGroupMembershipRead(ctx, d, config)
+	})
+}
 
-	for i := 0; i < 20; i++ { // Prevent infite loop
+func userGroupMembershipRead(ctx context.Context, d *schema.ResourceData, config *Meta) error {
+	if !isLegacyUserGroupMembership(d) {
+		return readUserGroupMembership(ctx, config, d)
+	}
+	groupID, userID := d.Get("groupid").(string), d.Get("userid").(string)
+	isMember, err := checkUserGroupMembership(ctx, config, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		// As we not have a JC-ID for the membership we simply store the
+		// concatenation of group ID and user ID as our membership ID
+		d.SetId(groupID + "/" + userID)
+		return nil
+	}
 
-		optionals := map[string]interface{}{
-			"groupId": d.Get("groupid").(string),
-			"limit":   int32(100),
-			"skip":    int32(i * 100),
-		}
+	// Instead of unsetting the ID, return an error to let Terraform retry
+	return fmt.Errorf("User ID %s not found in group ID %s", userID, groupID)
+}
 
-		graphconnect, _, err := client.UserGroupMembersMembershipApi.GraphUserGroupMembersList(
-			context.TODO(), d.Get("groupid").(string), "", "", optionals)
-		if err != nil {
+func resourceUserGroupMembershipUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutUpdate, func(ctx context.Context) error {
+		// the legacy attributes force a new resource, so only the members of
+		// user_group_id can change here
+		old, _ := d.GetChange("members")
+		if err := syncUserGroupMembership(ctx, config, d, old.(*schema.Set).List()); err != nil {
 			return err
 		}
+		return userGroupMembershipRead(ctx, d, config)
+	})
+}
 
-		// The Userids are hidden in a super-complex construct, see
-		// https://github.com/TheJumpCloud/jcapi-go/blob/master/v2/docs/GraphConnection.md
-		for _, v := range graphconnect {
-			if v.To.Id == d.Get("userid") {
-				// Found - As we not have a JC-ID for the membership we simply store
-				// the concatenation of group ID and user ID as our membership ID
-				d.SetId(d.Get("groupid").(string) + "/" + d.Get("userid").(string))
-				return nil
+func resourceUserGroupMembershipDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+	return withTimeout(d, config, schema.TimeoutDelete, func(ctx context.Context) error {
+		return userGroupMembershipDelete(ctx, d, config)
+	})
+}
+
+func userGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, config *Meta) error {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	if isLegacyUserGroupMembership(d) {
+		return modifyUserGroupMembership(ctx, client, d, "remove")
+	}
+
+	current, err := userGroupMemberEmails(ctx, config, d.Id())
+	if err != nil {
+		return err
+	}
+	// only the declared members are removed, even in exclusive mode
+	_, remove := userGroupMembersDiff(current, nil, interfacesToStrings(d.Get("members").(*schema.Set).List()), false)
+	return applyUserGroupMembersDiff(ctx, config, d, nil, remove)
+}
+
+// userGroupMemberEmails returns the emails of the members of the user group
+func userGroupMemberEmails(ctx context.Context, config *Meta, groupID string) ([]string, error) {
+	ids, err := getUserGroupMemberIDs(ctx, config, groupID)
+	if err != nil {
+		return nil, err
+	}
+	emails, unresolved, err := userIDsToEmails(ctx, config, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(unresolved) > 0 {
+		log.Printf("[WARN] members %s of user group %s are no JumpCloud users anymore",
+			strings.Join(unresolved, ", "), groupID)
+	}
+	return emails, nil
+}
+
+// userGroupMembersDiff returns the emails to add to and remove from a group
+// whose members are current, so that it contains desired. Unless
+// exclusive, only the members in managed are removed. Emails are compared
+// case-insensitively.
+func userGroupMembersDiff(current, desired, managed []string, exclusive bool) (add, remove []string) {
+	if exclusive {
+		managed = current
+	}
+
+	add = []string{}
+	for _, email := range desired {
+		if !containsFold(current, email) {
+			add = append(add, email)
+		}
+	}
+
+	remove = []string{}
+	for _, email := range managed {
+		if containsFold(current, email) && !containsFold(desired, email) && !containsFold(remove, email) {
+			remove = append(remove, email)
+		}
+	}
+	return add, remove
+}
+
+// declaredUserGroupMembers returns the members to store in state: all of
+// current in exclusive mode, otherwise only the declared ones that are
+// members. The declared spelling of an email is kept.
+func declaredUserGroupMembers(current, declared []string, exclusive bool) []string {
+	members := []string{}
+	for _, email := range declared {
+		if containsFold(current, email) {
+			members = append(members, email)
+		}
+	}
+	if exclusive {
+		for _, email := range current {
+			if !containsFold(declared, email) {
+				members = append(members, email)
 			}
 		}
+	}
+	return members
+}
 
-		if len(graphconnect) < 100 {
-			break
-		} else {
-			time.Sleep(100 * time.Millisecond)
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
 		}
 	}
+	return false
+}
 
-	// Instead of unsetting the ID, return an error to let Terraform retry
-	return fmt.Errorf("User ID %s not found in group ID %s", d.Get("userid").(string), d.Get("groupid").(string))
+func interfacesToStrings(list []interface{}) []string {
+	strs := make([]string, len(list))
+	for i, v := range list {
+		strs[i] = v.(string)
+	}
+	return strs
 }
 
-func resourceUserGroupMembershipDelete(d *schema.ResourceData, m interface{}) error {
-	config := m.(*jcapiv2.Configuration)
-	client := jcapiv2.NewAPIClient(config)
-	return modifyUserGroupMembership(client, d, "remove")
+// syncUserGroupMembership adds the configured members to the user group and
+// removes the ones that are no longer configured, i.e. those in managed, or
+// every other member in exclusive mode
+func syncUserGroupMembership(ctx context.Context, config *Meta, d *schema.ResourceData, managed []interface{}) error {
+	current, err := userGroupMemberEmails(ctx, config, d.Id())
+	if err != nil {
+		return err
+	}
+
+	add, remove := userGroupMembersDiff(current, interfacesToStrings(d.Get("members").(*schema.Set).List()),
+		interfacesToStrings(managed), d.Get("exclusive").(bool))
+	return applyUserGroupMembersDiff(ctx, config, d, add, remove)
+}
+
+func applyUserGroupMembersDiff(ctx context.Context, config *Meta, d *schema.ResourceData, add, remove []string) error {
+	removeIDs, err := userEmailsToIDs(ctx, config, stringsToInterfaces(remove))
+	if err != nil {
+		return err
+	}
+	// checked before any change is made, so the group is left untouched
+	if err := checkMemberRemovalLimit(d.Id(), len(removeIDs), config.Settings.MaxMemberRemovalPerApply); err != nil {
+		return err
+	}
+
+	addIDs, err := userEmailsToIDs(ctx, config, stringsToInterfaces(add))
+	if err != nil {
+		return err
+	}
+	if err := manageGroupMembers(ctx, config, d, addIDs, "add"); err != nil {
+		return err
+	}
+	return manageGroupMembers(ctx, config, d, removeIDs, "remove")
+}
+
+func stringsToInterfaces(strs []string) []interface{} {
+	list := make([]interface{}, len(strs))
+	for i, v := range strs {
+		list[i] = v
+	}
+	return list
+}
+
+func readUserGroupMembership(ctx context.Context, config *Meta, d *schema.ResourceData) error {
+	_, ok, err := userGroupReadHelper(ctx, config, d.Id())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// the group is gone, and its membership with it
+		d.SetId("")
+		return nil
+	}
+
+	current, err := userGroupMemberEmails(ctx, config, d.Id())
+	if err != nil {
+		return err
+	}
+
+	// declared members removed outside of Terraform show up as drift, as
+	// do additional members in exclusive mode
+	if err := d.Set("user_group_id", d.Id()); err != nil {
+		return err
+	}
+	return d.Set("members", declaredUserGroupMembers(current,
+		interfacesToStrings(d.Get("members").(*schema.Set).List()), d.Get("exclusive").(bool)))
 }
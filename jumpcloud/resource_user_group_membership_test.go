@@ -1,11 +1,19 @@
 package jumpcloud
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccUserGroupMembership(t *testing.T) {
@@ -46,3 +54,148 @@ func testAccUserGroupMembership(name string) string {
   		}
 	`, name, name, name, name, name, name, name, name)
 }
+
+func TestAccUserGroupMembershipMembers(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_user_group_membership.test_members"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{ // the member of the group resource is left alone
+				Config: testAccUserGroupMembershipMembers(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "members.#", "1"),
+					resource.TestCheckResourceAttr(fullResourceName, "exclusive", "false"),
+				),
+			},
+			{ // it is removed in exclusive mode
+				Config: testAccUserGroupMembershipMembers(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(fullResourceName, "members.#", "1"),
+					resource.TestCheckResourceAttr(fullResourceName, "exclusive", "true"),
+				),
+			},
+			{
+				ResourceName:            fullResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"members", "exclusive"},
+			},
+		},
+	})
+}
+
+func testAccUserGroupMembershipMembers(name string, exclusive bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "first" {
+			username = "%s_first"
+			email    = "%s_first@testorg.com"
+		}
+
+		resource "jumpcloud_user" "second" {
+			username = "%s_second"
+			email    = "%s_second@testorg.com"
+		}
+
+		resource "jumpcloud_user_group" "test_group" {
+			name    = "testgroup_%s"
+			members = [jumpcloud_user.first.email]
+
+			lifecycle {
+				ignore_changes = [members]
+			}
+		}
+
+		resource "jumpcloud_user_group_membership" "test_members" {
+			user_group_id = jumpcloud_user_group.test_group.id
+			members       = [jumpcloud_user.second.email]
+			exclusive     = %t
+		}
+	`, name, name, name, name, name, exclusive)
+}
+
+func TestUserGroupMembersDiff(t *testing.T) {
+	current := []string{"a@example.com", "B@example.com", "other@example.com"}
+
+	// only the previously declared members are removed
+	add, remove := userGroupMembersDiff(current, []string{"a@example.com", "c@example.com"},
+		[]string{"a@example.com", "b@example.com", "gone@example.com"}, false)
+	assert.Equal(t, []string{"c@example.com"}, add)
+	assert.Equal(t, []string{"b@example.com"}, remove)
+
+	// every member that isn't declared is removed
+	add, remove = userGroupMembersDiff(current, []string{"a@example.com", "c@example.com"}, nil, true)
+	assert.Equal(t, []string{"c@example.com"}, add)
+	assert.Equal(t, []string{"B@example.com", "other@example.com"}, remove)
+
+	add, remove = userGroupMembersDiff(current, []string{"b@EXAMPLE.com"}, []string{"b@EXAMPLE.com"}, false)
+	assert.Empty(t, add)
+	assert.Empty(t, remove)
+}
+
+func TestDeclaredUserGroupMembers(t *testing.T) {
+	current := []string{"A@example.com", "other@example.com"}
+	declared := []string{"a@example.com", "removed@example.com"}
+
+	assert.Equal(t, []string{"a@example.com"}, declaredUserGroupMembers(current, declared, false))
+	assert.Equal(t, []string{"a@example.com", "other@example.com"}, declaredUserGroupMembers(current, declared, true))
+}
+
+func TestCheckUserGroupMembership(t *testing.T) {
+	var skips []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		skips = append(skips, r.URL.Query().Get("skip"))
+		if len(skips) == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		// full pages, the user is on the second one
+		page := make([]jcapiv2.GraphConnection, pageSize)
+		for i := range page {
+			page[i].To = &jcapiv2.GraphObject{Id: fmt.Sprintf("%s-%d", r.URL.Query().Get("skip"), i)}
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(page))
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{MaxRetries: 2, RetryBaseDelayMS: 1}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL
+
+	// the rate limited page is retried, the pages after the user's aren't read
+	isMember, err := checkUserGroupMembership(context.TODO(), config.(*Meta), "group", "100-5")
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+	assert.Equal(t, []string{"0", "0", "100"}, skips)
+}
+
+func TestUserGroupMembershipUpdateTimeout(t *testing.T) {
+	// the API never answers
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL
+
+	r := resourceUserGroupMembership()
+	timeout := 50 * time.Millisecond
+	r.Timeouts.Update = &timeout
+	d := r.Data(&terraform.InstanceState{ID: "group", Attributes: map[string]string{
+		"user_group_id": "group",
+		"exclusive":     "true",
+	}})
+
+	start := time.Now()
+	err = r.Update(d, config)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "update timed out after 50ms")
+}
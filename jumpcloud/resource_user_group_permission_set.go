@@ -0,0 +1,126 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceUserGroupPermissionSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for granting a JumpCloud admin role permissions on a user group.",
+		Create:      resourceUserGroupPermissionSetCreate,
+		Read:        resourceUserGroupPermissionSetRead,
+		Update:      resourceUserGroupPermissionSetUpdate,
+		Delete:      resourceUserGroupPermissionSetDelete,
+		Schema: map[string]*schema.Schema{
+			"admin_role_id": {
+				Description: "The ID of the admin role.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"permissions": {
+				Description: "The permissions granted to the admin role on the group. Possible values: `read`, `write`, `manage_members`.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"read", "write", "manage_members"}, false),
+				},
+				Set: schema.HashString,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userGroupPermissionSetImporter,
+		},
+	}
+}
+
+func userGroupPermissionSetImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.Split(d.Id(), "/")
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'admin_role_id/group_id'")
+	}
+
+	_ = d.Set("admin_role_id", ids[0])
+	_ = d.Set("group_id", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func userGroupPermissionSetPath(d *schema.ResourceData) string {
+	return "/roles/" + d.Get("admin_role_id").(string) +
+		"/usergroups/" + d.Get("group_id").(string) + "/permissions"
+}
+
+func putUserGroupPermissionSet(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	permissions := interfacesToStrings(d.Get("permissions").(*schema.Set).List())
+	_, err := jumpCloudRequest(config, http.MethodPut, userGroupPermissionSetPath(d),
+		UserGroupPermissionSet{Permissions: permissions}, nil)
+	if err != nil {
+		return fmt.Errorf("error granting admin role %s permissions on group %s: %s",
+			d.Get("admin_role_id"), d.Get("group_id"), err)
+	}
+	return nil
+}
+
+func resourceUserGroupPermissionSetCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupPermissionSet(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("admin_role_id").(string) + "/" + d.Get("group_id").(string))
+	return resourceUserGroupPermissionSetRead(d, m)
+}
+
+func resourceUserGroupPermissionSetRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var permissionSet UserGroupPermissionSet
+	ok, err := jumpCloudRequest(config, http.MethodGet, userGroupPermissionSetPath(d), nil, &permissionSet)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("permissions", permissionSet.Permissions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceUserGroupPermissionSetUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupPermissionSet(d, m); err != nil {
+		return err
+	}
+	return resourceUserGroupPermissionSetRead(d, m)
+}
+
+func resourceUserGroupPermissionSetDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete, userGroupPermissionSetPath(d), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error revoking admin role %s permissions on group %s: %s",
+			d.Get("admin_role_id"), d.Get("group_id"), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,64 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestAccUserGroupPermissionSet(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	roleID := os.Getenv("JUMPCLOUD_ADMIN_ROLE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if roleID == "" {
+				t.Skip("JUMPCLOUD_ADMIN_ROLE_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupPermissionSet(rName, roleID, `["read"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group_permission_set.test_permissions", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_permission_set.test_permissions",
+						fmt.Sprintf("permissions.%d", schema.HashString("read")), "read"),
+				),
+			},
+			{
+				Config: testAccUserGroupPermissionSet(rName, roleID, `["read", "manage_members"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group_permission_set.test_permissions", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_permission_set.test_permissions",
+						fmt.Sprintf("permissions.%d", schema.HashString("manage_members")), "manage_members"),
+				),
+			},
+			{
+				ResourceName:      "jumpcloud_user_group_permission_set.test_permissions",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserGroupPermissionSet(name, roleID, permissions string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_user_group_permission_set" "test_permissions" {
+			admin_role_id = "%s"
+			group_id      = jumpcloud_user_group.test_group.id
+			permissions   = %s
+		}`, name, roleID, permissions,
+	)
+}
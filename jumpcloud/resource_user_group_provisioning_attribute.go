@@ -0,0 +1,152 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceUserGroupProvisioningAttribute() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource for managing an attribute provisioned to a SCIM application when a user is added to a JumpCloud user group.",
+		Create:        resourceUserGroupProvisioningAttributeCreate,
+		Read:          resourceUserGroupProvisioningAttributeRead,
+		Update:        resourceUserGroupProvisioningAttributeUpdate,
+		Delete:        resourceUserGroupProvisioningAttributeDelete,
+		CustomizeDiff: userGroupProvisioningAttributeCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the SCIM enabled application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attribute_name": {
+				Description: "The name of the SCIM attribute.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attribute_value": {
+				Description: "The value sent for the attribute. Required unless `operation` is `remove`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"operation": {
+				Description:  "The SCIM patch operation used for the attribute. Possible values: `add`, `replace`, `remove`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "replace",
+				ValidateFunc: validation.StringInSlice([]string{"add", "replace", "remove"}, false),
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userGroupProvisioningAttributeImporter,
+		},
+	}
+}
+
+func userGroupProvisioningAttributeImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), "/", 3)
+	if len(ids) != 3 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'application_id/group_id/attribute_name'")
+	}
+
+	_ = d.Set("application_id", ids[0])
+	_ = d.Set("group_id", ids[1])
+	_ = d.Set("attribute_name", ids[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func userGroupProvisioningAttributeCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("operation").(string) != "remove" && d.Get("attribute_value").(string) == "" &&
+		d.NewValueKnown("attribute_value") {
+		return fmt.Errorf("attribute_value is required unless operation is remove")
+	}
+	return nil
+}
+
+func userGroupProvisioningAttributePath(d *schema.ResourceData) string {
+	return "/applications/" + d.Get("application_id").(string) +
+		"/provisioning/usergroups/" + d.Get("group_id").(string) +
+		"/attributes/" + d.Get("attribute_name").(string)
+}
+
+func putUserGroupProvisioningAttribute(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	payload := ProvisioningAttribute{
+		Name:      d.Get("attribute_name").(string),
+		Value:     d.Get("attribute_value").(string),
+		Operation: d.Get("operation").(string),
+	}
+
+	_, err := jumpCloudRequest(config, http.MethodPut, userGroupProvisioningAttributePath(d), payload, nil)
+	if err != nil {
+		return fmt.Errorf("error setting provisioning attribute %s of application %s for group %s: %s",
+			payload.Name, d.Get("application_id"), d.Get("group_id"), err)
+	}
+	return nil
+}
+
+func resourceUserGroupProvisioningAttributeCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupProvisioningAttribute(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("application_id").(string) + "/" + d.Get("group_id").(string) +
+		"/" + d.Get("attribute_name").(string))
+	return resourceUserGroupProvisioningAttributeRead(d, m)
+}
+
+func resourceUserGroupProvisioningAttributeRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var attribute ProvisioningAttribute
+	ok, err := jumpCloudRequest(config, http.MethodGet, userGroupProvisioningAttributePath(d), nil, &attribute)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("attribute_value", attribute.Value); err != nil {
+		return err
+	}
+	if err := d.Set("operation", attribute.Operation); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceUserGroupProvisioningAttributeUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupProvisioningAttribute(d, m); err != nil {
+		return err
+	}
+	return resourceUserGroupProvisioningAttributeRead(d, m)
+}
+
+func resourceUserGroupProvisioningAttributeDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete, userGroupProvisioningAttributePath(d), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error removing provisioning attribute %s of application %s for group %s: %s",
+			d.Get("attribute_name"), d.Get("application_id"), d.Get("group_id"), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,66 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccUserGroupProvisioningAttribute(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	applicationID := os.Getenv("JUMPCLOUD_SCIM_APPLICATION_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SCIM_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserGroupProvisioningAttribute(rName, applicationID, "", "add"),
+				ExpectError: regexp.MustCompile("attribute_value is required"),
+			},
+			{
+				Config: testAccUserGroupProvisioningAttribute(rName, applicationID, "engineering", "add"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group_provisioning_attribute.test_attribute", "attribute_value", "engineering"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_provisioning_attribute.test_attribute", "operation", "add"),
+				),
+			},
+			{
+				Config: testAccUserGroupProvisioningAttribute(rName, applicationID, "sales", "replace"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_provisioning_attribute.test_attribute",
+					"operation", "replace"),
+			},
+			{
+				ResourceName:      "jumpcloud_user_group_provisioning_attribute.test_attribute",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserGroupProvisioningAttribute(name, applicationID, value, operation string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_user_group_provisioning_attribute" "test_attribute" {
+			application_id  = "%s"
+			group_id        = jumpcloud_user_group.test_group.id
+			attribute_name  = "department"
+			attribute_value = "%s"
+			operation       = "%s"
+		}`, name, applicationID, value, operation,
+	)
+}
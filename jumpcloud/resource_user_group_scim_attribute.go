@@ -0,0 +1,131 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceUserGroupScimAttribute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for mapping a custom SCIM attribute of an application for a JumpCloud user group.",
+		Create:      resourceUserGroupScimAttributeCreate,
+		Read:        resourceUserGroupScimAttributeRead,
+		Update:      resourceUserGroupScimAttributeUpdate,
+		Delete:      resourceUserGroupScimAttributeDelete,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Description: "The ID of the SCIM enabled application.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"scim_attribute": {
+				Description: "The name of the custom SCIM extension attribute, e.g. `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:costCenter`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"value_expression": {
+				Description: "The JumpCloud attribute expression the SCIM attribute is populated from.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userGroupScimAttributeImporter,
+		},
+	}
+}
+
+func userGroupScimAttributeImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), "/", 3)
+	if len(ids) != 3 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'application_id/group_id/scim_attribute'")
+	}
+
+	_ = d.Set("application_id", ids[0])
+	_ = d.Set("group_id", ids[1])
+	_ = d.Set("scim_attribute", ids[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func userGroupScimAttributePath(d *schema.ResourceData) string {
+	return "/applications/" + d.Get("application_id").(string) +
+		"/scim/usergroups/" + d.Get("group_id").(string) +
+		"/attributes/" + d.Get("scim_attribute").(string)
+}
+
+func putUserGroupScimAttribute(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	payload := ScimAttributeMapping{
+		Name:            d.Get("scim_attribute").(string),
+		ValueExpression: d.Get("value_expression").(string),
+	}
+
+	_, err := jumpCloudRequest(config, http.MethodPut, userGroupScimAttributePath(d), payload, nil)
+	if err != nil {
+		return fmt.Errorf("error mapping SCIM attribute %s of application %s for group %s: %s",
+			payload.Name, d.Get("application_id"), d.Get("group_id"), err)
+	}
+	return nil
+}
+
+func resourceUserGroupScimAttributeCreate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupScimAttribute(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("application_id").(string) + "/" + d.Get("group_id").(string) +
+		"/" + d.Get("scim_attribute").(string))
+	return resourceUserGroupScimAttributeRead(d, m)
+}
+
+func resourceUserGroupScimAttributeRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	var mapping ScimAttributeMapping
+	ok, err := jumpCloudRequest(config, http.MethodGet, userGroupScimAttributePath(d), nil, &mapping)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// not found
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("value_expression", mapping.ValueExpression); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceUserGroupScimAttributeUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := putUserGroupScimAttribute(d, m); err != nil {
+		return err
+	}
+	return resourceUserGroupScimAttributeRead(d, m)
+}
+
+func resourceUserGroupScimAttributeDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	_, err := jumpCloudRequest(config, http.MethodDelete, userGroupScimAttributePath(d), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error removing SCIM attribute %s of application %s for group %s: %s",
+			d.Get("scim_attribute"), d.Get("application_id"), d.Get("group_id"), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,60 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccUserGroupScimAttribute(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	applicationID := os.Getenv("JUMPCLOUD_SCIM_APPLICATION_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if applicationID == "" {
+				t.Skip("JUMPCLOUD_SCIM_APPLICATION_ID must be set for this acceptance test")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupScimAttribute(rName, applicationID, "${user.costCenter}"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group_scim_attribute.test_attribute", "scim_attribute", "costCenter"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_scim_attribute.test_attribute", "value_expression", "${user.costCenter}"),
+				),
+			},
+			{
+				Config: testAccUserGroupScimAttribute(rName, applicationID, "${user.department}"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group_scim_attribute.test_attribute",
+					"value_expression", "${user.department}"),
+			},
+			{
+				ResourceName:      "jumpcloud_user_group_scim_attribute.test_attribute",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserGroupScimAttribute(name, applicationID, expression string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_user_group_scim_attribute" "test_attribute" {
+			application_id   = "%s"
+			group_id         = jumpcloud_user_group.test_group.id
+			scim_attribute   = "costCenter"
+			value_expression = "$%s"
+		}`, name, applicationID, expression,
+	)
+}
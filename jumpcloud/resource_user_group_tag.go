@@ -0,0 +1,129 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceUserGroupTag() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a single tag of a JumpCloud user group.",
+		Create:      resourceUserGroupTagCreate,
+		Read:        resourceUserGroupTagRead,
+		Delete:      resourceUserGroupTagDelete,
+		Schema: map[string]*schema.Schema{
+			"user_group_id": {
+				Description: "The ID of the `resource_user_group` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"tag": {
+				Description: "The tag.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userGroupTagImporter,
+		},
+	}
+}
+
+func userGroupTagImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), "/", 2)
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'user_group_id/tag'")
+	}
+
+	_ = d.Set("user_group_id", ids[0])
+	_ = d.Set("tag", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateUserGroupTags rewrites the tags of a user group, ok is false if
+// the group doesn't exist
+func updateUserGroupTags(config *Meta, groupID string,
+	modify func([]string) []string) (ok bool, err error) {
+
+	groupTagsMutex.Lock(groupID)
+	defer groupTagsMutex.Unlock(groupID)
+
+	group, ok, err := userGroupReadHelper(requestContext(config), config, groupID)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	payload := GroupTags{Tags: modify(group.Tags)}
+	_, err = jumpCloudRequest(config, http.MethodPatch, "/usergroups/"+groupID, payload, nil)
+	if err != nil {
+		return true, fmt.Errorf("error updating tags of user group %s: %s", groupID, err)
+	}
+	return true, nil
+}
+
+func resourceUserGroupTagCreate(d *schema.ResourceData, m interface{}) error {
+	tag := d.Get("tag").(string)
+
+	groupID := d.Get("user_group_id").(string)
+	ok, err := updateUserGroupTags(m.(*Meta), groupID,
+		func(tags []string) []string {
+			if stringInSlice(tag, tags) {
+				return tags
+			}
+			return append(tags, tag)
+		})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("user group %s not found", groupID)
+	}
+
+	d.SetId(groupID + "/" + tag)
+	return resourceUserGroupTagRead(d, m)
+}
+
+func resourceUserGroupTagRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	group, ok, err := userGroupReadHelper(requestContext(config), config, d.Get("user_group_id").(string))
+	if err != nil {
+		return err
+	}
+
+	if !ok || !stringInSlice(d.Get("tag").(string), group.Tags) {
+		// the group or the tag is gone
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceUserGroupTagDelete(d *schema.ResourceData, m interface{}) error {
+	tag := d.Get("tag").(string)
+
+	ok, err := updateUserGroupTags(m.(*Meta), d.Get("user_group_id").(string),
+		func(tags []string) []string {
+			out := []string{}
+			for _, t := range tags {
+				if t != tag {
+					out = append(out, t)
+				}
+			}
+			return out
+		})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// the tag is gone with the group
+		log.Printf("[WARN] user group of tag %s not found, removing from state", d.Id())
+	}
+	d.SetId("")
+	return nil
+}
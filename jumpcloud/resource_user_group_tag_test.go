@@ -0,0 +1,76 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccUserGroupTag(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupTag(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group_tag.test_tag.0", "tag", "production"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group_tag.test_tag.1", "tag", "linux"),
+				),
+			},
+			{
+				ResourceName:      "jumpcloud_user_group_tag.test_tag.0",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserGroupTag(name string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+		}
+
+		resource "jumpcloud_user_group_tag" "test_tag" {
+			count           = 2
+			user_group_id   = jumpcloud_user_group.test_group.id
+			tag             = ["production", "linux"][count.index]
+		}`, name,
+	)
+}
+
+func TestUserGroupTagGroupDeleted(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/usergroups/group", r.URL.Path)
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceUserGroupTag()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"user_group_id": "group",
+		"tag":           "engineering",
+	})
+	assert.EqualError(t, r.Create(d, config), "user group group not found")
+
+	// the tag is gone with the group
+	d.SetId("group/engineering")
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, "", d.Id())
+}
@@ -2,16 +2,21 @@ package jumpcloud
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -36,22 +41,27 @@ func TestAccUserGroup(t *testing.T) {
 				Config: testAccUserGroupCreate(rName, gid, posixName),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "name", rName),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "description", "Created by the acceptance tests"),
 					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group",
 						"attributes.posix_groups", fmt.Sprintf("%d:%s", gid, posixName)),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "posix_gid", fmt.Sprint(gid)),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "posix_name", posixName),
 					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.#", "123"),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.0", emails[0]),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.60", emails[60]),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.99", emails[99]),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.100", emails[100]),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.122", emails[122]),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", emails[0]),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", emails[60]),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", emails[99]),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", emails[100]),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", emails[122]),
 				),
 			},
-			{ //check update works
+			{ //check update works, moving from posix_groups to posix_gid and posix_name keeps the group
 				Config: testAccUserGroupUpdate(rName, gid, posixName),
 				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group",
+						"attributes.posix_groups", fmt.Sprintf("%d:%s", gid, posixName)),
 					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.#", "2"),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.0", fmt.Sprintf("%s1@testorg.com", rName)),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.1", fmt.Sprintf("%s2@testorg.com", rName)),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", fmt.Sprintf("%s1@testorg.com", rName)),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", fmt.Sprintf("%s2@testorg.com", rName)),
 				),
 			},
 			{ //add a user to the group via the api, then check the plan complains
@@ -63,15 +73,68 @@ func TestAccUserGroup(t *testing.T) {
 			{ //remove the extra user
 				Config: testAccUserGroupUpdate(rName, gid, posixName),
 				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckGroupMemberAbsent(rName, fmt.Sprintf("%s43@testorg.com", rName)),
 					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.#", "2"),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.0", fmt.Sprintf("%s1@testorg.com", rName)),
-					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.1", fmt.Sprintf("%s2@testorg.com", rName)),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", fmt.Sprintf("%s1@testorg.com", rName)),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", fmt.Sprintf("%s2@testorg.com", rName)),
+				),
+			},
+			{ //import by ID
+				ResourceName:      "jumpcloud_user_group.test_group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{ //import by name
+				ResourceName:      "jumpcloud_user_group.test_group",
+				ImportState:       true,
+				ImportStateId:     rName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccUserGroupNameDrift(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	posixName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	gid := acctest.RandIntRange(1, 1000)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupNameDrift(rName, gid, posixName),
+				Check:  resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "name", rName),
+			},
+			{ //rename the group via the api, then check the plan complains
+				PreConfig:          renameGroupViaAPI(t, rName, rName+"_renamed"),
+				Config:             testAccUserGroupNameDrift(rName, gid, posixName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{ //restore the name, keeping the posix group
+				Config: testAccUserGroupNameDrift(rName, gid, posixName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "name", rName),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "posix_gid", fmt.Sprint(gid)),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "posix_name", posixName),
 				),
 			},
 		},
 	})
 }
 
+func testAccUserGroupNameDrift(name string, gid int, posixName string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name       = "%s"
+			posix_gid  = %d
+			posix_name = "%s"
+		}`, name, gid, posixName,
+	)
+}
+
 func testAccUserGroupCreate(name string, gid int, posixName string) string {
 	return fmt.Sprintf(`
 		resource "jumpcloud_user" "test_users" {
@@ -85,6 +148,7 @@ func testAccUserGroupCreate(name string, gid int, posixName string) string {
 		}
 		resource "jumpcloud_user_group" "test_group" {
     		name = "%[1]s"
+			description = "Created by the acceptance tests"
 			attributes = {
 				posix_groups = "%[2]d:%[3]s"
 			}
@@ -105,9 +169,8 @@ func testAccUserGroupUpdate(name string, gid int, posixName string) string {
 		}
 		resource "jumpcloud_user_group" "test_group" {
     		name = "%[1]s"
-			attributes = {
-				posix_groups = "%[2]d:%[3]s"
-			}
+			posix_gid = %[2]d
+			posix_name = "%[3]s"
 			members = [
 				jumpcloud_user.test_users[2].email,
 				jumpcloud_user.test_users[1].email,
@@ -116,43 +179,539 @@ func testAccUserGroupUpdate(name string, gid int, posixName string) string {
 	)
 }
 
+func TestAccUserGroupLdapUserAuthentication(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupLdapUserAuthentication(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group",
+						"enable_ldap_user_authentication", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group",
+						"ldap_server_associated", "false"),
+				),
+			},
+			{
+				Config: testAccUserGroupLdapUserAuthentication(rName, false),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_group.test_group",
+					"enable_ldap_user_authentication", "false"),
+			},
+		},
+	})
+}
+
+func testAccUserGroupLdapUserAuthentication(name string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%s"
+			enable_ldap_user_authentication = %t
+		}`, name, enabled,
+	)
+}
+
+func TestAccUserGroupSambaAndSudo(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserGroupSambaAndSudo(rName, false, true),
+				ExpectError: regexp.MustCompile("enable_samba on user group .* requires enable_ldap_user_authentication"),
+			},
+			{
+				Config: testAccUserGroupSambaAndSudo(rName, true, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "enable_samba", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "sudo.0.enabled", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "sudo.0.without_password", "true"),
+				),
+			},
+			{
+				Config: testAccUserGroupSambaAndSudo(rName, true, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "enable_samba", "false"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "sudo.0.enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserGroupSambaAndSudo(name string, ldap, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user_group" "test_group" {
+			name                            = "%[1]s"
+			enable_ldap_user_authentication = %[2]t
+			enable_samba                    = %[3]t
+
+			sudo {
+				enabled          = %[3]t
+				without_password = %[3]t
+			}
+		}`, name, ldap, enabled,
+	)
+}
+
+func TestUserGroupAuthenticationDiff(t *testing.T) {
+	diff := func(config map[string]interface{}) error {
+		config["name"] = "admins"
+		_, err := resourceUserGroup().Diff(&terraform.InstanceState{}, terraform.NewResourceConfigRaw(config),
+			newMeta(jcapiv2.NewConfiguration()))
+		return err
+	}
+
+	err := diff(map[string]interface{}{"enable_samba": true})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "enable_samba on user group admins requires enable_ldap_user_authentication")
+	}
+	assert.NoError(t, diff(map[string]interface{}{"enable_samba": true, "enable_ldap_user_authentication": true}))
+
+	err = diff(map[string]interface{}{"sudo": []interface{}{map[string]interface{}{"without_password": true}}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "sudo.without_password on user group admins requires sudo.enabled")
+	}
+	assert.NoError(t, diff(map[string]interface{}{
+		"sudo": []interface{}{map[string]interface{}{"enabled": true, "without_password": true}},
+	}))
+}
+
+func TestFlattenUserGroupSudo(t *testing.T) {
+	block := func(enabled, withoutPassword bool) []interface{} {
+		return []interface{}{map[string]interface{}{"enabled": enabled, "without_password": withoutPassword}}
+	}
+
+	assert.Equal(t, block(true, false), flattenUserGroupSudo(&UserGroupSudo{Enabled: true}, nil))
+	// no sudo and no block configured
+	assert.Equal(t, []interface{}{}, flattenUserGroupSudo(nil, nil))
+	assert.Equal(t, []interface{}{}, flattenUserGroupSudo(&UserGroupSudo{}, nil))
+	// sudo { enabled = false } isn't drift
+	assert.Equal(t, block(false, false), flattenUserGroupSudo(nil, block(false, false)))
+
+	assert.Equal(t, &UserGroupSudo{}, expandUserGroupSudo(nil))
+	assert.Equal(t, &UserGroupSudo{Enabled: true, WithoutPassword: true}, expandUserGroupSudo(block(true, true)))
+}
+
+func TestAccUserGroupTriggers(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGroupTriggers(rName, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "triggers.sync", "1"),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.#", "1"),
+				),
+			},
+			{ //add a user to the group via the api, then bump the trigger
+				PreConfig: addGroupMemberViaAPI(t, rName),
+				Config:    testAccUserGroupTriggers(rName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "triggers.sync", "2"),
+					testCheckGroupMemberAbsent(rName, fmt.Sprintf("%s43@testorg.com", rName)),
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.#", "1"),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", fmt.Sprintf("%s1@testorg.com", rName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserGroupTriggers(name string, trigger string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_users" {
+			count = 44
+
+			username = "%[1]s${count.index}"
+			email = "%[1]s${count.index}@testorg.com"
+			firstname = "Firstname"
+			lastname = "Lastname"
+		}
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+			members = [jumpcloud_user.test_users[1].email]
+			triggers = {
+				sync = "%[2]s"
+			}
+		}`, name, trigger,
+	)
+}
+
+func TestAccUserGroupMembershipExpiry(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				// the membership of the second user has already expired
+				Config: testAccUserGroupMembershipExpiry(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_group.test_group", "members.#", "1"),
+					testCheckTypeSetElemAttr("jumpcloud_user_group.test_group", "members.*", fmt.Sprintf("%s0@testorg.com", rName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserGroupMembershipExpiry(name string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_users" {
+			count = 2
+
+			username = "%[1]s${count.index}"
+			email = "%[1]s${count.index}@testorg.com"
+			firstname = "Firstname"
+			lastname = "Lastname"
+		}
+		resource "jumpcloud_user_group" "test_group" {
+			name = "%[1]s"
+			members = jumpcloud_user.test_users[*].email
+			membership_expiry = {
+				"%[1]s0@testorg.com" = "2999-01-01T00:00:00Z"
+				"%[1]s1@testorg.com" = "2000-01-01T00:00:00Z"
+			}
+		}`, name,
+	)
+}
+
+// testCheckTypeSetElemAttr checks that the set attr, given as "name.*",
+// has an element equal to value
+func testCheckTypeSetElemAttr(name, attr, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		prefix := strings.TrimSuffix(attr, "*")
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, prefix) && k != prefix+"#" && v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: no element of %s equals %q", name, attr, value)
+	}
+}
+
+func TestUnexpiredMembers(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	members := []interface{}{"a@testorg.com", "b@testorg.com", "c@testorg.com"}
+	expiry := map[string]interface{}{
+		"a@testorg.com": "2024-05-31T23:59:59Z",
+		"b@testorg.com": "2024-06-01T00:00:01Z",
+	}
+
+	kept, expired := unexpiredMembers(members, expiry, now)
+	assert.Equal(t, []interface{}{"b@testorg.com", "c@testorg.com"}, kept)
+	assert.Equal(t, []string{"a@testorg.com"}, expired)
+
+	_, errs := validateMembershipExpiry(map[string]interface{}{"a@testorg.com": "tomorrow"}, "membership_expiry")
+	assert.Len(t, errs, 1)
+	_, errs = validateMembershipExpiry(expiry, "membership_expiry")
+	assert.Empty(t, errs)
+}
+
+func TestCheckMemberRemovalLimit(t *testing.T) {
+	assert.NoError(t, checkMemberRemovalLimit("admins", 100, 0))
+	assert.NoError(t, checkMemberRemovalLimit("admins", 5, 5))
+	assert.Error(t, checkMemberRemovalLimit("admins", 6, 5))
+}
+
+func TestExpandUserGroupAttributes(t *testing.T) {
+	attr, ok := expandUserGroupAttributes(map[string]interface{}{}, 1001, "admins")
+	assert.True(t, ok)
+	assert.Equal(t, []jcapiv2.UserGroupAttributesPosixGroups{{Id: 1001, Name: "admins"}}, attr.PosixGroups)
+
+	// the deprecated "gid:name" form is still understood
+	attr, ok = expandUserGroupAttributes(map[string]interface{}{"posix_groups": "1002:devs"}, 0, "")
+	assert.True(t, ok)
+	assert.Equal(t, []jcapiv2.UserGroupAttributesPosixGroups{{Id: 1002, Name: "devs"}}, attr.PosixGroups)
+
+	// posix_gid and posix_name take precedence over it
+	attr, ok = expandUserGroupAttributes(map[string]interface{}{"posix_groups": "1002:devs"}, 1001, "admins")
+	assert.True(t, ok)
+	assert.Equal(t, []jcapiv2.UserGroupAttributesPosixGroups{{Id: 1001, Name: "admins"}}, attr.PosixGroups)
+
+	_, ok = expandUserGroupAttributes(map[string]interface{}{}, 0, "")
+	assert.False(t, ok)
+}
+
 func addGroupMemberViaAPI(t *testing.T, name string) func() {
 	return func() {
-		config := jcapiv2.NewConfiguration()
-		config.AddDefaultHeader("x-api-key", os.Getenv("JUMPCLOUD_API_KEY"))
-		client := jcapiv2.NewAPIClient(config)
+		addGroupMemberByEmail(t, name, fmt.Sprintf("%s43@testorg.com", name))
+	}
+}
 
-		groups, _, err := client.UserGroupsApi.GroupsUserList(context.Background(), "", "", map[string]interface{}{
-			"filter": []string{fmt.Sprintf(`name:eq:%s`, name)},
-		})
+// testAccAPIConfig returns a configuration for calls to the API made by the
+// acceptance tests outside of Terraform
+func testAccAPIConfig() *Meta {
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.AddDefaultHeader("x-api-key", os.Getenv("JUMPCLOUD_API_KEY"))
+	if orgID := os.Getenv("JUMPCLOUD_ORG_ID"); orgID != "" {
+		config.AddDefaultHeader("x-org-id", orgID)
+	}
+	return config
+}
+
+// addGroupMemberByEmail adds the user with the email to the user group
+// with the name behind Terraform's back, the way the provider does
+func addGroupMemberByEmail(t *testing.T, groupName, email string) {
+	config := testAccAPIConfig()
+
+	groupID, err := userGroupIDByName(config, groupName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := userEmailsToIDs(context.TODO(), config, []interface{}{email})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := resourceUserGroup().Data(&terraform.InstanceState{ID: groupID})
+	if err := manageGroupMember(context.TODO(), config, jcapiv2.NewAPIClient(config.Configuration), d, ids[0], "add"); err != nil {
+		t.Fatalf("error adding %s to group %s via api: %s", email, groupName, err)
+	}
+}
+
+// testCheckGroupMemberAbsent checks the API, not the state, for the user
+// with the email not being a member of the user group with the name
+func testCheckGroupMemberAbsent(groupName, email string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccAPIConfig()
+
+		groupID, err := userGroupIDByName(config, groupName)
 		if err != nil {
-			t.Fatal(err)
+			return err
+		}
+		memberIDs, err := getUserGroupMemberIDs(context.TODO(), config, groupID)
+		if err != nil {
+			return err
 		}
+		emails, _, err := userIDsToEmails(context.TODO(), config, memberIDs)
+		if err != nil {
+			return err
+		}
+		if containsFold(emails, email) {
+			return fmt.Errorf("%s is still a member of user group %s", email, groupName)
+		}
+		return nil
+	}
+}
 
-		email := make([]interface{}, 1)
-		email[0] = fmt.Sprintf("%s43@testorg.com", name)
+// renameGroupViaAPI renames the group, resending its attributes as the
+// provider does
+func renameGroupViaAPI(t *testing.T, name, newName string) func() {
+	return func() {
+		config := testAccAPIConfig()
 
-		ids, err := userEmailsToIDs(config, email)
+		id, err := userGroupIDByName(config, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		group, _, err := userGroupReadHelper(context.TODO(), config, id)
 		if err != nil {
 			t.Fatal(err)
 		}
-		payload := jcapiv2.UserGroupMembersReq{
-			Op:    "add",
-			Type_: "user",
-			Id:    ids[0],
+
+		body := UserGroupPost{Name: newName, Description: group.Description, Attributes: &group.Attributes, Tags: group.Tags}
+		if _, err := jumpCloudRequest(config, http.MethodPatch, "/usergroups/"+id, body, nil); err != nil {
+			t.Fatalf("error renaming group %s via api: %s", name, err)
+		}
+	}
+}
+
+func TestUserGroupRequestAttributes(t *testing.T) {
+	// only the name changed, the posix group in state is resent
+	d := resourceUserGroup().Data(&terraform.InstanceState{
+		ID: "id",
+		Attributes: map[string]string{
+			"name":       "old",
+			"posix_gid":  "1001",
+			"posix_name": "admins",
+		},
+	})
+	assert.NoError(t, d.Set("name", "new"))
+	assert.Equal(t, []jcapiv2.UserGroupAttributesPosixGroups{{Id: 1001, Name: "admins"}},
+		userGroupRequestAttributes(d).PosixGroups)
+
+	// groups without a posix group can be renamed as well
+	d = resourceUserGroup().Data(&terraform.InstanceState{
+		ID: "id",
+		Attributes: map[string]string{
+			"name":                            "old",
+			"enable_ldap_user_authentication": "true",
+		},
+	})
+	assert.NoError(t, d.Set("name", "new"))
+	attributes := userGroupRequestAttributes(d)
+	assert.Empty(t, attributes.PosixGroups)
+	assert.True(t, attributes.EnableLdapUserAuthentication)
+	// sudo is disabled without a block
+	assert.Equal(t, &UserGroupSudo{}, attributes.Sudo)
+
+	// removing the deprecated attributes keeps the posix group as well
+	d = resourceUserGroup().Data(&terraform.InstanceState{
+		ID: "id",
+		Attributes: map[string]string{
+			"name":                    "admins",
+			"attributes.%":            "1",
+			"attributes.posix_groups": "1001:admins",
+			"posix_gid":               "1001",
+			"posix_name":              "admins",
+		},
+	})
+	assert.NoError(t, d.Set("attributes", nil))
+	assert.Equal(t, []jcapiv2.UserGroupAttributesPosixGroups{{Id: 1001, Name: "admins"}},
+		userGroupRequestAttributes(d).PosixGroups)
+}
+
+func TestUserGroupUpdateHelper(t *testing.T) {
+	var patched UserGroupPost
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/usergroups/id", r.URL.Path)
+		if r.Method == http.MethodPatch {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(rw).Encode(UserGroup{ID: "id", Name: "old", Tags: []string{"a", "b"}}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	// the tags set by jumpcloud_user_group_tag are resent with the rename
+	d := resourceUserGroup().Data(&terraform.InstanceState{ID: "id", Attributes: map[string]string{"name": "old"}})
+	assert.NoError(t, d.Set("name", "new"))
+	assert.NoError(t, userGroupUpdateHelper(context.TODO(), d, config))
+	assert.Equal(t, "new", patched.Name)
+	assert.Equal(t, []string{"a", "b"}, patched.Tags)
+}
+
+func TestUserGroupPosixDiff(t *testing.T) {
+	state := func(attributes map[string]string) *terraform.InstanceState {
+		attributes["name"] = "admins"
+		return &terraform.InstanceState{ID: "id", Attributes: attributes}
+	}
+	posixState := state(map[string]string{
+		"attributes.%":            "1",
+		"attributes.posix_groups": "1001:admins",
+		"posix_gid":               "1001",
+		"posix_name":              "admins",
+	})
+	diff := func(s *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceDiff, error) {
+		config["name"] = "admins"
+		return resourceUserGroup().Diff(s, terraform.NewResourceConfigRaw(config), newMeta(jcapiv2.NewConfiguration()))
+	}
+
+	// changing the posix group fails at plan time
+	_, err := diff(posixState, map[string]interface{}{"posix_gid": 1002, "posix_name": "admins"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the POSIX group 1001:admins of user group admins can't be changed to 1002:admins "+
+			"after the group was created, create a new group instead")
+	}
+	_, err = diff(posixState, map[string]interface{}{"attributes": map[string]interface{}{"posix_groups": "1001:devs"}})
+	assert.Error(t, err)
+
+	// as does adding one
+	_, err = diff(state(map[string]string{"posix_gid": "0"}), map[string]interface{}{"posix_gid": 1001, "posix_name": "admins"})
+	assert.Error(t, err)
+
+	// moving from attributes to posix_gid and posix_name keeps the group
+	_, err = diff(posixState, map[string]interface{}{"posix_gid": 1001, "posix_name": "admins"})
+	assert.NoError(t, err)
+
+	// as does removing attributes entirely
+	_, err = diff(posixState, map[string]interface{}{})
+	assert.NoError(t, err)
+}
+
+func TestUserGroupReadTimeout(t *testing.T) {
+	// the API never answers
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
 		}
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL
+
+	r := resourceUserGroup()
+	timeout := 50 * time.Millisecond
+	r.Timeouts.Read = &timeout
+	d := r.Data(&terraform.InstanceState{ID: "group"})
+
+	start := time.Now()
+	err = resourceUserGroupRead(d, config)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "read timed out after 50ms")
+}
 
-		req := map[string]interface{}{
-			"body": payload,
+func TestUserGroupImporter(t *testing.T) {
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("filter") {
+		case "name:eq:Engineering":
+			rw.Write([]byte(`[{"id":"5f0c1b2a3d4e5f6a7b8c9d0e","name":"Engineering"}]`))
+		case "name:eq:Ops":
+			rw.Write([]byte(`[{"id":"5f0c1b2a3d4e5f6a7b8c9d01","name":"Ops"},{"id":"5f0c1b2a3d4e5f6a7b8c9d02","name":"Ops"}]`))
+		default:
+			rw.Write([]byte(`[]`))
 		}
+	}))
+	defer testServer.Close()
 
-		_, err = client.UserGroupMembersMembershipApi.GraphUserGroupMembersPost(
-			context.TODO(), groups[0].Id, "", "", req)
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
 
+	importID := func(id string) (string, error) {
+		d := resourceUserGroup().Data(&terraform.InstanceState{ID: id})
+		imported, err := userGroupImporter(d, config)
 		if err != nil {
-			t.Fatal(fmt.Sprintf("error adding user to group via api. group_id: %s, user_id: %s, error: %s", groups[0].Id, ids[0], err))
+			return "", err
 		}
+		return imported[0].Id(), nil
 	}
+
+	// IDs are imported as they are
+	id, err := importID("5f0c1b2a3d4e5f6a7b8c9d0f")
+	assert.NoError(t, err)
+	assert.Equal(t, "5f0c1b2a3d4e5f6a7b8c9d0f", id)
+	assert.Equal(t, 0, requests)
+
+	id, err = importID("Engineering")
+	assert.NoError(t, err)
+	assert.Equal(t, "5f0c1b2a3d4e5f6a7b8c9d0e", id)
+
+	_, err = importID("Ops")
+	assert.EqualError(t, err, "2 user groups are named Ops (5f0c1b2a3d4e5f6a7b8c9d01, "+
+		"5f0c1b2a3d4e5f6a7b8c9d02), refer to the group by ID instead")
+
+	_, err = importID("Nobody")
+	assert.EqualError(t, err, "no user group found with name: Nobody")
 }
 
 func TestResourceUserGroup(t *testing.T) {
@@ -187,14 +746,57 @@ func (s *ResourceUserGroupSuite) TestTrueUserGroupRead() {
 			rw.Write(c.Payload)
 		}))
 
-		config := &jcapiv2.Configuration{
+		config := newMeta(&jcapiv2.Configuration{
 			BasePath: testServer.URL,
-		}
+		})
 
-		ug, ok, err := userGroupReadHelper(config, "id")
+		ug, ok, err := userGroupReadHelper(context.TODO(), config, "id")
 		s.A.Equal(c.OK, ok)
 		s.A.Equal(c.UserGroupNil, ug == nil)
 		s.A.Equal(c.ErrorNil, err == nil)
 		testServer.Close()
 	}
 }
+
+func TestUserGroupPosixConflicts(t *testing.T) {
+	r := resourceUserGroup()
+
+	// the deprecated attributes can't be combined with posix_gid and posix_name
+	_, errs := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":       "developers",
+		"attributes": map[string]interface{}{"posix_groups": "1001:developers"},
+		"posix_gid":  1001,
+		"posix_name": "developers",
+	}))
+	assert.NotEmpty(t, errs)
+
+	warns, errs := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":       "developers",
+		"attributes": map[string]interface{}{"posix_groups": "1001:developers"},
+	}))
+	assert.Empty(t, errs)
+	assert.NotEmpty(t, warns)
+}
+
+func TestUserGroupHasLdapServer(t *testing.T) {
+	var servers []jcapiv2.GraphConnection
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/usergroups/group/associations", r.URL.Path)
+		assert.Equal(t, "ldap_server", r.URL.Query().Get("targets"))
+		rw.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(rw).Encode(servers))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	ok, err := userGroupHasLdapServer(context.TODO(), config, "group")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	servers = []jcapiv2.GraphConnection{{To: &jcapiv2.GraphObject{Id: "ldap", Type_: "ldap_server"}}}
+	ok, err = userGroupHasLdapServer(context.TODO(), config, "group")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,170 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// userAttributesMutex serializes the read-modify-write cycles on the
+// custom attributes of a user
+var userAttributesMutex = mutexkv.NewMutexKV()
+
+func resourceUserLdapAttribute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a resource for managing a custom LDAP attribute of a JumpCloud user.",
+		Create:      resourceUserLdapAttributeCreate,
+		Read:        resourceUserLdapAttributeRead,
+		Update:      resourceUserLdapAttributeUpdate,
+		Delete:      resourceUserLdapAttributeDelete,
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "The ID of the `resource_user` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attribute_name": {
+				Description: "The name of the LDAP attribute.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"attribute_value": {
+				Description: "The value of the LDAP attribute.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userLdapAttributeImporter,
+		},
+	}
+}
+
+func userLdapAttributeImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ids := strings.SplitN(d.Id(), "/", 2)
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("Invalid import format. Expected 'user_id/attribute_name'")
+	}
+
+	_ = d.Set("user_id", ids[0])
+	_ = d.Set("attribute_name", ids[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateUserAttributes reads the custom attributes of a user, applies modify
+// and writes them back, leaving all other attributes untouched. Updates of
+// the same user are serialized, so parallel attributes don't overwrite
+// each other.
+func updateUserAttributes(config *Meta, userID string,
+	modify func([]interface{}) []interface{}) error {
+	userAttributesMutex.Lock(userID)
+	defer userAttributesMutex.Unlock(userID)
+
+	ctx := requestContext(config)
+
+	client := jcapiv1.NewAPIClient(convertV2toV1Config(config))
+
+	user, res, err := client.SystemusersApi.SystemusersGet(ctx,
+		userID, "", "", nil)
+	if err != nil {
+		return fmt.Errorf("error reading user %s: %w", userID, apiError(res, err))
+	}
+
+	attributes := modify(user.Attributes)
+	if len(attributes) == 0 {
+		// an empty list would be omitted from the SDK payload, so the
+		// last attribute has to be cleared through the HTTP API directly
+		_, err := jumpCloudV1Request(config, http.MethodPut, "/systemusers/"+userID,
+			map[string]interface{}{"attributes": attributes}, nil)
+		return err
+	}
+
+	req := map[string]interface{}{
+		"body": jcapiv1.Systemuserput{Attributes: attributes},
+	}
+	_, res, err = client.SystemusersApi.SystemusersPut(ctx,
+		userID, "", "", req)
+	if err != nil {
+		return fmt.Errorf("error updating attributes of user %s: %w", userID, apiError(res, err))
+	}
+	return nil
+}
+
+func resourceUserLdapAttributeCreate(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("attribute_name").(string)
+	value := d.Get("attribute_value").(string)
+
+	err := updateUserAttributes(m.(*Meta), d.Get("user_id").(string),
+		func(attributes []interface{}) []interface{} {
+			return setUserAttribute(attributes, name, value)
+		})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("user_id").(string) + "/" + name)
+	return resourceUserLdapAttributeRead(d, m)
+}
+
+func resourceUserLdapAttributeRead(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	user, _, err := client.SystemusersApi.SystemusersGet(ctx,
+		d.Get("user_id").(string), "", "", nil)
+	if err != nil {
+		// see resourceUserRead, a missing user results in an EOF error
+		if err.Error() == "EOF" {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	value, ok := findUserAttribute(user.Attributes, d.Get("attribute_name").(string))
+	if !ok {
+		// attribute has been removed
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("attribute_value", value); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceUserLdapAttributeUpdate(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("attribute_name").(string)
+	value := d.Get("attribute_value").(string)
+
+	err := updateUserAttributes(m.(*Meta), d.Get("user_id").(string),
+		func(attributes []interface{}) []interface{} {
+			return setUserAttribute(attributes, name, value)
+		})
+	if err != nil {
+		return err
+	}
+	return resourceUserLdapAttributeRead(d, m)
+}
+
+func resourceUserLdapAttributeDelete(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("attribute_name").(string)
+
+	err := updateUserAttributes(m.(*Meta), d.Get("user_id").(string),
+		func(attributes []interface{}) []interface{} {
+			return removeUserAttribute(attributes, name)
+		})
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,134 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccUserLdapAttribute(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserLdapAttribute(rName, "1001"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_ldap_attribute.test_attribute",
+					"attribute_value", "1001"),
+			},
+			{
+				Config: testAccUserLdapAttribute(rName, "1002"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user_ldap_attribute.test_attribute",
+					"attribute_value", "1002"),
+			},
+		},
+	})
+}
+
+func testAccUserLdapAttribute(name, value string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username = "%[1]s"
+			email = "%[1]s@testorg.com"
+		}
+
+		resource "jumpcloud_user_ldap_attribute" "test_attribute" {
+			user_id         = jumpcloud_user.test_user.id
+			attribute_name  = "employeeNumber"
+			attribute_value = "%[2]s"
+		}`, name, value,
+	)
+}
+
+func TestUserAttributes(t *testing.T) {
+	attributes := []interface{}{
+		map[string]interface{}{"name": "costCenter", "value": "42"},
+	}
+
+	attributes = setUserAttribute(attributes, "employeeNumber", "1001")
+	value, ok := findUserAttribute(attributes, "employeeNumber")
+	assert.True(t, ok)
+	assert.Equal(t, "1001", value)
+
+	attributes = setUserAttribute(attributes, "employeeNumber", "1002")
+	assert.Len(t, attributes, 2)
+	value, _ = findUserAttribute(attributes, "employeeNumber")
+	assert.Equal(t, "1002", value)
+
+	attributes = removeUserAttribute(attributes, "employeeNumber")
+	_, ok = findUserAttribute(attributes, "employeeNumber")
+	assert.False(t, ok)
+	value, ok = findUserAttribute(attributes, "costCenter")
+	assert.True(t, ok)
+	assert.Equal(t, "42", value)
+}
+
+func TestUpdateUserAttributesConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	attributes := []interface{}{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			var body struct {
+				Attributes []interface{} `json:"attributes"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			mu.Lock()
+			attributes = body.Attributes
+			mu.Unlock()
+		} else {
+			// widen the window between reading and writing the attributes
+			time.Sleep(5 * time.Millisecond)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		assert.NoError(t, json.NewEncoder(rw).Encode(map[string]interface{}{
+			"_id":        "user",
+			"attributes": attributes,
+		}))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("attribute%d", i)
+			assert.NoError(t, updateUserAttributes(config, "user", func(attributes []interface{}) []interface{} {
+				return setUserAttribute(attributes, name, "value")
+			}))
+		}(i)
+	}
+	// a synced attribute is merged into the same list
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r := resourceUserAttributeSync()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"user_id":    "user",
+			"attributes": map[string]interface{}{"synced": "value"},
+		})
+		assert.NoError(t, r.Create(d, config))
+	}()
+	wg.Wait()
+
+	// no update is lost
+	assert.Len(t, attributes, 6)
+}
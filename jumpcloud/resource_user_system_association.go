@@ -0,0 +1,262 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceUserSystemAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Binds a JumpCloud user directly to systems, allowing the user to log in to them, " +
+			"optionally with sudo. Systems the user is bound to outside of Terraform are left alone.",
+		Create:        resourceUserSystemAssociationCreate,
+		Read:          resourceUserSystemAssociationRead,
+		Update:        resourceUserSystemAssociationUpdate,
+		Delete:        resourceUserSystemAssociationDelete,
+		CustomizeDiff: userSystemAssociationDiff,
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description:  "The ID of the user. Either `user_id` or `email` must be set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user_id", "email"},
+			},
+			"email": {
+				Description: "The e-mail address of the user, resolved to its ID when the association is created.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"system_ids": {
+				Description: "The IDs of the systems.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"sudo": {
+				Description: "Grant the user sudo on the systems.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"sudo_without_password": {
+				Description: "Let the user use sudo on the systems without entering their password. Requires `sudo`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: userSystemAssociationImporter,
+		},
+	}
+}
+
+func userSystemAssociationImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	userID, systemIDs, err := parseAssociationImportID(d.Id(), "user_id", "system_id")
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(userID)
+	_ = d.Set("user_id", userID)
+	_ = d.Set("system_ids", systemIDs)
+	return []*schema.ResourceData{d}, nil
+}
+
+// userSystemAssociationDiff rejects sudo without a password but without
+// sudo at plan time
+func userSystemAssociationDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("sudo_without_password").(bool) && !d.Get("sudo").(bool) {
+		return fmt.Errorf("sudo_without_password on the systems of user %s requires sudo", d.Get("user_id"))
+	}
+	return nil
+}
+
+// getUserSystems returns the attributes of the systems the user is bound
+// to directly by system ID, not the ones it can log in to through user and
+// system groups. ok is false if the user doesn't exist.
+func getUserSystems(config *Meta, userID string) (systems map[string]UserSystemAttributes, ok bool, err error) {
+	systems = map[string]UserSystemAttributes{}
+	ok = true
+	ctx := requestContext(config)
+	err = newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		var graphconnect []UserSystemConnection
+		res, found, err := jumpCloudPageRequest(ctx, config,
+			fmt.Sprintf("%s/users/%s/associations?targets=system&limit=%d&skip=%d", config.BasePath, userID, pageSize, skip),
+			&graphconnect)
+		ok = ok && found
+		for _, v := range graphconnect {
+			var attributes UserSystemAttributes
+			if v.Attributes != nil {
+				attributes = *v.Attributes
+			}
+			systems[v.To.Id] = attributes
+		}
+		return res, len(graphconnect), err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting the systems of user %s: %w", userID, err)
+	}
+	return systems, ok, nil
+}
+
+// expandUserSystemAttributes builds the attributes of the associations of
+// the user with its systems from d
+func expandUserSystemAttributes(d *schema.ResourceData) UserSystemAttributes {
+	return UserSystemAttributes{
+		Sudo: UserGroupSudo{
+			Enabled:         d.Get("sudo").(bool),
+			WithoutPassword: d.Get("sudo_without_password").(bool),
+		},
+	}
+}
+
+// manageUserSystem adds, updates or removes the association of the user
+// with the system, attributes are ignored when removing it
+func manageUserSystem(config *Meta, userID, systemID, action string,
+	attributes *UserSystemAttributes) error {
+	body := UserSystemManagementReq{
+		Op:         action,
+		Type:       "system",
+		ID:         systemID,
+		Attributes: attributes,
+	}
+
+	if _, err := jumpCloudRequest(config, http.MethodPost, "/users/"+userID+"/associations", body, nil); err != nil {
+		return fmt.Errorf("error trying to %s system %s on user %s: %w", action, systemID, userID, err)
+	}
+	return nil
+}
+
+// syncUserSystems binds the user to the configured systems with the
+// configured sudo, and unbinds it from the ones in removed, i.e. those no
+// longer configured
+func syncUserSystems(config *Meta, d *schema.ResourceData, removed []interface{}) error {
+	userID := d.Get("user_id").(string)
+	current, _, err := getUserSystems(config, userID)
+	if err != nil {
+		return err
+	}
+
+	attributes := expandUserSystemAttributes(d)
+	for _, v := range d.Get("system_ids").(*schema.Set).List() {
+		action := ""
+		if bound, ok := current[v.(string)]; !ok {
+			action = "add"
+		} else if bound.Sudo != attributes.Sudo {
+			action = "update"
+		}
+		if action != "" {
+			if err := manageUserSystem(config, userID, v.(string), action, &attributes); err != nil {
+				return err
+			}
+		}
+	}
+	for _, v := range removed {
+		if _, ok := current[v.(string)]; ok {
+			if err := manageUserSystem(config, userID, v.(string), "remove", nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resourceUserSystemAssociationCreate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	if email := d.Get("email").(string); email != "" {
+		ids, err := userEmailsToIDs(requestContext(config), config, []interface{}{email})
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("no user found with email %s", email)
+		}
+		if err := d.Set("user_id", ids[0]); err != nil {
+			return err
+		}
+	}
+
+	if err := syncUserSystems(config, d, nil); err != nil {
+		return err
+	}
+	d.SetId(d.Get("user_id").(string))
+	return resourceUserSystemAssociationRead(d, m)
+}
+
+func resourceUserSystemAssociationRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	current, ok, err := getUserSystems(config, d.Id())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// the user was deleted
+		d.SetId("")
+		return nil
+	}
+
+	// systems unbound outside of Terraform show up as drift and are bound
+	// again by the next apply, as do sudo changes on any of the systems
+	bound := []string{}
+	sudo := expandUserSystemAttributes(d).Sudo
+	for _, v := range d.Get("system_ids").(*schema.Set).List() {
+		attributes, ok := current[v.(string)]
+		if !ok {
+			continue
+		}
+		bound = append(bound, v.(string))
+		if attributes.Sudo.Enabled != d.Get("sudo").(bool) {
+			sudo.Enabled = attributes.Sudo.Enabled
+		}
+		if attributes.Sudo.WithoutPassword != d.Get("sudo_without_password").(bool) {
+			sudo.WithoutPassword = attributes.Sudo.WithoutPassword
+		}
+	}
+	if err := d.Set("user_id", d.Id()); err != nil {
+		return err
+	}
+	if err := d.Set("system_ids", bound); err != nil {
+		return err
+	}
+	if err := d.Set("sudo", sudo.Enabled); err != nil {
+		return err
+	}
+	return d.Set("sudo_without_password", sudo.WithoutPassword)
+}
+
+func resourceUserSystemAssociationUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	old, new := d.GetChange("system_ids")
+	if err := syncUserSystems(config, d, old.(*schema.Set).Difference(new.(*schema.Set)).List()); err != nil {
+		return err
+	}
+	return resourceUserSystemAssociationRead(d, m)
+}
+
+func resourceUserSystemAssociationDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Meta)
+
+	current, _, err := getUserSystems(config, d.Id())
+	if err != nil {
+		return err
+	}
+	for _, v := range d.Get("system_ids").(*schema.Set).List() {
+		if _, ok := current[v.(string)]; ok {
+			if err := manageUserSystem(config, d.Id(), v.(string), "remove", nil); err != nil {
+				return err
+			}
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,290 @@
+package jumpcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccUserSystemAssociation(t *testing.T) {
+	systemID := os.Getenv("JUMPCLOUD_SYSTEM_ID")
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	fullResourceName := "jumpcloud_user_system_association.test_association"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if systemID == "" {
+				t.Skip("JUMPCLOUD_SYSTEM_ID must be set for this acceptance test")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserSystemAssociation(rName, systemID, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(fullResourceName, "user_id", "jumpcloud_user.test_user", "id"),
+					resource.TestCheckResourceAttr(fullResourceName, "system_ids.#", "1"),
+					resource.TestCheckResourceAttr(fullResourceName, "sudo", "false"),
+				),
+			},
+			{
+				Config: testAccUserSystemAssociation(rName, systemID, true),
+				Check:  resource.TestCheckResourceAttr(fullResourceName, "sudo", "true"),
+			},
+			{
+				ResourceName:            fullResourceName,
+				ImportState:             true,
+				ImportStateIdFunc:       userSystemAssociationImportID(fullResourceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"email"},
+			},
+		},
+	})
+}
+
+func testAccUserSystemAssociation(name, systemID string, sudo bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username = "%[1]s"
+			email    = "%[1]s@testorg.com"
+		}
+
+		resource "jumpcloud_user_system_association" "test_association" {
+			email      = jumpcloud_user.test_user.email
+			system_ids = ["%[2]s"]
+			sudo       = %[3]t
+		}`, name, systemID, sudo,
+	)
+}
+
+// userSystemAssociationImportID builds the user_id:system_id import ID of
+// the association from its state
+func userSystemAssociationImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", name)
+		}
+
+		systemIDs := []string{}
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "system_ids.") && k != "system_ids.#" {
+				systemIDs = append(systemIDs, v)
+			}
+		}
+		return rs.Primary.ID + ":" + strings.Join(systemIDs, ","), nil
+	}
+}
+
+func TestUserSystemAssociationReconcile(t *testing.T) {
+	bound := map[string]bool{"other": true}
+	userExists := true
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/systemusers" {
+			assert.Equal(t, "email:$in:john.doe@example.com", r.URL.Query().Get("filter"))
+			assert.NoError(t, json.NewEncoder(rw).Encode(jcapiv1.Systemuserslist{
+				Results: []jcapiv1.Systemuserreturn{{Id: "user", Email: "john.doe@example.com"}},
+			}))
+			return
+		}
+
+		assert.Equal(t, "/v2/users/user/associations", r.URL.Path)
+		if !userExists {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPost {
+			var req jcapiv2.GraphManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, jcapiv2.GraphType("system"), *req.Type_)
+			if req.Op == "add" {
+				bound[req.Id] = true
+			} else {
+				delete(bound, req.Id)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		assert.Equal(t, "system", r.URL.Query().Get("targets"))
+		connections := []jcapiv2.GraphConnection{}
+		for id := range bound {
+			connections = append(connections, jcapiv2.GraphConnection{To: &jcapiv2.GraphObject{Id: id}})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	boundIDs := func() []string {
+		ids := []string{}
+		for id := range bound {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	config, err := (&Config{}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL + "/v2"
+	r := resourceUserSystemAssociation()
+
+	// bind two systems, the user is looked up by its email
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"email":      "john.doe@example.com",
+		"system_ids": []interface{}{"a", "b"},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "user", d.Id())
+	assert.Equal(t, "user", d.Get("user_id"))
+	assert.Equal(t, []string{"a", "b", "other"}, boundIDs())
+
+	// unbind one of them
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email":      "john.doe@example.com",
+		"system_ids": []interface{}{"a"},
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "other"}, boundIDs())
+	d = r.Data(state)
+
+	// unbound outside of Terraform
+	delete(bound, "a")
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, d.Get("system_ids").(*schema.Set).List())
+
+	// destroying only unbinds the configured systems
+	bound["a"] = true
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"user_id":    "user",
+		"system_ids": []interface{}{"a"},
+	})
+	d.SetId("user")
+	assert.NoError(t, r.Delete(d, config))
+	assert.Equal(t, []string{"other"}, boundIDs())
+
+	// the user was deleted
+	userExists = false
+	d.SetId("user")
+	assert.NoError(t, r.Read(d, config))
+	assert.Empty(t, d.Id())
+}
+
+func TestUserSystemAssociationSudo(t *testing.T) {
+	bound := map[string]UserSystemAttributes{"a": {}, "b": {}}
+	var updates []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/user/associations", r.URL.Path)
+		if r.Method == http.MethodPost {
+			var req UserSystemManagementReq
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "system", req.Type)
+			assert.NotNil(t, req.Attributes)
+			if req.Op == "update" {
+				updates = append(updates, req.ID)
+			}
+			bound[req.ID] = *req.Attributes
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		connections := []UserSystemConnection{}
+		for id, attributes := range bound {
+			attributes := attributes
+			connections = append(connections, UserSystemConnection{
+				To:         jcapiv2.GraphObject{Id: id},
+				Attributes: &attributes,
+			})
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(connections))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	r := resourceUserSystemAssociation()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"user_id":    "user",
+		"system_ids": []interface{}{"a", "b"},
+	})
+	d.SetId("user")
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, false, d.Get("sudo"))
+
+	apply := func(sudo, withoutPassword bool) {
+		diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"user_id":               "user",
+			"system_ids":            []interface{}{"a", "b"},
+			"sudo":                  sudo,
+			"sudo_without_password": withoutPassword,
+		}), config)
+		assert.NoError(t, err)
+		state, err := r.Apply(d.State(), diff, config)
+		assert.NoError(t, err)
+		d = r.Data(state)
+	}
+
+	// grant sudo on the existing association
+	apply(true, true)
+	assert.ElementsMatch(t, []string{"a", "b"}, updates)
+	assert.Equal(t, UserGroupSudo{Enabled: true, WithoutPassword: true}, bound["a"].Sudo)
+	assert.Equal(t, true, d.Get("sudo_without_password"))
+
+	// changed on one of the systems outside of Terraform
+	bound["b"] = UserSystemAttributes{}
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, false, d.Get("sudo"))
+
+	// only the changed system is updated again
+	updates = nil
+	apply(true, true)
+	assert.Equal(t, []string{"b"}, updates)
+	assert.Equal(t, true, d.Get("sudo"))
+
+	// revoke it
+	updates = nil
+	apply(false, false)
+	assert.ElementsMatch(t, []string{"a", "b"}, updates)
+	assert.Equal(t, UserGroupSudo{}, bound["a"].Sudo)
+
+	// sudo without a password requires sudo
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"user_id":               "user",
+		"system_ids":            []interface{}{"a", "b"},
+		"sudo_without_password": true,
+	}), config)
+	assert.Nil(t, diff)
+	assert.EqualError(t, err, "sudo_without_password on the systems of user user requires sudo")
+}
+
+func TestUserSystemAssociationImporter(t *testing.T) {
+	r := resourceUserSystemAssociation()
+	d := r.TestResourceData()
+	d.SetId("user:a,b")
+
+	imported, err := userSystemAssociationImporter(d, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "user", imported[0].Id())
+	assert.Equal(t, "user", imported[0].Get("user_id"))
+	assert.ElementsMatch(t, []interface{}{"a", "b"}, imported[0].Get("system_ids").(*schema.Set).List())
+
+	d.SetId("user")
+	_, err = userSystemAssociationImporter(d, nil)
+	assert.EqualError(t, err, `invalid ID "user", expected 'user_id:system_id'`)
+}
@@ -1,12 +1,22 @@
 package jumpcloud
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccUser(t *testing.T) {
@@ -29,6 +39,7 @@ func TestAccUser(t *testing.T) {
 					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "password_never_expires", "false"),
 					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "sudo", "false"),
 					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "suspended", "false"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "external_dn", ""),
 				),
 			},
 		},
@@ -55,14 +66,583 @@ func TestAccUserFull(t *testing.T) {
 					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "password_never_expires", "true"),
 					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "sudo", "true"),
 					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "suspended", "true"),
-					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "phone_number.0.type", "work"),
-					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "phone_number.0.number", "855.212.3122"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "phone_number.#", "1"),
+					testAccCheckUserPhoneNumber("jumpcloud_user.test_user", "work", "855.212.3122"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccUserPhoneNumbers(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserPhoneNumbers(rName, map[string]string{"work": "855.212.3122"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "phone_number.#", "1"),
+					testAccCheckUserPhoneNumber("jumpcloud_user.test_user", "work", "855.212.3122"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "address.#", "1"),
+				),
+			},
+			{
+				// add a number
+				Config: testAccUserPhoneNumbers(rName, map[string]string{
+					"work":   "855.212.3122",
+					"mobile": "855.212.3123",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "phone_number.#", "2"),
+					testAccCheckUserPhoneNumber("jumpcloud_user.test_user", "mobile", "855.212.3123"),
+				),
+			},
+			{
+				// modify a number
+				Config: testAccUserPhoneNumbers(rName, map[string]string{
+					"work":   "855.212.3124",
+					"mobile": "855.212.3123",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "phone_number.#", "2"),
+					testAccCheckUserPhoneNumber("jumpcloud_user.test_user", "work", "855.212.3124"),
+				),
+			},
+			{
+				// remove all numbers
+				Config: testAccUserPhoneNumbers(rName, nil),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "phone_number.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckUserPhoneNumber checks that the user has a phone number of
+// the type, the numbers are a set so their index isn't known
+func testAccCheckUserPhoneNumber(name, typ, number string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		attributes := rs.Primary.Attributes
+		for key, value := range attributes {
+			if !strings.HasPrefix(key, "phone_number.") || !strings.HasSuffix(key, ".type") || value != typ {
+				continue
+			}
+			if attributes[strings.TrimSuffix(key, ".type")+".number"] == number {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s has no %s phone number %s", name, typ, number)
+	}
+}
+
+func TestAccUserStates(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserStates(rName, true, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "suspended", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "account_locked", "true"),
+				),
+			},
+			{
+				// both states are lifted again
+				Config: testAccUserStates(rName, false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "suspended", "false"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "account_locked", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserMFA(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	until := time.Now().Add(7 * 24 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserMFA(rName, true, until),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "mfa.0.exclusion", "true"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "mfa.0.exclusion_until", until),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "mfa.0.configured", "false"),
+				),
+			},
+			{
+				Config: testAccUserMFA(rName, false, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "mfa.0.exclusion", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserAttributes(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserAttributes(rName, "Engineering"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "attributes.%", "2"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "attributes.costCenter", "Engineering"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "attributes.employeeId", "E-1001"),
+				),
+			},
+			{
+				Config: testAccUserAttributes(rName, "Sales"),
+				Check: resource.TestCheckResourceAttr("jumpcloud_user.test_user",
+					"attributes.costCenter", "Sales"),
+			},
+		},
+	})
+}
+
+func TestAccUserEmployee(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserEmployee(rName, "Engineering", "Engineer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "department", "Engineering"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "employee_identifier", rName),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "location", "Copenhagen"),
+				),
+			},
+			{
+				Config: testAccUserEmployee(rName, "Platform", "Senior Engineer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "department", "Platform"),
+					resource.TestCheckResourceAttr("jumpcloud_user.test_user", "job_title", "Senior Engineer"),
+				),
+			},
+		},
+	})
+}
+
+func TestUserCustomAttributes(t *testing.T) {
+	user := map[string]interface{}{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/api/systemusers", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+			user["_id"] = "user"
+		case http.MethodPut:
+			assert.Equal(t, "/api/systemusers/user", r.URL.Path)
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			for k, v := range body {
+				user[k] = v
+			}
+		case http.MethodGet:
+			assert.Equal(t, "/api/systemusers/user", r.URL.Path)
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode(user))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceUser()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"username": "john.doe",
+		"email":    "john.doe@acme.org",
+		"attributes": map[string]interface{}{
+			"employeeId": "E-1001",
+			"costCenter": "Engineering",
+		},
+	})
+	assert.NoError(t, r.Create(d, config))
+	// sent sorted by name
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "costCenter", "value": "Engineering"},
+		map[string]interface{}{"name": "employeeId", "value": "E-1001"},
+	}, user["attributes"])
+	assert.Equal(t, map[string]interface{}{"costCenter": "Engineering", "employeeId": "E-1001"},
+		d.Get("attributes"))
+
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username": "john.doe",
+		"email":    "john.doe@acme.org",
+		"attributes": map[string]interface{}{
+			"employeeId": "E-1001",
+			"costCenter": "Sales",
+		},
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "costCenter", "value": "Sales"},
+		map[string]interface{}{"name": "employeeId", "value": "E-1001"},
+	}, user["attributes"])
+	assert.Equal(t, "Sales", state.Attributes["attributes.costCenter"])
+	assert.Equal(t, "E-1001", state.Attributes["attributes.employeeId"])
+
+	// reading the same attributes in another order is no change
+	user["attributes"] = []interface{}{
+		map[string]interface{}{"name": "employeeId", "value": "E-1001"},
+		map[string]interface{}{"name": "costCenter", "value": "Sales"},
+	}
+	d = r.Data(state)
+	assert.NoError(t, r.Read(d, config))
+	diff, err = r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username": "john.doe",
+		"email":    "john.doe@acme.org",
+		"attributes": map[string]interface{}{
+			"employeeId": "E-1001",
+			"costCenter": "Sales",
+		},
+	}), config)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// leaving the attributes out keeps them
+	diff, err = r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username": "john.doe",
+		"email":    "john.doe@acme.org",
+	}), config)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// an empty map removes all of them
+	diff, err = r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":   "john.doe",
+		"email":      "john.doe@acme.org",
+		"attributes": map[string]interface{}{},
+	}), config)
+	assert.NoError(t, err)
+	state, err = r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{}, user["attributes"])
+	assert.Equal(t, "0", state.Attributes["attributes.%"])
+}
+
+func TestUserEmployeeFields(t *testing.T) {
+	user := map[string]interface{}{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if r.Method != http.MethodGet {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+		if id, _ := body["employeeIdentifier"].(string); id == "E-taken" {
+			rw.WriteHeader(http.StatusConflict)
+			rw.Write([]byte(`{"message": "employeeIdentifier must be unique"}`))
+			return
+		}
+		for k, v := range body {
+			user[k] = v
+		}
+		user["_id"] = "user"
+		assert.NoError(t, json.NewEncoder(rw).Encode(user))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceUser()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"username":            "john.doe",
+		"email":               "john.doe@acme.org",
+		"department":          "Engineering",
+		"cost_center":         "CC-100",
+		"employee_identifier": "E-1001",
+		"job_title":           "Engineer",
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, "Engineering", user["department"])
+	assert.Equal(t, "CC-100", user["costCenter"])
+	assert.Equal(t, "E-1001", user["employeeIdentifier"])
+	assert.Equal(t, "Engineer", d.Get("job_title"))
+
+	// change some fields, clear one and add another
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":            "john.doe",
+		"email":               "john.doe@acme.org",
+		"department":          "Platform",
+		"employee_identifier": "E-1001",
+		"job_title":           "Senior Engineer",
+		"location":            "Copenhagen",
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, "Platform", user["department"])
+	assert.Equal(t, "", user["costCenter"])
+	assert.Equal(t, "Copenhagen", user["location"])
+	assert.Equal(t, "Senior Engineer", state.Attributes["job_title"])
+	assert.Equal(t, "", state.Attributes["cost_center"])
+
+	// changed outside of Terraform
+	user["company"] = "Acme"
+	d = r.Data(state)
+	assert.NoError(t, r.Read(d, config))
+	assert.Equal(t, "Acme", d.Get("company"))
+
+	// another user has the employee identifier already
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"username":            "jane.doe",
+		"email":               "jane.doe@acme.org",
+		"employee_identifier": "E-taken",
+	})
+	err = r.Create(d, config)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "employee_identifier E-taken is already used by another user")
+	}
+}
+
+func TestUserAccountLocked(t *testing.T) {
+	user := map[string]interface{}{}
+	unlocks := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/unlock") {
+			unlocks++
+			user["account_locked"] = false
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.Method != http.MethodGet {
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			for k, v := range body {
+				user[k] = v
+			}
+		}
+		user["_id"] = "user"
+		assert.NoError(t, json.NewEncoder(rw).Encode(user))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceUser()
+
+	raw := map[string]interface{}{
+		"username": "john.doe",
+		"email":    "john.doe@acme.org",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	assert.NoError(t, r.Create(d, config))
+
+	// locked by JumpCloud after too many failed logins
+	user["account_locked"] = true
+	d = r.Data(d.State())
+	assert.NoError(t, r.Read(d, config))
+	assert.True(t, d.Get("account_locked").(bool))
+
+	// left out of the config the lock is no drift
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(raw), config)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// set to false it unlocks the account
+	diff, err = r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":       "john.doe",
+		"email":          "john.doe@acme.org",
+		"account_locked": false,
+	}), config)
+	assert.NoError(t, err)
+	state, err := r.Apply(d.State(), diff, config)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, unlocks)
+	assert.Equal(t, "false", state.Attributes["account_locked"])
+}
+
+func TestUserConflictError(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"username":            "jane.doe",
+		"email":               "jane.doe@acme.org",
+		"employee_identifier": "E-1001",
+	})
+
+	conflict := &jumpCloudAPIError{StatusCode: http.StatusConflict, Message: "already exists"}
+	assert.EqualError(t, userConflictError(d, conflict),
+		"employee_identifier E-1001 is already used by another user, it must be unique: "+conflict.Error())
+
+	// other errors mentioning the field are left alone
+	invalid := &jumpCloudAPIError{StatusCode: http.StatusBadRequest, Message: "employeeIdentifier is invalid"}
+	assert.Equal(t, invalid, userConflictError(d, invalid))
+}
+
+func TestUserPhoneNumbers(t *testing.T) {
+	user := map[string]interface{}{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			for k, v := range body {
+				user[k] = v
+			}
+		}
+		user["_id"] = "user"
+		assert.NoError(t, json.NewEncoder(rw).Encode(user))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/api/v2"
+	r := resourceUser()
+
+	phoneNumber := func(typ, number string) map[string]interface{} {
+		return map[string]interface{}{"type": typ, "number": number}
+	}
+	address := map[string]interface{}{
+		"type":           "work",
+		"street_address": "Main Street 1",
+		"locality":       "Copenhagen",
+		"country":        "DK",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"username":     "john.doe",
+		"email":        "john.doe@acme.org",
+		"phone_number": []interface{}{phoneNumber("work", "855.212.3122")},
+		"address":      []interface{}{address},
+	})
+	assert.NoError(t, r.Create(d, config))
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "work", "number": "855.212.3122"}},
+		user["phoneNumbers"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"type":          "work",
+		"streetAddress": "Main Street 1",
+		"locality":      "Copenhagen",
+		"country":       "DK",
+	}}, user["addresses"])
+	assert.Equal(t, 1, d.Get("address").(*schema.Set).Len())
+
+	update := func(phoneNumbers []interface{}) {
+		diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"username":     "john.doe",
+			"email":        "john.doe@acme.org",
+			"phone_number": phoneNumbers,
+			"address":      []interface{}{address},
+		}), config)
+		assert.NoError(t, err)
+		state, err := r.Apply(d.State(), diff, config)
+		assert.NoError(t, err)
+		d = r.Data(state)
+	}
+
+	// add a number
+	update([]interface{}{phoneNumber("work", "855.212.3122"), phoneNumber("mobile", "855.212.3123")})
+	assert.Len(t, user["phoneNumbers"], 2)
+	assert.Equal(t, 2, d.Get("phone_number").(*schema.Set).Len())
+
+	// modify a number
+	update([]interface{}{phoneNumber("work", "855.212.3124"), phoneNumber("mobile", "855.212.3123")})
+	assert.ElementsMatch(t, []interface{}{
+		map[string]interface{}{"type": "work", "number": "855.212.3124"},
+		map[string]interface{}{"type": "mobile", "number": "855.212.3123"},
+	}, user["phoneNumbers"])
+
+	// remove all numbers, the empty list has to be sent
+	update(nil)
+	assert.Equal(t, []interface{}{}, user["phoneNumbers"])
+	assert.Equal(t, 0, d.Get("phone_number").(*schema.Set).Len())
+	assert.Equal(t, 1, d.Get("address").(*schema.Set).Len())
+
+	// the order the API returns the numbers in doesn't matter
+	user["phoneNumbers"] = []interface{}{
+		map[string]interface{}{"type": "mobile", "number": "855.212.3123"},
+		map[string]interface{}{"type": "work", "number": "855.212.3122"},
+	}
+	assert.NoError(t, r.Read(d, config))
+	diff, err := r.Diff(d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":     "john.doe",
+		"email":        "john.doe@acme.org",
+		"phone_number": []interface{}{phoneNumber("work", "855.212.3122"), phoneNumber("mobile", "855.212.3123")},
+		"address":      []interface{}{address},
+	}), config)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+}
+
+func TestUserStateUpgradeV0(t *testing.T) {
+	r := resourceUser()
+	assert.Equal(t, 1, r.SchemaVersion)
+
+	// the version 0 type still has the list
+	v0 := r.StateUpgraders[0].Type
+	assert.True(t, v0.AttributeType("phone_number").IsListType())
+	assert.True(t, r.CoreConfigSchema().ImpliedType().AttributeType("phone_number").IsSetType())
+
+	rawState := map[string]interface{}{
+		"id": "user",
+		"phone_number": []interface{}{
+			map[string]interface{}{"number": "+45 12 34 56 78", "type": "mobile"},
+			map[string]interface{}{"number": "+45 87 65 43 21", "type": "work"},
+		},
+	}
+	upgraded, err := r.StateUpgraders[0].Upgrade(rawState, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, rawState, upgraded)
+}
+
+func TestFlattenUserAttributes(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{}, flattenUserAttributes(nil))
+	assert.Equal(t, map[string]interface{}{"badge": "42", "hrId": "E-1", "empty": ""},
+		flattenUserAttributes([]interface{}{
+			map[string]interface{}{"name": "hrId", "value": "E-1"},
+			map[string]interface{}{"name": "badge", "value": float64(42)},
+			map[string]interface{}{"name": "empty"},
+			map[string]interface{}{"value": "unnamed"},
+		}))
+}
+
+func TestCheckMFAExclusion(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, checkMFAExclusion(UserMFA{}, now))
+	assert.NoError(t, checkMFAExclusion(UserMFA{Exclusion: true, ExclusionUntil: "2024-01-08T00:00:00Z"}, now))
+	assert.Error(t, checkMFAExclusion(UserMFA{Exclusion: true}, now))
+	assert.Error(t, checkMFAExclusion(UserMFA{Exclusion: true, ExclusionUntil: "2023-12-31T00:00:00Z"}, now))
+	// a past exclusion_until doesn't matter without an exclusion
+	assert.NoError(t, checkMFAExclusion(UserMFA{ExclusionUntil: "2023-12-31T00:00:00Z"}, now))
+}
+
+func TestFlattenUserMFA(t *testing.T) {
+	assert.Empty(t, flattenUserMFA(nil))
+
+	until := time.Date(2024, 1, 8, 2, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"exclusion":       true,
+		"exclusion_until": "2024-01-08T00:00:00Z",
+		"configured":      true,
+	}}, flattenUserMFA(&jcapiv1.Mfa{Configured: true, Exclusion: true, ExclusionUntil: until}))
+}
+
 // testAccPreCheck validates the necessary test API keys exist
 // in the testing environment
 func testAccPreCheck(t *testing.T) {
@@ -102,3 +682,89 @@ func testAccUserFull(name string) string {
 		}`, name, name,
 	)
 }
+
+func testAccUserPhoneNumbers(name string, phoneNumbers map[string]string) string {
+	blocks := ""
+	for typ, number := range phoneNumbers {
+		blocks += fmt.Sprintf(`
+			phone_number {
+				type   = "%s"
+				number = "%s"
+			}`, typ, number)
+	}
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username = "%[1]s"
+			email    = "%[1]s@testorg.com"
+			%[2]s
+
+			address {
+				type           = "work"
+				street_address = "Main Street 1"
+				locality       = "Copenhagen"
+				postal_code    = "1000"
+				country        = "DK"
+			}
+		}`, name, blocks,
+	)
+}
+
+func testAccUserStates(name string, suspended, locked bool) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+  			username = "%[1]s"
+			email = "%[1]s@testorg.com"
+			firstname = "Firstname"
+			lastname = "Lastname"
+			suspended = %[2]t
+			account_locked = %[3]t
+		}`, name, suspended, locked,
+	)
+}
+
+func testAccUserAttributes(name, costCenter string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username  = "%[1]s"
+			email     = "%[1]s@testorg.com"
+			firstname = "Firstname"
+			lastname  = "Lastname"
+			attributes = {
+				costCenter = "%[2]s"
+				employeeId = "E-1001"
+			}
+		}`, name, costCenter,
+	)
+}
+
+func testAccUserEmployee(name, department, jobTitle string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username            = "%[1]s"
+			email               = "%[1]s@testorg.com"
+			department          = "%[2]s"
+			company             = "Test Org"
+			cost_center         = "CC-100"
+			employee_identifier = "%[1]s"
+			employee_type       = "Full-time"
+			job_title           = "%[3]s"
+			location            = "Copenhagen"
+		}`, name, department, jobTitle,
+	)
+}
+
+func testAccUserMFA(name string, exclusion bool, until string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+  			username = "%[1]s"
+			email = "%[1]s@testorg.com"
+			firstname = "Firstname"
+			lastname = "Lastname"
+			enable_mfa = true
+			mfa {
+				exclusion = %[2]t
+				exclusion_until = %[3]q
+			}
+		}`, name, exclusion, until,
+	)
+}
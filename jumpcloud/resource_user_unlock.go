@@ -0,0 +1,83 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"time"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceUserUnlock() *schema.Resource {
+	return &schema.Resource{
+		Description: "Unlocks a JumpCloud user that was locked out after too many failed logins. Changing `triggers` unlocks the user again.",
+		Create:      resourceUserUnlockCreate,
+		Read:        resourceUserUnlockRead,
+		Delete:      resourceUserUnlockDelete,
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "The ID of the `resource_user` object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary values that, when changed, unlock the user again.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"unlocked_at": {
+				Description: "The RFC 3339 timestamp the user was last unlocked at.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceUserUnlockCreate(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+	userID := d.Get("user_id").(string)
+
+	res, err := client.SystemusersApi.SystemusersUnlock(ctx,
+		userID, "", headerAccept, nil)
+	if err != nil {
+		return fmt.Errorf("error unlocking user %s: %w", userID, apiError(res, err))
+	}
+
+	d.SetId(userID)
+	if err := d.Set("unlocked_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	return resourceUserUnlockRead(d, m)
+}
+
+func resourceUserUnlockRead(d *schema.ResourceData, m interface{}) error {
+	configv1 := convertV2toV1Config(m.(*Meta))
+	ctx := requestContext(m.(*Meta))
+	client := jcapiv1.NewAPIClient(configv1)
+
+	_, _, err := client.SystemusersApi.SystemusersGet(ctx,
+		d.Id(), "", "", nil)
+	if err != nil {
+		if err.Error() == "EOF" {
+			// the user is gone
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func resourceUserUnlockDelete(d *schema.ResourceData, m interface{}) error {
+	// there is nothing to undo about an unlock
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,50 @@
+package jumpcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccUserUnlock(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserUnlock(rName, "1"),
+				Check:  resource.TestCheckResourceAttrSet("jumpcloud_user_unlock.test_unlock", "unlocked_at"),
+			},
+			{
+				Config: testAccUserUnlock(rName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("jumpcloud_user_unlock.test_unlock", "triggers.ticket", "2"),
+					resource.TestCheckResourceAttrSet("jumpcloud_user_unlock.test_unlock", "unlocked_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserUnlock(name, trigger string) string {
+	return fmt.Sprintf(`
+		resource "jumpcloud_user" "test_user" {
+			username  = "%[1]s"
+			email     = "%[1]s@testorg.com"
+			firstname = "Firstname"
+			lastname  = "Lastname"
+		}
+
+		resource "jumpcloud_user_unlock" "test_unlock" {
+			user_id = jumpcloud_user.test_user.id
+			triggers = {
+				ticket = "%[2]s"
+			}
+		}`, name, trigger,
+	)
+}
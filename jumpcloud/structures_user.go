@@ -1,6 +1,10 @@
 package jumpcloud
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
 )
 
@@ -20,11 +24,7 @@ func flattenPhoneNumbers(pn []jcapiv1.SystemuserreturnPhoneNumbers) []interface{
 }
 
 func expandPhoneNumbers(input []interface{}) []map[string]string {
-	if input == nil || len(input) == 0 {
-		return nil
-	}
-
-	var phoneNumbers []map[string]string
+	phoneNumbers := make([]map[string]string, 0, len(input))
 
 	for _, v := range input {
 		if phoneNumber, ok := v.(map[string]interface{}); ok {
@@ -37,3 +37,169 @@ func expandPhoneNumbers(input []interface{}) []map[string]string {
 	}
 	return phoneNumbers
 }
+
+// userAddressFields maps the fields of the address block to the ones of
+// the v1 API
+var userAddressFields = map[string]string{
+	"type":           "type",
+	"street_address": "streetAddress",
+	"locality":       "locality",
+	"region":         "region",
+	"postal_code":    "postalCode",
+	"country":        "country",
+}
+
+func flattenUserAddresses(addresses []jcapiv1.SystemuserreturnAddresses) []interface{} {
+	out := make([]interface{}, 0, len(addresses))
+	for _, v := range addresses {
+		out = append(out, map[string]interface{}{
+			"type":           v.Type_,
+			"street_address": v.StreetAddress,
+			"locality":       v.Locality,
+			"region":         v.Region,
+			"postal_code":    v.PostalCode,
+			"country":        v.Country,
+		})
+	}
+	return out
+}
+
+func expandUserAddresses(input []interface{}) []map[string]string {
+	addresses := make([]map[string]string, 0, len(input))
+	for _, v := range input {
+		address, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		out := map[string]string{}
+		for key, field := range userAddressFields {
+			if value := address[key].(string); value != "" {
+				out[field] = value
+			}
+		}
+		addresses = append(addresses, out)
+	}
+	return addresses
+}
+
+// The custom attributes of a system user are returned by the v1 API as an
+// array of name/value objects
+func findUserAttribute(attributes []interface{}, name string) (string, bool) {
+	for _, v := range attributes {
+		if attribute, ok := v.(map[string]interface{}); ok && attribute["name"] == name {
+			value, _ := attribute["value"].(string)
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func setUserAttribute(attributes []interface{}, name, value string) []interface{} {
+	out := removeUserAttribute(attributes, name)
+	return append(out, map[string]interface{}{
+		"name":  name,
+		"value": value,
+	})
+}
+
+func removeUserAttribute(attributes []interface{}, name string) []interface{} {
+	out := make([]interface{}, 0, len(attributes))
+	for _, v := range attributes {
+		if attribute, ok := v.(map[string]interface{}); ok && attribute["name"] == name {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// expandUserAttributes turns the attributes map of the user resource into
+// the name/value array of the API, sorted by name so the request doesn't
+// depend on the map order
+func expandUserAttributes(input map[string]interface{}) []interface{} {
+	names := make([]string, 0, len(input))
+	for name := range input {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attributes := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		attributes = append(attributes, map[string]interface{}{
+			"name":  name,
+			"value": input[name].(string),
+		})
+	}
+	return attributes
+}
+
+// flattenUserAttributes turns the name/value array of the API into a map,
+// values the API stores as other types than strings are formatted
+func flattenUserAttributes(attributes []interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, v := range attributes {
+		attribute, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := attribute["name"].(string)
+		if !ok || name == "" {
+			continue
+		}
+		if value, ok := attribute["value"].(string); ok {
+			out[name] = value
+		} else if attribute["value"] != nil {
+			out[name] = fmt.Sprint(attribute["value"])
+		} else {
+			out[name] = ""
+		}
+	}
+	return out
+}
+
+func flattenUserMFA(mfa *jcapiv1.Mfa) []interface{} {
+	if mfa == nil {
+		return []interface{}{}
+	}
+
+	exclusionUntil := ""
+	if !mfa.ExclusionUntil.IsZero() {
+		exclusionUntil = mfa.ExclusionUntil.UTC().Format(time.RFC3339)
+	}
+	return []interface{}{map[string]interface{}{
+		"exclusion":       mfa.Exclusion,
+		"exclusion_until": exclusionUntil,
+		"configured":      mfa.Configured,
+	}}
+}
+
+func expandUserMFA(input []interface{}) UserMFA {
+	if len(input) == 0 || input[0] == nil {
+		return UserMFA{}
+	}
+
+	mfa := input[0].(map[string]interface{})
+	return UserMFA{
+		Exclusion:      mfa["exclusion"].(bool),
+		ExclusionUntil: mfa["exclusion_until"].(string),
+	}
+}
+
+// checkMFAExclusion validates an MFA exclusion at plan time, JumpCloud only
+// accepts exclusions that end in the future
+func checkMFAExclusion(mfa UserMFA, now time.Time) error {
+	if !mfa.Exclusion {
+		return nil
+	}
+	if mfa.ExclusionUntil == "" {
+		return fmt.Errorf("mfa.0.exclusion_until must be set when mfa.0.exclusion is true")
+	}
+	until, err := time.Parse(time.RFC3339, mfa.ExclusionUntil)
+	if err != nil {
+		return fmt.Errorf("mfa.0.exclusion_until is not an RFC 3339 time: %s", err)
+	}
+	if !until.After(now) {
+		return fmt.Errorf("mfa.0.exclusion_until must be in the future, got %s", mfa.ExclusionUntil)
+	}
+	return nil
+}
@@ -4,8 +4,11 @@ package jumpcloud
 
 import (
 	"fmt"
+	"log"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
 )
@@ -13,7 +16,6 @@ import (
 func flattenAttributes(attr *jcapiv2.UserGroupAttributes) map[string]interface{} {
 	return map[string]interface{}{
 		"posix_groups": flattenPosixGroups(attr.PosixGroups),
-		// "enable_samba": fmt.Sprintf("%t", attr.SambaEnabled),
 	}
 }
 
@@ -25,6 +27,34 @@ func flattenPosixGroups(pg []jcapiv2.UserGroupAttributesPosixGroups) string {
 	return strings.Join(out, ",")
 }
 
+// The range of POSIX group IDs accepted for posix_gid, bounded by the
+// int32 the JCAPI stores them in.
+const (
+	minPosixGID = 1
+	maxPosixGID = math.MaxInt32
+)
+
+// expandUserGroupAttributes builds the group's attributes from posix_gid and
+// posix_name. Without a gid it falls back to the deprecated "gid:name"
+// form of attributes.posix_groups.
+func expandUserGroupAttributes(attr interface{}, gid int,
+	name string) (*jcapiv2.UserGroupAttributes, bool) {
+	if gid != 0 {
+		return &jcapiv2.UserGroupAttributes{
+			PosixGroups: []jcapiv2.UserGroupAttributesPosixGroups{
+				{Id: int32(gid), Name: name},
+			},
+		}, true
+	}
+
+	out, ok := expandAttributes(attr)
+	if ok {
+		log.Printf("[WARN] attributes.posix_groups is deprecated, " +
+			"use posix_gid and posix_name instead")
+	}
+	return out, ok
+}
+
 func expandAttributes(attr interface{}) (out *jcapiv2.UserGroupAttributes, ok bool) {
 	if attr == nil {
 		return
@@ -34,12 +64,6 @@ func expandAttributes(attr interface{}) (out *jcapiv2.UserGroupAttributes, ok bo
 		return
 	}
 
-	// var enableSamba bool
-	// sambaStr, ok := mapAttr["enable_samba"].(string)
-	// if ok {
-	// 	enableSamba, _ = strconv.ParseBool(sambaStr)
-	// }
-
 	// TODO: empty string? nil?
 	posixStr, ok := mapAttr["posix_groups"].(string)
 	if !ok {
@@ -69,6 +93,70 @@ func expandAttributes(attr interface{}) (out *jcapiv2.UserGroupAttributes, ok bo
 
 	return &jcapiv2.UserGroupAttributes{
 		PosixGroups: posixGroups,
-		// SambaEnabled: enableSamba,
 	}, true
 }
+
+func expandUserGroupSudo(sudo []interface{}) *UserGroupSudo {
+	if len(sudo) == 0 || sudo[0] == nil {
+		return &UserGroupSudo{}
+	}
+	m := sudo[0].(map[string]interface{})
+	return &UserGroupSudo{
+		Enabled:         m["enabled"].(bool),
+		WithoutPassword: m["without_password"].(bool),
+	}
+}
+
+// flattenUserGroupSudo returns the sudo block of a group. A group without
+// sudo has no block, unless one is configured, so sudo { enabled = false }
+// doesn't show up as drift.
+func flattenUserGroupSudo(sudo *UserGroupSudo, configured []interface{}) []interface{} {
+	if sudo == nil {
+		sudo = &UserGroupSudo{}
+	}
+	if !sudo.Enabled && !sudo.WithoutPassword && len(configured) == 0 {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"enabled":          sudo.Enabled,
+		"without_password": sudo.WithoutPassword,
+	}}
+}
+
+// unexpiredMembers drops the members whose entry in expiry lies before now.
+// The emails of the dropped members are returned as well.
+func unexpiredMembers(members []interface{}, expiry map[string]interface{},
+	now time.Time) (kept []interface{}, expired []string) {
+
+	kept = []interface{}{}
+	for _, member := range members {
+		email := member.(string)
+		if v, ok := expiry[email]; ok {
+			if t, err := time.Parse(time.RFC3339, v.(string)); err == nil && !t.After(now) {
+				expired = append(expired, email)
+				continue
+			}
+		}
+		kept = append(kept, member)
+	}
+	return
+}
+
+func validateMembershipExpiry(v interface{}, k string) (ws []string, es []error) {
+	for email, value := range v.(map[string]interface{}) {
+		if _, err := time.Parse(time.RFC3339, value.(string)); err != nil {
+			es = append(es, fmt.Errorf("%q: expiry of %s must be an RFC 3339 timestamp: %s", k, email, err))
+		}
+	}
+	return
+}
+
+// checkMemberRemovalLimit fails if more members are to be removed from a
+// group than the max_member_removal_per_apply provider setting allows
+func checkMemberRemovalLimit(groupName string, removals, limit int) error {
+	if limit > 0 && removals > limit {
+		return fmt.Errorf("refusing to remove %d members from user group %s, max_member_removal_per_apply is %d; "+
+			"increase the limit if this is intended", removals, groupName, limit)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package jumpcloud
 
-import jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+import (
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+)
 
 // UserGroup is like jcapiv2.UserGroup with Attributes
 type UserGroup struct {
@@ -11,6 +14,451 @@ type UserGroup struct {
 	Type string `json:"type,omitempty"`
 
 	// Display name of a User Group.
-	Name       string                      `json:"name,omitempty"`
-	Attributes jcapiv2.UserGroupAttributes `json:"attributes,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Attributes  UserGroupAttributes `json:"attributes,omitempty"`
+
+	// Tags of the group, not exposed by jcapiv2.UserGroup.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// UserGroupAttributes is like jcapiv2.UserGroupAttributes with the
+// attributes the SDK doesn't know about
+type UserGroupAttributes struct {
+	jcapiv2.UserGroupAttributes
+	EnableLdapUserAuthentication bool           `json:"ldapUserAuthentication,omitempty"`
+	Sudo                         *UserGroupSudo `json:"sudo,omitempty"`
+}
+
+// UserGroupSudo grants the members of a user group sudo on the systems
+// they are bound to, or a user sudo on a system bound to it directly
+type UserGroupSudo struct {
+	Enabled         bool `json:"enabled"`
+	WithoutPassword bool `json:"withoutPassword"`
+}
+
+// UserSystemAttributes are the attributes of the association of a user
+// with a system, which jcapiv2.GraphConnection lacks
+type UserSystemAttributes struct {
+	Sudo UserGroupSudo `json:"sudo"`
+}
+
+// UserSystemConnection is like jcapiv2.GraphConnection with the attributes
+// of the association of a user with a system
+type UserSystemConnection struct {
+	To         jcapiv2.GraphObject   `json:"to"`
+	Attributes *UserSystemAttributes `json:"attributes,omitempty"`
+}
+
+// UserSystemManagementReq is like jcapiv2.GraphManagementReq with the
+// attributes of the association of a user with a system
+type UserSystemManagementReq struct {
+	Op         string                `json:"op"`
+	Type       string                `json:"type"`
+	ID         string                `json:"id"`
+	Attributes *UserSystemAttributes `json:"attributes,omitempty"`
+}
+
+// UserGroupPost is like jcapiv2.UserGroupPost with UserGroupAttributes
+type UserGroupPost struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Attributes  *UserGroupAttributes `json:"attributes,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+}
+
+// GoogleWorkspaceSyncRule maps a Google group to a JumpCloud user group
+// for a G Suite directory integration
+type GoogleWorkspaceSyncRule struct {
+	ID               string `json:"id,omitempty"`
+	GoogleGroupEmail string `json:"googleGroupEmail"`
+	UserGroupID      string `json:"userGroupId"`
+	SyncDirection    string `json:"syncDirection"`
+	Enabled          bool   `json:"enabled"`
+}
+
+// SystemUserActivity holds the activity related fields of a system user
+// which are not part of jcapiv1.Systemuserreturn
+type SystemUserActivity struct {
+	ID                  string `json:"_id"`
+	Email               string `json:"email"`
+	Activated           bool   `json:"activated"`
+	Created             string `json:"created"`
+	LastStateChangeDate string `json:"lastStateChangeDate"`
+}
+
+// LdapAttribute is a custom attribute exposed in the LDAP
+// representation of a user group or a user
+type LdapAttribute struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsArray bool   `json:"isArray,omitempty"`
+}
+
+// ProviderBinding grants an administrator of an MSP provider access
+// to the resources of a child organization
+type ProviderBinding struct {
+	AdministratorID string   `json:"administratorId,omitempty"`
+	OrganizationID  string   `json:"organizationId,omitempty"`
+	Permissions     []string `json:"permissions"`
+}
+
+// ScimAttributeMapping maps a custom SCIM extension attribute of an
+// application to a JumpCloud attribute expression for a user group.
+type ScimAttributeMapping struct {
+	Name            string `json:"name"`
+	ValueExpression string `json:"valueExpression"`
+}
+
+// DirectorySyncJob is an on-demand synchronization of a directory
+// integration (Google Workspace, Office 365, Workday...).
+type DirectorySyncJob struct {
+	ID          string `json:"id,omitempty"`
+	Type        string `json:"type"`
+	Status      string `json:"status,omitempty"`
+	CompletedAt string `json:"completedAt,omitempty"`
+	StartedAt   string `json:"startedAt,omitempty"`
+}
+
+// SPCertificate is the service provider certificate of a SAML application.
+type SPCertificate struct {
+	Certificate string `json:"certificate"`
+}
+
+// UserGroupPermissionSet lists what an admin role may do with a user group.
+type UserGroupPermissionSet struct {
+	Permissions []string `json:"permissions"`
+}
+
+// MDMProfile is a custom configuration profile pushed to systems by
+// JumpCloud MDM.
+type MDMProfile struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	PayloadType string `json:"payloadType"`
+	Content     string `json:"content"`
+	TargetType  string `json:"targetType"`
+	TargetID    string `json:"targetId"`
+	Signed      bool   `json:"signed"`
+}
+
+// ProvisioningAttribute is an attribute sent to the SCIM endpoint of an
+// application when a user is added to a group.
+type ProvisioningAttribute struct {
+	Name      string `json:"name"`
+	Value     string `json:"value,omitempty"`
+	Operation string `json:"operation"`
+}
+
+// OrganizationBranding holds the customizations of the JumpCloud user portal.
+type OrganizationBranding struct {
+	LogoURL        string `json:"logoUrl,omitempty"`
+	Logo           string `json:"logo,omitempty"`
+	PrimaryColor   string `json:"primaryColor,omitempty"`
+	SecondaryColor string `json:"secondaryColor,omitempty"`
+	HelpdeskURL    string `json:"helpdeskUrl,omitempty"`
+	HelpdeskLabel  string `json:"helpdeskLabel,omitempty"`
+	SupportLink    string `json:"supportLink,omitempty"`
+}
+
+// PasswordManagerSettings are the organization wide settings of JumpCloud
+// Password Manager. Unset fields are left unchanged by the API.
+type PasswordManagerSettings struct {
+	AutoLockTimeoutMinutes       *int  `json:"autoLockTimeoutMinutes,omitempty"`
+	ClipboardClearSeconds        *int  `json:"clipboardClearSeconds,omitempty"`
+	RequireMasterPasswordForView *bool `json:"requireMasterPasswordForView,omitempty"`
+	AllowBrowserSave             *bool `json:"allowBrowserSave,omitempty"`
+	EnforceStrongMasterPassword  *bool `json:"enforceStrongMasterPassword,omitempty"`
+}
+
+// UserGroupDeviceRestrictions are the device types members of a user
+// group can authenticate from.
+type UserGroupDeviceRestrictions struct {
+	AllowMacOS              bool `json:"allowMacOS"`
+	AllowWindows            bool `json:"allowWindows"`
+	AllowLinux              bool `json:"allowLinux"`
+	AllowIOS                bool `json:"allowIOS"`
+	AllowAndroid            bool `json:"allowAndroid"`
+	TrustedDevicesOnly      bool `json:"trustedDevicesOnly"`
+	RequireEncryptedStorage bool `json:"requireEncryptedStorage"`
+}
+
+// UserGroupManager delegates the management of a user group to a user.
+type UserGroupManager struct {
+	UserID           string `json:"userId,omitempty"`
+	CanManageMembers bool   `json:"canManageMembers"`
+}
+
+// SystemGroup is the HTTP API view of a system group, including the
+// fields jcapiv2.SystemGroup lacks.
+type SystemGroup struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// SystemGroupPost is like jcapiv2.SystemGroupData with a description.
+type SystemGroupPost struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GroupTags is the payload to update the tags of a user or system group.
+type GroupTags struct {
+	Tags []string `json:"tags"`
+}
+
+// DirectoryInsightsQuery is the body of a Directory Insights event search.
+type DirectoryInsightsQuery struct {
+	Service     []string               `json:"service"`
+	StartTime   string                 `json:"start_time"`
+	EndTime     string                 `json:"end_time,omitempty"`
+	Limit       int                    `json:"limit,omitempty"`
+	Sort        string                 `json:"sort,omitempty"`
+	SearchTerm  map[string]interface{} `json:"search_term,omitempty"`
+	SearchAfter []interface{}          `json:"search_after,omitempty"`
+}
+
+// GroupMembershipEvent is a Directory Insights event recording a user
+// being added to or removed from a group.
+type GroupMembershipEvent struct {
+	Timestamp  string `json:"timestamp"`
+	EventType  string `json:"event_type"`
+	Operation  string `json:"operation"`
+	AuthMethod string `json:"auth_method"`
+	UserAgent  struct {
+		Name string `json:"name"`
+	} `json:"useragent"`
+	InitiatedBy struct {
+		Email string `json:"email"`
+	} `json:"initiated_by"`
+	Resource struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"resource"`
+	Target struct {
+		Email string `json:"email"`
+	} `json:"target"`
+}
+
+// UserCustomField is a custom field the users of an organization can have.
+type UserCustomField struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Required   bool   `json:"required"`
+	Searchable bool   `json:"searchable"`
+}
+
+// UserCustomFields is the custom field schema of an organization.
+type UserCustomFields struct {
+	Fields []UserCustomField `json:"fields"`
+}
+
+// MembershipExpiryWebhook is a webhook notified ahead of expiring user
+// group memberships. The secret is write only.
+type MembershipExpiryWebhook struct {
+	ID               string `json:"id,omitempty"`
+	Event            string `json:"event"`
+	GroupID          string `json:"groupId"`
+	NotifyDaysBefore int    `json:"notifyDaysBefore"`
+	URL              string `json:"url"`
+	Secret           string `json:"secret,omitempty"`
+}
+
+// UserMFA is the MFA configuration of a system user. Unlike jcapiv1.Mfa it
+// sends an exclusion of false and no exclusionUntil when there's none.
+type UserMFA struct {
+	Exclusion      bool   `json:"exclusion"`
+	ExclusionUntil string `json:"exclusionUntil,omitempty"`
+}
+
+// UserMFAPut is the payload to update the MFA configuration of a system user.
+type UserMFAPut struct {
+	MFA UserMFA `json:"mfa"`
+}
+
+// V1Object holds the ID of an object of the v1 API, which some of the
+// SDK's models like jcapiv1.Command lack.
+type V1Object struct {
+	ID string `json:"_id"`
+}
+
+// PolicyValue is like jcapiv2.PolicyValue with the value of the config
+// field, which the SDK's model lacks. Depending on the field the value is
+// a string, a bool or a number.
+type PolicyValue struct {
+	ConfigFieldID string      `json:"configFieldID"`
+	Value         interface{} `json:"value"`
+}
+
+// PolicyRequest is like jcapiv2.PolicyRequest with PolicyValues. The
+// template may only be sent when the policy is created.
+type PolicyRequest struct {
+	Name     string                         `json:"name"`
+	Template *jcapiv2.PolicyRequestTemplate `json:"template,omitempty"`
+	Values   []PolicyValue                  `json:"values"`
+}
+
+// PolicyWithDetails is like jcapiv2.PolicyWithDetails with PolicyValues
+type PolicyWithDetails struct {
+	ID           string                              `json:"id,omitempty"`
+	Name         string                              `json:"name,omitempty"`
+	Template     *jcapiv2.PolicyTemplate             `json:"template,omitempty"`
+	ConfigFields []jcapiv2.PolicyTemplateConfigField `json:"configFields,omitempty"`
+	Values       []PolicyValue                       `json:"values,omitempty"`
+}
+
+// System is like jcapiv1.System with the serial number, which the SDK's
+// model lacks
+type System struct {
+	jcapiv1.System
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+// SystemsList is like jcapiv1.Systemslist with System
+type SystemsList struct {
+	Results    []System `json:"results"`
+	TotalCount int      `json:"totalCount"`
+}
+
+// DirectorySettings are the settings of a Google Workspace or Office 365
+// directory integration. Unlike jcapiv2.GsuiteOutput they have the name,
+// default domain and group sync of the directory.
+type DirectorySettings struct {
+	ID                           string           `json:"id,omitempty"`
+	Name                         string           `json:"name,omitempty"`
+	DefaultDomain                *DirectoryDomain `json:"defaultDomain,omitempty"`
+	GroupsEnabled                bool             `json:"groupsEnabled"`
+	UserLockoutAction            string           `json:"userLockoutAction,omitempty"`
+	UserPasswordExpirationAction string           `json:"userPasswordExpirationAction,omitempty"`
+}
+
+// DirectoryDomain is a domain of a Google Workspace or Office 365 tenant
+type DirectoryDomain struct {
+	ID     string `json:"id,omitempty"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// SoftwareApp is an app JumpCloud installs on the systems it is
+// associated with. The API takes the settings as a list, of which only
+// the first one is used.
+type SoftwareApp struct {
+	ID          string                `json:"id,omitempty"`
+	DisplayName string                `json:"displayName"`
+	Settings    []SoftwareAppSettings `json:"settings"`
+}
+
+// SoftwareAppSettings describes the package of a SoftwareApp and how it
+// is kept on the systems
+type SoftwareAppSettings struct {
+	PackageManager string `json:"packageManager"`
+	PackageID      string `json:"packageId,omitempty"`
+	Location       string `json:"location,omitempty"`
+	PackageVersion string `json:"packageVersion,omitempty"`
+	AutoUpdate     bool   `json:"autoUpdate"`
+	DesiredState   string `json:"desiredState,omitempty"`
+}
+
+// Organization is the v1 view of an organization, limited to the settings
+// used by the provider.
+type Organization struct {
+	ID          string               `json:"_id"`
+	DisplayName string               `json:"displayName"`
+	Settings    OrganizationSettings `json:"settings"`
+}
+
+// OrganizationSettings are the organization wide settings of the v1 API.
+type OrganizationSettings struct {
+	ContactName    string         `json:"contactName"`
+	ContactEmail   string         `json:"contactEmail"`
+	PasswordPolicy PasswordPolicy `json:"passwordPolicy"`
+}
+
+// PasswordPolicy holds the password complexity requirements of the users
+// of an organization. Limits that are not enabled don't apply.
+type PasswordPolicy struct {
+	EnableMinLength                bool `json:"enableMinLength"`
+	MinLength                      int  `json:"minLength"`
+	NeedsUppercase                 bool `json:"needsUppercase"`
+	NeedsLowercase                 bool `json:"needsLowercase"`
+	NeedsNumeric                   bool `json:"needsNumeric"`
+	NeedsSymbolic                  bool `json:"needsSymbolic"`
+	EnablePasswordExpirationInDays bool `json:"enablePasswordExpirationInDays"`
+	PasswordExpirationInDays       int  `json:"passwordExpirationInDays"`
+	EnableMaxHistory               bool `json:"enableMaxHistory"`
+	MaxHistory                     int  `json:"maxHistory"`
+}
+
+// ScimServer is the SCIM endpoint an identity provider provisions users
+// and groups to. The token is only returned when it is generated.
+type ScimServer struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	BaseURL string `json:"baseUrl,omitempty"`
+	Token   string `json:"token,omitempty"`
+}
+
+// IPList is a named list of IP addresses and CIDR ranges conditional
+// access policies allow or deny.
+type IPList struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type"`
+	IPs         []string `json:"ips"`
+}
+
+// AuthnPolicy is a conditional access policy, applying its effect to the
+// logins of the targeted users that match all of its conditions.
+type AuthnPolicy struct {
+	ID          string                `json:"id,omitempty"`
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Disabled    bool                  `json:"disabled"`
+	Targets     AuthnPolicyTargets    `json:"targets"`
+	Conditions  AuthnPolicyConditions `json:"conditions"`
+	Effect      AuthnPolicyEffect     `json:"effect"`
+}
+
+// AuthnPolicyTargets are the users a conditional access policy applies to.
+type AuthnPolicyTargets struct {
+	UserGroups AuthnPolicyInclusions `json:"userGroups"`
+}
+
+// AuthnPolicyInclusions lists the IDs of the targeted objects of a type.
+type AuthnPolicyInclusions struct {
+	Inclusions []string `json:"inclusions"`
+}
+
+// AuthnPolicyConditions must all match for the policy to apply, an empty
+// list matches every login.
+type AuthnPolicyConditions struct {
+	All []AuthnPolicyCondition `json:"all"`
+}
+
+// AuthnPolicyCondition is a single condition, Not negates its nested
+// condition.
+type AuthnPolicyCondition struct {
+	IPAddressIn   []string              `json:"ipAddressIn,omitempty"`
+	DeviceManaged *bool                 `json:"deviceManaged,omitempty"`
+	Not           *AuthnPolicyCondition `json:"not,omitempty"`
+}
+
+// AuthnPolicyEffect is what happens to matching logins.
+type AuthnPolicyEffect struct {
+	Action      string                  `json:"action"`
+	Obligations *AuthnPolicyObligations `json:"obligations,omitempty"`
+}
+
+// AuthnPolicyObligations are the extra steps of allowed logins.
+type AuthnPolicyObligations struct {
+	MFA *AuthnPolicyMFA `json:"mfa,omitempty"`
+}
+
+// AuthnPolicyMFA requires matching logins to complete MFA.
+type AuthnPolicyMFA struct {
+	Required bool `json:"required"`
 }
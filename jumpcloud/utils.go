@@ -1,43 +1,417 @@
 package jumpcloud
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"reflect"
+	"math/rand"
+	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
 
 	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
 	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
-	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
-// Gets an application's metadata XML for SAML authentication
-// this direct API call is a needed workaround since JumpCloud does not offer this endpoint through its SDK
-func GetApplicationMetadataXml(orgId string, applicationId string, apiKey string) (string, error) {
-	url := "https://console.jumpcloud.com/api/organizations/" + orgId + "/applications/" + applicationId + "/metadata.xml"
+// GetApplicationMetadataXml gets an application's metadata XML for SAML
+// authentication. This direct API call is a needed workaround since
+// JumpCloud does not offer this endpoint through its SDK.
+func GetApplicationMetadataXml(config *Meta, applicationId string) (string, error) {
+	url := strings.TrimSuffix(config.BasePath, "/v2") + "/organizations/" + config.DefaultHeader["x-org-id"] +
+		"/applications/" + applicationId + "/metadata.xml"
 
-	// debug is always set to true, but output will only be shown if TF_LOG=DEBUG is set
-	client := resty.New().SetDebug(true)
+	req, err := http.NewRequestWithContext(requestContext(config), http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	addDefaultHeaders(req, config)
 
-	resp, err := client.R().
-		SetHeader("x-api-key", apiKey).
-		Get(url)
+	res, err := config.Settings.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
 
+	body, err := io.ReadAll(res.Body)
 	if err != nil {
 		return "", err
 	}
+	log.Printf("[DEBUG] metadata XML of application %s: %s\n%s", applicationId, res.Status, body)
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return "", newAPIError(res, body)
+	}
+	return string(body), nil
+}
+
+// addDefaultHeaders authenticates a raw API request like the SDK clients
+// created from config do, including the organization of MSP admins, and
+// identifies the provider with its user agent
+func addDefaultHeaders(req *http.Request, config *Meta) {
+	req.Header.Set("User-Agent", config.UserAgent)
+	req.Header.Add("x-api-key", config.DefaultHeader["x-api-key"])
+	if config.DefaultHeader["x-org-id"] != "" {
+		req.Header.Add("x-org-id", config.DefaultHeader["x-org-id"])
+	}
+}
+
+// jumpCloudRequest calls an endpoint of the JumpCloud v2 API that is not
+// covered by the SDK. path is relative to config.BasePath; body, if set, is
+// sent as JSON and the JSON response is decoded into out, if set.
+// Like userGroupReadHelper, ok is false if the object does not exist; only
+// GET requests report a missing object this way, for any other method a 404
+// is a *jumpCloudAPIError like other failures; deletes check it with
+// isNotFound to treat an object that is gone already as deleted.
+func jumpCloudRequest(config *Meta, method, path string,
+	body interface{}, out interface{}) (ok bool, err error) {
+	_, ok, err = doJumpCloudRequest(requestContext(config), config, method, config.BasePath+path, body, out)
+	return
+}
+
+// jumpCloudRequestContext is like jumpCloudRequest, canceling the request
+// when ctx is done
+func jumpCloudRequestContext(ctx context.Context, config *Meta, method, path string,
+	body interface{}, out interface{}) (ok bool, err error) {
+	_, ok, err = doJumpCloudRequest(ctx, config, method, config.BasePath+path, body, out)
+	return
+}
+
+// jumpCloudV1Request is like jumpCloudRequest for endpoints of the v1 API,
+// path is relative to the v1 base path derived from config.BasePath
+func jumpCloudV1Request(config *Meta, method, path string,
+	body interface{}, out interface{}) (ok bool, err error) {
+	_, ok, err = doJumpCloudRequest(requestContext(config), config, method, v1BasePath(config)+path, body, out)
+	return
+}
 
-	log.Println("Status Code:", resp.StatusCode())
-	log.Println("Status     :", resp.Status())
-	log.Println("Time       :", resp.Time())
-	log.Println("Received At:", resp.ReceivedAt())
-	log.Println("Body       :\n", resp)
+// jumpCloudPageRequest GETs a page of a list endpoint for a pager. url is
+// absolute, i.e. starts with config.BasePath or v1BasePath(config). Unlike
+// jumpCloudRequest it returns the response, whose rate limit headers pace
+// the pager.
+func jumpCloudPageRequest(ctx context.Context, config *Meta, url string,
+	out interface{}) (res *http.Response, ok bool, err error) {
+	return doJumpCloudRequest(ctx, config, http.MethodGet, url, nil, out)
+}
 
-	return string(resp.Body()), nil
+// v1BasePath is the base path of the v1 API, derived from config.BasePath
+func v1BasePath(config *Meta) string {
+	return strings.TrimSuffix(config.BasePath, "/v2")
+}
+
+// doJumpCloudRequest sends the request of jumpCloudRequest to url. The
+// body of the returned response is already closed.
+func doJumpCloudRequest(ctx context.Context, config *Meta, method, url string,
+	body interface{}, out interface{}) (res *http.Response, ok bool, err error) {
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, false, err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return
+	}
+
+	addDefaultHeaders(req, config)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+
+	res, err = config.Settings.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound && method == http.MethodGet {
+		return
+	}
+	if res.StatusCode >= http.StatusMultipleChoices {
+		resBody, _ := io.ReadAll(res.Body)
+		return res, false, newAPIError(res, resBody)
+	}
+
+	ok = true
+	if out != nil && res.StatusCode != http.StatusNoContent {
+		err = json.NewDecoder(res.Body).Decode(out)
+	}
+	return
+}
+
+// jumpCloudAPIError is a failed JumpCloud API call, with the message and
+// code of the error body JumpCloud responded with, if any
+type jumpCloudAPIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Status     string
+	Message    string
+	Code       string
+}
+
+func (e *jumpCloudAPIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.Path, e.Status)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Code != "" {
+		msg += " (code " + e.Code + ")"
+	}
+	return msg
+}
+
+// isNotFound reports whether err is a JumpCloud API error for an object
+// that doesn't exist
+func isNotFound(err error) bool {
+	var apiErr *jumpCloudAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// apiError turns the error of an SDK call into a concise error with the
+// status, request path and JumpCloud error message of res, instead of the
+// raw response. Errors without an error response, e.g. network errors,
+// and cancellations while waiting to retry are returned as they are.
+func apiError(res *http.Response, err error) error {
+	if err == nil || res == nil || res.StatusCode < http.StatusMultipleChoices ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	// the SDK consumes the body and reports it as "Status: ..., Body: ..."
+	var body []byte
+	if i := strings.Index(err.Error(), "Body: "); i >= 0 {
+		body = []byte(err.Error()[i+len("Body: "):])
+	} else if res.Body != nil {
+		body, _ = io.ReadAll(res.Body)
+	}
+	return newAPIError(res, body)
+}
+
+// newAPIError parses the error body of res. JumpCloud's v1 API responds
+// with {"message": ...} or {"error": ...}, the v2 API with {"code": ...,
+// "message": ...}; any other body is used as the message.
+func newAPIError(res *http.Response, body []byte) *jumpCloudAPIError {
+	e := &jumpCloudAPIError{StatusCode: res.StatusCode, Status: res.Status}
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.Path = res.Request.URL.Path
+	}
+
+	var parsed struct {
+		Message string      `json:"message"`
+		Error   interface{} `json:"error"`
+		Code    interface{} `json:"code"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		e.Message = strings.TrimSpace(string(body))
+		return e
+	}
+	e.Message = parsed.Message
+	if msg, ok := parsed.Error.(string); ok && e.Message == "" {
+		e.Message = msg
+	}
+	if parsed.Code != nil {
+		e.Code = fmt.Sprint(parsed.Code)
+	}
+	return e
+}
+
+// withRateLimitRetry calls fn until it doesn't fail with a 429 Too Many
+// Requests response or the max_retries of config are used up. Between the
+// attempts it waits for as long as the Retry-After header asks for or,
+// without one, backs off exponentially from retry_base_delay_ms.
+// fn must be safe to repeat. Waiting is given up once ctx is done.
+func withRateLimitRetry(ctx context.Context, config *Meta, fn func() (*http.Response, error)) error {
+	settings := config.Settings
+	for attempt := 0; ; attempt++ {
+		res, err := fn()
+		if err == nil || res == nil || res.StatusCode != http.StatusTooManyRequests ||
+			attempt >= settings.MaxRetries {
+			return err
+		}
+
+		delay := rateLimitDelay(res, settings.RetryBaseDelay, attempt, time.Now())
+		log.Printf("[INFO] rate limited by JumpCloud, retrying in %s (retry %d of %d)",
+			delay, attempt+1, settings.MaxRetries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// withTimeout runs fn with a context that is done once the timeout of
+// the operation key of d has passed, or once Terraform stops the provider.
+// Running out of time is reported along with how to raise the timeout.
+func withTimeout(d *schema.ResourceData, config *Meta, key string,
+	fn func(ctx context.Context) error) error {
+
+	timeout := d.Timeout(key)
+	ctx, cancel := context.WithTimeout(requestContext(config), timeout)
+	defer cancel()
+
+	err := fn(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%s timed out after %s, raise it in the timeouts block: %w", key, timeout, err)
+	}
+	return err
+}
+
+// sleepContext waits for d, or returns the error of ctx if it is done
+// before that
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rateLimitDelay is the time to wait before retrying the rate limited
+// request of res, the attempt-th retry starting at 0
+func rateLimitDelay(res *http.Response, base time.Duration, attempt int, now time.Time) time.Duration {
+	if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(retryAfter); err == nil {
+			if at.After(now) {
+				return at.Sub(now)
+			}
+			return 0
+		}
+	}
+
+	backoff := base << uint(attempt)
+	if base <= 0 {
+		return backoff
+	}
+	// jitter spreads out the retries of concurrent requests
+	return backoff + time.Duration(rand.Int63n(int64(base)))
+}
+
+// pageSize is the number of objects requested per page of a list
+const pageSize = 100
+
+// defaultPageDelay is the pause between pages when the API doesn't report
+// its rate limit
+const defaultPageDelay = 100 * time.Millisecond
+
+// pager fetches the pages of a list endpoint one after another, retrying
+// rate limited requests like withRateLimitRetry. Between the pages it only
+// waits when the rate limit is running low.
+type pager struct {
+	ctx    context.Context
+	config *Meta
+	now    func() time.Time
+	sleep  func(context.Context, time.Duration) error
+}
+
+// newPager returns a pager that stops once ctx is done
+func newPager(ctx context.Context, config *Meta) *pager {
+	return &pager{ctx: ctx, config: config, now: time.Now, sleep: sleepContext}
+}
+
+// each calls fetch with the skip of every page until a page holds fewer
+// than pageSize objects. fetch returns the response and the number of
+// objects of the page.
+func (p *pager) each(fetch func(skip int32) (*http.Response, int, error)) error {
+	for skip := int32(0); ; skip += pageSize {
+		var res *http.Response
+		var n int
+		err := withRateLimitRetry(p.ctx, p.config, func() (*http.Response, error) {
+			var err error
+			res, n, err = fetch(skip)
+			return res, err
+		})
+		if err != nil {
+			return err
+		}
+		if n < pageSize {
+			return nil
+		}
+
+		if delay := pageDelay(res, p.config.Settings.RetryBaseDelay, p.now()); delay > 0 {
+			log.Printf("[DEBUG] waiting %s before fetching the next page", delay)
+			if err := p.sleep(p.ctx, delay); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pageDelay is the time to wait before fetching the page after the one of
+// res. There is no wait while more than a tenth of the rate limit is left;
+// below that the remaining requests are spread over the rest of the
+// window, or base if the window's reset isn't known.
+func pageDelay(res *http.Response, base time.Duration, now time.Time) time.Duration {
+	if res == nil {
+		return defaultPageDelay
+	}
+	status := parseRateLimitHeaders(res.Header, now)
+	if status.Limit <= 0 {
+		return defaultPageDelay
+	}
+	if status.Remaining*10 > status.Limit {
+		return 0
+	}
+
+	untilReset := time.Unix(status.ResetAt, 0).Sub(now)
+	if status.ResetAt == 0 || untilReset <= 0 {
+		return base
+	}
+	return untilReset / time.Duration(status.Remaining+1)
+}
+
+// parseAssociationImportID splits the fromID:toID import ID of an
+// association resource, several toIDs are separated by commas. from and
+// to name the IDs in errors.
+func parseAssociationImportID(id, from, to string) (fromID string, toIDs []string, err error) {
+	s := strings.Split(id, ":")
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return "", nil, fmt.Errorf("invalid ID %q, expected '%s:%s'", id, from, to)
+	}
+	for _, toID := range strings.Split(s[1], ",") {
+		if toID == "" {
+			return "", nil, fmt.Errorf("invalid ID %q, empty %s", id, to)
+		}
+		toIDs = append(toIDs, toID)
+	}
+	return s[0], toIDs, nil
+}
+
+// maxGroupDescriptionLength is the longest description JumpCloud accepts
+// for user and system groups
+const maxGroupDescriptionLength = 1024
+
+// validateGroupDescription checks group descriptions at plan time, the API
+// rejects overly long ones and ones containing markup or control characters
+func validateGroupDescription(v interface{}, k string) (ws []string, es []error) {
+	description := v.(string)
+	if len([]rune(description)) > maxGroupDescriptionLength {
+		es = append(es, fmt.Errorf("%s must be at most %d characters long, got %d",
+			k, maxGroupDescriptionLength, len([]rune(description))))
+	}
+	for _, r := range description {
+		if r == '<' || r == '>' || (unicode.IsControl(r) && r != '\n' && r != '\t') {
+			es = append(es, fmt.Errorf("%s must not contain %q", k, r))
+			break
+		}
+	}
+	return
 }
 
 func stringInSlice(a string, list []string) bool {
@@ -49,114 +423,433 @@ func stringInSlice(a string, list []string) bool {
 	return false
 }
 
-func getUserGroupMemberIDs(client *jcapiv2.APIClient, groupID string) ([]string, error) {
+func getUserGroupMemberIDs(ctx context.Context, config *Meta, groupID string) ([]string, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
 	var userIds []string
-	for i := 0; ; i++ {
+	var skip int32
+	var res *http.Response
+	err := newPager(ctx, config).each(func(s int32) (*http.Response, int, error) {
+		skip = s
 		optionals := map[string]interface{}{
 			"groupId": groupID,
-			"limit":   int32(100),
-			"skip":    int32(i * 100),
-		}
-
-		graphconnect, res, err := client.UserGroupMembersMembershipApi.GraphUserGroupMembersList(
-			context.TODO(), groupID, "", "", optionals)
-		if err != nil {
-			return nil, fmt.Errorf("error getting group members for group id %s, error:%s; response = %+v", groupID, err, res)
+			"limit":   int32(pageSize),
+			"skip":    skip,
 		}
 
+		var graphconnect []jcapiv2.GraphConnection
+		var err error
+		graphconnect, res, err = client.UserGroupMembersMembershipApi.GraphUserGroupMembersList(
+			ctx, groupID, "", "", optionals)
 		for _, v := range graphconnect {
 			userIds = append(userIds, v.To.Id)
 		}
+		return res, len(graphconnect), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting group members for group id %s at skip %d: %w",
+			groupID, skip, apiError(res, err))
+	}
+	return userIds, nil
+}
+
+func getSystemGroupMemberIDs(ctx context.Context, config *Meta, groupID string) ([]string, error) {
+	client := jcapiv2.NewAPIClient(config.Configuration)
+
+	var systemIDs []string
+	var res *http.Response
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		optionals := map[string]interface{}{
+			"limit": int32(pageSize),
+			"skip":  skip,
+		}
 
-		if len(graphconnect) < 100 {
-			break
-		} else {
-			time.Sleep(100 * time.Millisecond)
+		var graphconnect []jcapiv2.GraphConnection
+		var err error
+		graphconnect, res, err = client.SystemGroupMembersMembershipApi.GraphSystemGroupMembersList(
+			ctx, groupID, "", "", optionals)
+		for _, v := range graphconnect {
+			systemIDs = append(systemIDs, v.To.Id)
 		}
+		return res, len(graphconnect), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting group members for system group id %s: %w", groupID, apiError(res, err))
 	}
-	return userIds, nil
+	return systemIDs, nil
 }
 
-func userIDsToEmails(configv2 *jcapiv2.Configuration, userIDs []string) ([]string, error) {
-	emails := make([]string, len(userIDs))
+// systemIDsToHostnames resolves system IDs to hostnames, sorted by hostname.
+// Systems that no longer exist are skipped with a warning.
+func systemIDsToHostnames(configv2 *Meta, systemIDs []string) ([]string, error) {
+	ctx := requestContext(configv2)
+	hostnames := []string{}
 
-	if len(userIDs) == 0 {
-		return emails, nil
+	if len(systemIDs) == 0 {
+		return hostnames, nil
 	}
 
 	configv1 := convertV2toV1Config(configv2)
 	client := jcapiv1.NewAPIClient(configv1)
 
-	for i := 0; ; i++ {
-		users, res, err := client.SystemusersApi.SystemusersList(context.TODO(), "", "", map[string]interface{}{
-			"filter": "_id:$in:" + strings.Join(userIDs[:], "|"),
-			"limit":  int32(100),
-			"skip":   int32(i * 100),
-			"fields": "email",
-			"sort":   "email",
+	found := map[string]bool{}
+	var skip int32
+	var res *http.Response
+	err := newPager(ctx, configv2).each(func(s int32) (*http.Response, int, error) {
+		skip = s
+		var systems jcapiv1.Systemslist
+		var err error
+		systems, res, err = client.SystemsApi.SystemsList(ctx, "", "", map[string]interface{}{
+			"filter": "_id:$in:" + strings.Join(systemIDs[:], "|"),
+			"limit":  int32(pageSize),
+			"skip":   skip,
+			"fields": "hostname",
+			"sort":   "hostname",
 		})
-
-		if err != nil {
-			return nil, fmt.Errorf("error loading user emails from IDs: %s, i:%d, error:%s; response:%+v", userIDs, i, err, res)
+		for _, result := range systems.Results {
+			found[result.Id] = true
+			hostnames = append(hostnames, result.Hostname)
 		}
+		return res, len(systems.Results), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading system hostnames from IDs %s at skip %d: %w", systemIDs, skip, apiError(res, err))
+	}
 
-		for j, result := range users.Results {
-			emails[j+(i*100)] = result.Email
+	for _, id := range systemIDs {
+		if !found[id] {
+			log.Printf("[WARN] system %s is a group member but no longer exists, skipping it", id)
 		}
+	}
 
-		if len(users.Results) < 100 {
-			break
+	return hostnames, nil
+}
+
+// userCache remembers the email of every user resolved by userIDsToEmails
+// and userEmailsToIDs, so the users shared by several groups are only
+// looked up once per run. Only users that were found are cached, so users
+// created later in the same run still resolve. The nil cache is disabled.
+type userCache struct {
+	mu        sync.Mutex
+	idByEmail map[string]string // keyed by lower case email
+	emailByID map[string]string
+}
+
+func newUserCache() *userCache {
+	return &userCache{
+		idByEmail: map[string]string{},
+		emailByID: map[string]string{},
+	}
+}
+
+func (c *userCache) id(email string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.idByEmail[strings.ToLower(email)]
+	return id, ok
+}
+
+func (c *userCache) email(id string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	email, ok := c.emailByID[id]
+	return email, ok
+}
+
+func (c *userCache) add(id, email string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.idByEmail[strings.ToLower(email)] = id
+	c.emailByID[id] = email
+}
+
+// forget drops the user id, to be called whenever a user changes
+func (c *userCache) forget(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if email, ok := c.emailByID[id]; ok {
+		delete(c.idByEmail, strings.ToLower(email))
+		delete(c.emailByID, id)
+	}
+}
+
+// userIDsToEmails returns the sorted emails of the users with the IDs.
+// IDs without a user, e.g. of users deleted while still being members of
+// a group, are returned as unresolved instead of failing the lookup.
+func userIDsToEmails(ctx context.Context, configv2 *Meta, userIDs []string) (emails []string,
+	unresolved []string, err error) {
+
+	if len(userIDs) == 0 {
+		return []string{}, nil, nil
+	}
+
+	cache := configv2.Settings.userCache
+	found := map[string]string{}
+	uncachedIDs := []string{}
+	for _, id := range userIDs {
+		if email, ok := cache.email(id); ok {
+			found[id] = email
 		} else {
-			time.Sleep(100 * time.Millisecond)
+			uncachedIDs = append(uncachedIDs, id)
+		}
+	}
+
+	configv1 := convertV2toV1Config(configv2)
+	client := jcapiv1.NewAPIClient(configv1)
+
+	// the IDs are part of the query string, so they are looked up in
+	// batches to keep the URL short
+	batchSize := configv2.Settings.EmailLookupBatchSize
+	for start := 0; start < len(uncachedIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(uncachedIDs) {
+			end = len(uncachedIDs)
+		}
+		batch := uncachedIDs[start:end]
+
+		var skip int32
+		var res *http.Response
+		err := newPager(ctx, configv2).each(func(s int32) (*http.Response, int, error) {
+			skip = s
+			var users jcapiv1.Systemuserslist
+			var err error
+			users, res, err = client.SystemusersApi.SystemusersList(ctx, "", "", map[string]interface{}{
+				"filter": "_id:$in:" + strings.Join(batch, "|"),
+				"limit":  int32(pageSize),
+				"skip":   skip,
+				"fields": "_id email",
+				"sort":   "email",
+			})
+			for _, result := range users.Results {
+				found[result.Id] = result.Email
+				cache.add(result.Id, result.Email)
+			}
+			return res, len(users.Results), err
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("error loading user emails from IDs %s at skip %d: %w", batch, skip, apiError(res, err))
 		}
 	}
 
-	return emails, nil
+	emails = make([]string, 0, len(found))
+	for _, id := range userIDs {
+		if email, ok := found[id]; ok {
+			emails = append(emails, email)
+		} else {
+			unresolved = append(unresolved, id)
+		}
+	}
+	sort.Strings(emails)
+	return emails, unresolved, nil
 }
 
-func userEmailsToIDs(configv2 *jcapiv2.Configuration, userEmailsInterface []interface{}) ([]string, error) {
+func userEmailsToIDs(ctx context.Context, configv2 *Meta, userEmailsInterface []interface{}) ([]string, error) {
 	userEmails := make([]string, len(userEmailsInterface))
 	for i, userEmail := range userEmailsInterface {
 		userEmails[i] = userEmail.(string)
 	}
 
-	ids := make([]string, len(userEmailsInterface))
-
 	if len(userEmails) == 0 {
-		return ids, nil
+		return []string{}, nil
+	}
+
+	settings := configv2.Settings
+	found := map[string]string{}
+	uncachedEmails := []string{}
+	for _, email := range userEmails {
+		if id, ok := settings.userCache.id(email); ok {
+			found[strings.ToLower(email)] = id
+		} else {
+			uncachedEmails = append(uncachedEmails, email)
+		}
 	}
 
 	configv1 := convertV2toV1Config(configv2)
 	client := jcapiv1.NewAPIClient(configv1)
 
-	for i := 0; ; i++ {
-		users, res, err := client.SystemusersApi.SystemusersList(context.TODO(), "", "", map[string]interface{}{
-			"filter": "email:$in:" + strings.Join(userEmails[:], "|"),
-			"limit":  int32(100),
-			"skip":   int32(i * 100),
-			"fields": "_id",
-			"sort":   "_id",
+	// the emails are part of the query string, so they are looked up in
+	// batches to keep the URL short
+	batchSize := settings.EmailLookupBatchSize
+	for start := 0; start < len(uncachedEmails); start += batchSize {
+		end := start + batchSize
+		if end > len(uncachedEmails) {
+			end = len(uncachedEmails)
+		}
+		batch := uncachedEmails[start:end]
+
+		var res *http.Response
+		err := newPager(ctx, configv2).each(func(skip int32) (*http.Response, int, error) {
+			var users jcapiv1.Systemuserslist
+			var err error
+			users, res, err = client.SystemusersApi.SystemusersList(ctx, "", "", map[string]interface{}{
+				"filter": "email:$in:" + strings.Join(batch, "|"),
+				"limit":  int32(pageSize),
+				"skip":   skip,
+				"fields": "_id email",
+				"sort":   "_id",
+			})
+			for _, result := range users.Results {
+				found[strings.ToLower(result.Email)] = result.Id
+				settings.userCache.add(result.Id, result.Email)
+			}
+			return res, len(users.Results), err
 		})
-
 		if err != nil {
-			return nil, fmt.Errorf("error loading user IDs from emails:%s; response = %+v", err, res)
+			return nil, fmt.Errorf("error loading user IDs from emails: %w", apiError(res, err))
 		}
+	}
 
-		for j, result := range users.Results {
-			ids[j+(i*100)] = result.Id
-		}
+	return resolveMemberEmails(userEmails, found, settings.MemberNotFoundBehavior)
+}
 
-		if len(users.Results) < 100 {
-			break
+// resolveMemberEmails maps emails to the IDs in found, keyed by lower case
+// email, handling emails without a user according to behavior
+func resolveMemberEmails(emails []string, found map[string]string, behavior string) ([]string, error) {
+	ids := make([]string, 0, len(emails))
+	var missing []string
+	for _, email := range emails {
+		if id, ok := found[strings.ToLower(email)]; ok {
+			ids = append(ids, id)
 		} else {
-			time.Sleep(100 * time.Millisecond)
+			missing = append(missing, email)
 		}
 	}
 
+	if len(missing) > 0 {
+		switch behavior {
+		case "warn":
+			log.Printf("[WARN] no JumpCloud users found for %s, skipping", strings.Join(missing, ", "))
+		case "ignore":
+		default:
+			return nil, fmt.Errorf("no JumpCloud users found for %s", strings.Join(missing, ", "))
+		}
+	}
 	return ids, nil
 }
 
-func manageGroupMember(client *jcapiv2.APIClient, d *schema.ResourceData, memberID string, action string) error {
+// bulkMemberBatchSize is the number of operations sent per bulk request
+const bulkMemberBatchSize = 100
+
+// manageGroupMembers adds or removes several members of the user group d.
+// The bulk endpoint is used unless disabled by use_bulk_operations or
+// unavailable, in which case every member is managed by its own request.
+func manageGroupMembers(ctx context.Context, config *Meta, d *schema.ResourceData,
+	memberIDs []string, action string) error {
+	if len(memberIDs) == 0 {
+		return nil
+	}
+
+	if config.Settings.UseBulkOperations && !config.bulkUnsupported.Load() {
+		err := manageGroupMembersBulk(ctx, config, d.Id(), memberIDs, action)
+		if !errors.Is(err, errBulkUnsupported) {
+			return err
+		}
+		log.Printf("[INFO] bulk membership operations not available, managing members one by one")
+		config.bulkUnsupported.Store(true)
+	}
+
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	err := forEachConcurrently(ctx, config.Settings.MemberConcurrency, memberIDs,
+		func(memberID string) error {
+			return manageGroupMember(ctx, config, client, d, memberID, action)
+		})
+	if err != nil {
+		return fmt.Errorf("error managing members of user group %s: %w", d.Id(), err)
+	}
+	return nil
+}
+
+// forEachConcurrently calls fn for every item with at most limit calls
+// running at a time. It doesn't stop at the first error, all errors are
+// joined. Once ctx is done no further calls are started.
+func forEachConcurrently(ctx context.Context, limit int, items []string, fn func(string) error) error {
+	if limit < 1 {
+		limit = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, limit)
+	for _, item := range items {
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			wg.Wait()
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		wg.Add(1)
+		go func(item string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := fn(item); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(item)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// errBulkUnsupported is returned by manageGroupMembersBulk if the API
+// doesn't offer the bulk membership endpoint
+var errBulkUnsupported = errors.New("bulk membership operations not available")
+
+// manageGroupMembersBulk sends the operations in batches. Only a 404
+// without an error body for the first batch means the endpoint is missing;
+// a 404 for a missing group or user comes with a message and is returned
+// like any other failure.
+func manageGroupMembersBulk(ctx context.Context, config *Meta, groupID string,
+	memberIDs []string, action string) error {
+	for start := 0; start < len(memberIDs); start += bulkMemberBatchSize {
+		end := start + bulkMemberBatchSize
+		if end > len(memberIDs) {
+			end = len(memberIDs)
+		}
+
+		ops := make([]jcapiv2.UserGroupMembersReq, 0, end-start)
+		for _, memberID := range memberIDs[start:end] {
+			ops = append(ops, jcapiv2.UserGroupMembersReq{Op: action, Type_: "user", Id: memberID})
+		}
+
+		_, err := jumpCloudRequestContext(ctx, config, http.MethodPost, "/bulk/usergroups/"+groupID+"/members", ops, nil)
+		var apiErr *jumpCloudAPIError
+		if start == 0 && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound &&
+			apiErr.Message == "" && apiErr.Code == "" {
+			return errBulkUnsupported
+		}
+		if err != nil {
+			return fmt.Errorf("error managing group members in bulk, action: %s, error: %s", action, err)
+		}
+	}
+	return nil
+}
+
+func manageGroupMember(ctx context.Context, config *Meta, client *jcapiv2.APIClient,
+	d *schema.ResourceData, memberID string, action string) error {
 	payload := jcapiv2.UserGroupMembersReq{
 		Op:    action,
 		Type_: "user",
@@ -167,44 +860,48 @@ func manageGroupMember(client *jcapiv2.APIClient, d *schema.ResourceData, member
 		"body": payload,
 	}
 
-	res, err := client.UserGroupMembersMembershipApi.GraphUserGroupMembersPost(
-		context.TODO(), d.Id(), "", "", req)
+	var res *http.Response
+	err := withRateLimitRetry(ctx, config, func() (*http.Response, error) {
+		var err error
+		res, err = client.UserGroupMembersMembershipApi.GraphUserGroupMembersPost(
+			ctx, d.Id(), "", "", req)
+		return res, err
+	})
 
 	if err != nil {
-		return fmt.Errorf("error managing group member, action: %s, member id:%s, error: %s; response = %+v", action, memberID, err, res)
+		return fmt.Errorf("error managing group member, action: %s, member id:%s: %w", action, memberID, apiError(res, err))
 	}
 	return nil
 }
 
-// https://github.com/rootlyhq/terraform-provider-rootly/blob/99175a7ab4e154793ea8a8710d329a3f48eb0c90/tools/ignore_array_order.go#L12
-func EqualIgnoringOrder(key, oldValue, newValue string, d *schema.ResourceData) bool {
-	// The key is a path not the list itself, e.g. "events.0"
-	lastDotIndex := strings.LastIndex(key, ".")
-	if lastDotIndex != -1 {
-		key = string(key[:lastDotIndex])
-	}
-	oldData, newData := d.GetChange(key)
-	if oldData == nil || newData == nil {
-		return false
-	}
-	oldArray := oldData.([]interface{})
-	newArray := newData.([]interface{})
-	if len(oldArray) != len(newArray) {
-		// Items added or removed, always detect as changed
-		return false
+// directoryIDByName looks up the ID of the directory of type directoryType,
+// e.g. g_suite, called name among the directories of the organization.
+// kind names the type in errors.
+func directoryIDByName(config *Meta, directoryType, kind, name string) (string, error) {
+	ctx := requestContext(config)
+	var ids []string
+	err := newPager(ctx, config).each(func(skip int32) (*http.Response, int, error) {
+		var directories []jcapiv2.Directory
+		res, _, err := jumpCloudPageRequest(ctx, config,
+			fmt.Sprintf("%s/directories?limit=%d&skip=%d", config.BasePath, pageSize, skip), &directories)
+		for _, directory := range directories {
+			if directory.Type_ == directoryType && directory.Name == name {
+				ids = append(ids, directory.Id)
+			}
+		}
+		return res, len(directories), err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error listing directories: %w", err)
 	}
 
-	// Convert data to string arrays
-	oldItems := make([]string, len(oldArray))
-	newItems := make([]string, len(newArray))
-	for i, oldItem := range oldArray {
-		oldItems[i] = oldItem.(string)
-	}
-	for j, newItem := range newArray {
-		newItems[j] = newItem.(string)
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s directory named %s, connect it in the JumpCloud console first", kind, name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d %s directories are named %s, import the one to manage by ID instead",
+			len(ids), kind, name)
 	}
-	// Ensure consistent sorting before comparison, to suppress unnecessary change detections
-	sort.Strings(oldItems)
-	sort.Strings(newItems)
-	return reflect.DeepEqual(oldItems, newItems)
 }
@@ -0,0 +1,678 @@
+package jumpcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jcapiv1 "github.com/TheJumpCloud/jcapi-go/v1"
+	jcapiv2 "github.com/TheJumpCloud/jcapi-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJumpCloudRequest(t *testing.T) {
+	cases := []struct {
+		Method         string
+		ResponseStatus int
+		OK             bool
+		ErrorNil       bool
+		Payload        []byte
+	}{
+		{http.MethodGet, http.StatusNotFound, false, true, []byte("irrelevant")},
+		{http.MethodGet, http.StatusBadRequest, false, false, []byte(`{"message":"bad request"}`)},
+		{http.MethodGet, http.StatusOK, true, true, []byte(`{"id":"123"}`)},
+		{http.MethodGet, http.StatusNoContent, true, true, nil},
+		// only a missing object to read is not an error
+		{http.MethodPost, http.StatusNotFound, false, false, []byte("irrelevant")},
+		{http.MethodPut, http.StatusNotFound, false, false, nil},
+		{http.MethodDelete, http.StatusNotFound, false, false, nil},
+		{http.MethodPost, http.StatusCreated, true, true, []byte(`{"id":"123"}`)},
+	}
+
+	for _, c := range cases {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(c.ResponseStatus)
+			rw.Write(c.Payload)
+		}))
+
+		config := newMeta(&jcapiv2.Configuration{
+			BasePath: testServer.URL,
+		})
+
+		var out struct {
+			ID string `json:"id"`
+		}
+		ok, err := jumpCloudRequest(config, c.Method, "/objects/123", nil, &out)
+		assert.Equal(t, c.OK, ok, c.Method)
+		assert.Equal(t, c.ErrorNil, err == nil, c.Method)
+		if c.Method != http.MethodGet && c.ResponseStatus == http.StatusNotFound {
+			assert.True(t, isNotFound(err))
+		}
+		testServer.Close()
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{"code":400,"message":"name is not unique","status":"INVALID_ARGUMENT"}`))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	// SDK calls
+	client := jcapiv2.NewAPIClient(config.Configuration)
+	res, err := client.UserGroupsApi.GroupsUserDelete(context.TODO(), "123", "", headerAccept, nil)
+	assert.EqualError(t, apiError(res, err),
+		"DELETE /usergroups/123: 400 Bad Request: name is not unique (code 400)")
+
+	var apiErr *jumpCloudAPIError
+	assert.True(t, errors.As(fmt.Errorf("error deleting user group: %w", apiError(res, err)), &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	// raw requests
+	_, err = jumpCloudRequest(config, http.MethodPatch, "/usergroups/123", nil, nil)
+	assert.EqualError(t, err, "PATCH /usergroups/123: 400 Bad Request: name is not unique (code 400)")
+
+	// no error response
+	transportErr := errors.New("connection refused")
+	assert.Equal(t, transportErr, apiError(nil, transportErr))
+	assert.NoError(t, apiError(nil, nil))
+
+	// other error bodies
+	for body, expected := range map[string]string{
+		`{"message":"Unauthorized"}`: "GET /systems: 401 Unauthorized: Unauthorized",
+		`{"error":"invalid filter"}`: "GET /systems: 401 Unauthorized: invalid filter",
+		"not json\n":                 "GET /systems: 401 Unauthorized: not json",
+		"":                           "GET /systems: 401 Unauthorized",
+	} {
+		res := &http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Status:     "401 Unauthorized",
+			Request:    httptest.NewRequest(http.MethodGet, "/systems", nil),
+		}
+		assert.EqualError(t, newAPIError(res, []byte(body)), expected)
+	}
+}
+
+func TestGetUserGroupMemberIDsError(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	_, err := getUserGroupMemberIDs(context.TODO(), config, "group")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "group id group at skip 0")
+
+	// the transport error is wrapped
+	var urlErr *url.Error
+	assert.True(t, errors.As(err, &urlErr))
+}
+
+func TestGetSystemGroupMemberIDs(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		// two pages, a full one and a partial one
+		count := 100
+		if r.URL.Query().Get("skip") == "100" {
+			count = 2
+		}
+
+		members := make([]string, count)
+		for i := range members {
+			members[i] = fmt.Sprintf(`{"to":{"id":"%s-%d","type":"system"}}`, r.URL.Query().Get("skip"), i)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte("[" + strings.Join(members, ",") + "]"))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	ids, err := getSystemGroupMemberIDs(context.TODO(), config, "group")
+	assert.NoError(t, err)
+	assert.Len(t, ids, 102)
+	assert.Equal(t, "100-1", ids[101])
+}
+
+func TestResolveMemberEmails(t *testing.T) {
+	found := map[string]string{
+		"alice@example.com": "1",
+		"bob@example.com":   "2",
+	}
+	emails := []string{"Bob@example.com", "carol@example.com", "alice@example.com"}
+
+	_, err := resolveMemberEmails(emails, found, "error")
+	assert.EqualError(t, err, "no JumpCloud users found for carol@example.com")
+
+	for _, behavior := range []string{"warn", "ignore"} {
+		ids, err := resolveMemberEmails(emails, found, behavior)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2", "1"}, ids)
+	}
+
+	ids, err := resolveMemberEmails(emails[:1], found, "error")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, ids)
+}
+
+func TestUserEmailsToIDsBatches(t *testing.T) {
+	emails := make([]interface{}, 500)
+	for i := range emails {
+		emails[i] = fmt.Sprintf("user%03d@example.com", i)
+	}
+
+	for _, c := range []struct {
+		BatchSize int
+		Requests  int
+	}{
+		{0, 10},   // the default of 50 per batch
+		{150, 7},  // 150, 150, 150 and 50 emails, the full batches in two pages
+		{1000, 6}, // all emails in one batch of five full pages and an empty one
+	} {
+		var requests, longestBatch int
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, "/systemusers", r.URL.Path)
+			batch := strings.Split(strings.TrimPrefix(r.URL.Query().Get("filter"), "email:$in:"), "|")
+			if len(batch) > longestBatch {
+				longestBatch = len(batch)
+			}
+			skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			end := skip + limit
+			if end > len(batch) {
+				end = len(batch)
+			}
+			users := jcapiv1.Systemuserslist{}
+			for _, email := range batch[skip:end] {
+				users.Results = append(users.Results, jcapiv1.Systemuserreturn{Id: "id-" + email, Email: email})
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			assert.NoError(t, json.NewEncoder(rw).Encode(users))
+		}))
+
+		config, err := (&Config{EmailLookupBatchSize: c.BatchSize}).Client()
+		assert.NoError(t, err)
+		config.(*Meta).BasePath = testServer.URL + "/v2"
+
+		ids, err := userEmailsToIDs(context.TODO(), config.(*Meta), emails)
+		assert.NoError(t, err)
+		assert.Len(t, ids, len(emails))
+		assert.Equal(t, "id-user000@example.com", ids[0])
+		assert.Equal(t, "id-user499@example.com", ids[499])
+		assert.Equal(t, c.Requests, requests)
+		if c.BatchSize > 0 {
+			assert.LessOrEqual(t, longestBatch, c.BatchSize)
+		} else {
+			assert.Equal(t, 50, longestBatch)
+		}
+		testServer.Close()
+	}
+}
+
+func TestUserIDsToEmailsBatches(t *testing.T) {
+	ids := make([]string, 120)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id%03d", i)
+	}
+
+	var batches []int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		batch := strings.Split(strings.TrimPrefix(r.URL.Query().Get("filter"), "_id:$in:"), "|")
+		batches = append(batches, len(batch))
+		users := jcapiv1.Systemuserslist{}
+		for _, id := range batch {
+			users.Results = append(users.Results, jcapiv1.Systemuserreturn{Id: id, Email: id + "@example.com"})
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(rw).Encode(users))
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{EmailLookupBatchSize: 50}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL + "/v2"
+
+	emails, unresolved, err := userIDsToEmails(context.TODO(), config.(*Meta), ids)
+	assert.NoError(t, err)
+	assert.Empty(t, unresolved)
+	assert.Len(t, emails, len(ids))
+	assert.Equal(t, "id000@example.com", emails[0])
+	assert.Equal(t, []int{50, 50, 20}, batches)
+}
+
+func TestUserIDsToEmailsUnresolved(t *testing.T) {
+	users := map[string]string{"id1": "alice@example.com", "id3": "carol@example.com"}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		list := jcapiv1.Systemuserslist{}
+		for _, id := range strings.Split(strings.TrimPrefix(r.URL.Query().Get("filter"), "_id:$in:"), "|") {
+			if email, ok := users[id]; ok {
+				list.Results = append(list.Results, jcapiv1.Systemuserreturn{Id: id, Email: email})
+			}
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(rw).Encode(list))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL + "/v2"
+
+	// id2 belonged to a user that was deleted while still a group member
+	emails, unresolved, err := userIDsToEmails(context.TODO(), config, []string{"id3", "id2", "id1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com", "carol@example.com"}, emails)
+	assert.Equal(t, []string{"id2"}, unresolved)
+}
+
+func TestValidateGroupDescription(t *testing.T) {
+	cases := []struct {
+		Description string
+		Valid       bool
+	}{
+		{"", true},
+		{strings.Repeat("a", maxGroupDescriptionLength), true},
+		{strings.Repeat("ä", maxGroupDescriptionLength), true},
+		{strings.Repeat("a", maxGroupDescriptionLength+1), false},
+		{"Engineering\nBackend team", true},
+		{"<script>", false},
+		{"bell\a", false},
+	}
+
+	for _, c := range cases {
+		_, errs := validateGroupDescription(c.Description, "description")
+		assert.Equal(t, c.Valid, len(errs) == 0, "%q", c.Description)
+	}
+}
+
+func TestManageGroupMembers(t *testing.T) {
+	memberIDs := make([]string, 150)
+	for i := range memberIDs {
+		memberIDs[i] = fmt.Sprintf("user%d", i)
+	}
+
+	for _, bulk := range []bool{true, false} {
+		var bulkRequests, singleRequests int
+		var managed []string
+		var mu sync.Mutex
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			switch r.URL.Path {
+			case "/bulk/usergroups/group/members":
+				if !bulk {
+					rw.WriteHeader(http.StatusNotFound)
+					return
+				}
+				var ops []jcapiv2.UserGroupMembersReq
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&ops))
+				for _, op := range ops {
+					managed = append(managed, op.Id)
+				}
+				bulkRequests++
+			case "/usergroups/group/members":
+				var op jcapiv2.UserGroupMembersReq
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&op))
+				assert.Equal(t, "add", op.Op)
+				managed = append(managed, op.Id)
+				singleRequests++
+			default:
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+
+		config := newMeta(jcapiv2.NewConfiguration())
+		config.BasePath = testServer.URL
+		d := schema.TestResourceDataRaw(t, resourceUserGroup().Schema, map[string]interface{}{})
+		d.SetId("group")
+
+		assert.NoError(t, manageGroupMembers(context.TODO(), config, d, memberIDs, "add"))
+		// members managed one by one are processed in parallel
+		assert.ElementsMatch(t, memberIDs, managed)
+		if bulk {
+			assert.Equal(t, 2, bulkRequests)
+			assert.Equal(t, 0, singleRequests)
+		} else {
+			assert.Equal(t, 150, singleRequests)
+
+			// the missing endpoint isn't probed again
+			assert.NoError(t, manageGroupMembers(context.TODO(), config, d, memberIDs[:1], "add"))
+			assert.Equal(t, 151, singleRequests)
+		}
+		testServer.Close()
+	}
+}
+
+func TestManageGroupMembersBulkFailure(t *testing.T) {
+	var singleRequests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bulk/usergroups/group/members" {
+			singleRequests++
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		// the endpoint exists, the group doesn't
+		rw.WriteHeader(http.StatusNotFound)
+		rw.Write([]byte(`{"message": "usergroup not found"}`))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+	d := schema.TestResourceDataRaw(t, resourceUserGroup().Schema, map[string]interface{}{})
+	d.SetId("group")
+
+	err := manageGroupMembers(context.TODO(), config, d, []string{"user1"}, "add")
+	assert.EqualError(t, err, "error managing group members in bulk, action: add, error: "+
+		"POST /bulk/usergroups/group/members: 404 Not Found: usergroup not found")
+	assert.Equal(t, 0, singleRequests)
+
+	// bulk operations stay enabled
+	assert.False(t, config.bulkUnsupported.Load())
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	res := func(retryAfter string) *http.Response {
+		res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		if retryAfter != "" {
+			res.Header.Set("Retry-After", retryAfter)
+		}
+		return res
+	}
+
+	assert.Equal(t, 3*time.Second, rateLimitDelay(res("3"), time.Second, 0, now))
+	assert.Equal(t, 30*time.Second, rateLimitDelay(res("Mon, 01 Jan 2024 12:00:30 GMT"), time.Second, 0, now))
+	assert.Equal(t, time.Duration(0), rateLimitDelay(res("Mon, 01 Jan 2024 11:00:00 GMT"), time.Second, 0, now))
+
+	// without Retry-After the delay doubles on every attempt, plus jitter
+	for attempt, backoff := range []time.Duration{100, 200, 400, 800} {
+		delay := rateLimitDelay(res(""), 100*time.Millisecond, attempt, now)
+		assert.GreaterOrEqual(t, delay, backoff*time.Millisecond)
+		assert.Less(t, delay, (backoff+100)*time.Millisecond)
+	}
+}
+
+func TestPageDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	res := func(remaining, limit string, reset int64) *http.Response {
+		res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		res.Header.Set("X-RateLimit-Remaining", remaining)
+		res.Header.Set("X-RateLimit-Limit", limit)
+		if reset != 0 {
+			res.Header.Set("X-RateLimit-Reset", fmt.Sprint(reset))
+		}
+		return res
+	}
+
+	// headroom left
+	assert.Equal(t, time.Duration(0), pageDelay(res("500", "1000", now.Unix()+60), time.Second, now))
+	// the remaining requests are spread over the rest of the window
+	assert.Equal(t, 6*time.Second, pageDelay(res("9", "1000", now.Unix()+60), time.Second, now))
+	assert.Equal(t, 60*time.Second, pageDelay(res("0", "1000", now.Unix()+60), time.Second, now))
+	// the reset is relative for small values
+	assert.Equal(t, 30*time.Second, pageDelay(res("1", "1000", 60), time.Second, now))
+	// no reset known
+	assert.Equal(t, time.Second, pageDelay(res("1", "1000", 0), time.Second, now))
+	// no rate limit reported
+	assert.Equal(t, defaultPageDelay, pageDelay(res("", "", 0), time.Second, now))
+	assert.Equal(t, defaultPageDelay, pageDelay(nil, time.Second, now))
+}
+
+func TestPager(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// the rate limit headers sent with the pages, the last page is partial
+	remaining := []string{"900", "50", "0", "800"}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		page := skip / pageSize
+		rw.Header().Set("X-RateLimit-Remaining", remaining[page])
+		rw.Header().Set("X-RateLimit-Limit", "1000")
+		rw.Header().Set("X-RateLimit-Reset", fmt.Sprint(now.Unix()+10))
+		if page == len(remaining)-1 {
+			rw.Write([]byte("1"))
+		} else {
+			rw.Write([]byte(fmt.Sprint(pageSize)))
+		}
+	}))
+	defer testServer.Close()
+
+	var sleeps []time.Duration
+	p := newPager(context.TODO(), newMeta(jcapiv2.NewConfiguration()))
+	p.now = func() time.Time { return now }
+	p.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+
+	var skips []int32
+	err := p.each(func(skip int32) (*http.Response, int, error) {
+		skips = append(skips, skip)
+		res, err := http.Get(fmt.Sprintf("%s?skip=%d", testServer.URL, skip))
+		if err != nil {
+			return res, 0, err
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		n, _ := strconv.Atoi(string(body))
+		return res, n, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{0, 100, 200, 300}, skips)
+	// no wait with headroom, spread out when running low, none after the last page
+	assert.Equal(t, []time.Duration{10 * time.Second / 51, 10 * time.Second}, sleeps)
+}
+
+func TestWithRateLimitRetry(t *testing.T) {
+	for _, c := range []struct {
+		RateLimited int
+		ErrorNil    bool
+	}{
+		{0, true},
+		{2, true},
+		{3, false}, // one more than max_retries
+	} {
+		var requests int
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests <= c.RateLimited {
+				rw.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.Write([]byte(`[{"to":{"id":"user","type":"user"}}]`))
+		}))
+
+		config, err := (&Config{MaxRetries: 2, RetryBaseDelayMS: 1}).Client()
+		assert.NoError(t, err)
+		config.(*Meta).BasePath = testServer.URL
+
+		ids, err := getUserGroupMemberIDs(context.TODO(), config.(*Meta), "group")
+		assert.Equal(t, c.ErrorNil, err == nil)
+		if c.ErrorNil {
+			assert.Equal(t, []string{"user"}, ids)
+		}
+		assert.Equal(t, min(c.RateLimited+1, 3), requests)
+		testServer.Close()
+	}
+
+	// the wait before a retry ends once Terraform stops the provider
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer testServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	config, err := (&Config{MaxRetries: 2, RetryBaseDelayMS: 60000, StopContext: ctx}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err = getUserGroupMemberIDs(requestContext(config.(*Meta)), config.(*Meta), "group")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, requests)
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	items := make([]string, 50)
+	for i := range items {
+		items[i] = fmt.Sprintf("item%d", i)
+	}
+
+	var running, maxRunning int32
+	var mu sync.Mutex
+	var processed []string
+	err := forEachConcurrently(context.Background(), 5, items, func(item string) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		processed = append(processed, item)
+		mu.Unlock()
+		if item == "item3" || item == "item42" {
+			return fmt.Errorf("%s failed", item)
+		}
+		return nil
+	})
+
+	assert.ElementsMatch(t, items, processed)
+	assert.LessOrEqual(t, maxRunning, int32(5))
+	// every failure is reported, not only the first one
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "item3 failed")
+	assert.Contains(t, err.Error(), "item42 failed")
+
+	// nothing is started once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls int32
+	err = forEachConcurrently(ctx, 5, items, func(string) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(0), calls)
+}
+
+func TestUserCache(t *testing.T) {
+	config, err := (&Config{CacheUserLookups: true}).Client()
+	assert.NoError(t, err)
+	configv2 := config.(*Meta)
+	cache := configv2.Settings.userCache
+	cache.add("id1", "Jane.Doe@example.com")
+	cache.add("id2", "john.doe@example.com")
+
+	// fully cached lookups don't reach the API
+	ids, err := userEmailsToIDs(context.TODO(), configv2, []interface{}{"john.doe@example.com", "jane.doe@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id2", "id1"}, ids)
+	emails, unresolved, err := userIDsToEmails(context.TODO(), configv2, []string{"id2", "id1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Jane.Doe@example.com", "john.doe@example.com"}, emails)
+	assert.Empty(t, unresolved)
+
+	cache.forget("id1")
+	_, ok := cache.id("jane.doe@example.com")
+	assert.False(t, ok)
+	_, ok = cache.email("id1")
+	assert.False(t, ok)
+
+	// the cache of configurations without one is disabled
+	var disabled *userCache
+	disabled.add("id1", "jane.doe@example.com")
+	_, ok = disabled.id("jane.doe@example.com")
+	assert.False(t, ok)
+	assert.Nil(t, newMeta(jcapiv2.NewConfiguration()).Settings.userCache)
+}
+
+func TestParseAssociationImportID(t *testing.T) {
+	fromID, toIDs, err := parseAssociationImportID("radius:group1,group2", "radius_server_id", "user_group_id")
+	assert.NoError(t, err)
+	assert.Equal(t, "radius", fromID)
+	assert.Equal(t, []string{"group1", "group2"}, toIDs)
+
+	for _, id := range []string{"radius", "radius:", ":group", "radius:group:x", "radius:group1,"} {
+		_, _, err := parseAssociationImportID(id, "radius_server_id", "user_group_id")
+		assert.Error(t, err, id)
+	}
+}
+
+func TestDirectoryIDByName(t *testing.T) {
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/directories", r.URL.Path)
+		requests++
+		if requests == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(rw).Encode([]jcapiv2.Directory{
+			{Id: "gsuite", Name: "example.com", Type_: "g_suite"},
+			{Id: "office365", Name: "example.com", Type_: "office_365"},
+		}))
+	}))
+	defer testServer.Close()
+
+	config, err := (&Config{MaxRetries: 2, RetryBaseDelayMS: 1}).Client()
+	assert.NoError(t, err)
+	config.(*Meta).BasePath = testServer.URL
+
+	// the rate limited page is retried
+	id, err := directoryIDByName(config.(*Meta), "office_365", "Office 365", "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "office365", id)
+	assert.Equal(t, 2, requests)
+
+	_, err = directoryIDByName(config.(*Meta), "office_365", "Office 365", "other.com")
+	assert.EqualError(t, err, "no Office 365 directory named other.com, connect it in the JumpCloud console first")
+}
+
+func TestGetSystemGroupMemberIDsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+		// a full page, the wait for the next one ends with the context
+		page := make([]jcapiv2.GraphConnection, 100)
+		for i := range page {
+			page[i].To = &jcapiv2.GraphObject{Id: fmt.Sprint(i)}
+		}
+		cancel()
+		assert.NoError(t, json.NewEncoder(rw).Encode(page))
+	}))
+	defer testServer.Close()
+
+	config := newMeta(jcapiv2.NewConfiguration())
+	config.BasePath = testServer.URL
+
+	_, err := getSystemGroupMemberIDs(ctx, config, "group")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, requests)
+}
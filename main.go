@@ -9,7 +9,11 @@ import (
 // Generate the Terraform provider documentation using `tfplugindocs`:
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
+// version is set by goreleaser to the version of the release
+var version = "dev"
+
 func main() {
+	jumpcloud.ProviderVersion = version
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() terraform.ResourceProvider {
 			return jumpcloud.Provider()